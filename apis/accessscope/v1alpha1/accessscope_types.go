@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Operator of a label selector requirement.
+// +kubebuilder:validation:Enum=IN;NOT_IN;EXISTS;NOT_EXISTS
+type Operator string
+
+// LabelSelectorRequirement matches labels with a key, an operator and
+// values.
+type LabelSelectorRequirement struct {
+	// Key of the label.
+	Key string `json:"key"`
+
+	// Op relating the key and the values.
+	Op Operator `json:"op"`
+
+	// Values of the label. Must be empty for EXISTS and NOT_EXISTS.
+	// +kubebuilder:validation:Optional
+	Values []string `json:"values,omitempty"`
+}
+
+// LabelSelector matches objects whose labels fulfill all requirements.
+type LabelSelector struct {
+	// Requirements of the selector.
+	Requirements []LabelSelectorRequirement `json:"requirements"`
+}
+
+// Namespace identifies a namespace of a cluster.
+type Namespace struct {
+	// ClusterName of the cluster the namespace belongs to.
+	ClusterName string `json:"clusterName"`
+
+	// NamespaceName of the namespace.
+	NamespaceName string `json:"namespaceName"`
+}
+
+// AccessScopeRules define the clusters and namespaces included in an
+// AccessScope.
+type AccessScopeRules struct {
+	// IncludedClusters lists the names of included clusters. Names resolved
+	// from IncludedClusterRefs or IncludedClusterSelector are written here.
+	// Set either literal names or a reference or selector, not both: names
+	// that are already set are not resolved again, so literal names disable
+	// the references and the selector.
+	// +kubebuilder:validation:Optional
+	IncludedClusters []string `json:"includedClusters,omitempty"`
+
+	// IncludedClusterRefs reference Cluster managed resources to retrieve
+	// the names of included clusters.
+	// +kubebuilder:validation:Optional
+	IncludedClusterRefs []xpv1.Reference `json:"includedClusterRefs,omitempty"`
+
+	// IncludedClusterSelector selects Cluster managed resources to retrieve
+	// the names of included clusters. The selector is resolved once by
+	// default. Set policy.resolve to Always to pick up clusters that are
+	// created later. The resolved names then replace IncludedClusters on
+	// every reconcile.
+	// +kubebuilder:validation:Optional
+	IncludedClusterSelector *xpv1.Selector `json:"includedClusterSelector,omitempty"`
+
+	// IncludedNamespaces lists included namespaces.
+	// +kubebuilder:validation:Optional
+	IncludedNamespaces []Namespace `json:"includedNamespaces,omitempty"`
+
+	// ClusterLabelSelectors include clusters by their labels.
+	// +kubebuilder:validation:Optional
+	ClusterLabelSelectors []LabelSelector `json:"clusterLabelSelectors,omitempty"`
+
+	// NamespaceLabelSelectors include namespaces by their labels.
+	// +kubebuilder:validation:Optional
+	NamespaceLabelSelectors []LabelSelector `json:"namespaceLabelSelectors,omitempty"`
+}
+
+// AccessScopeParameters are the configurable fields of an AccessScope.
+type AccessScopeParameters struct {
+	// Name of the access scope.
+	Name string `json:"name"`
+
+	// Description of the access scope.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description"`
+
+	// Rules of the access scope.
+	// +kubebuilder:validation:Optional
+	Rules AccessScopeRules `json:"rules"`
+}
+
+// AccessScopeObservation are the observable fields of an AccessScope.
+type AccessScopeObservation struct {
+	// ID of the access scope.
+	ID string `json:"id,omitempty"`
+}
+
+// An AccessScopeSpec defines the desired state of an AccessScope.
+type AccessScopeSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AccessScopeParameters `json:"forProvider"`
+}
+
+// An AccessScopeStatus represents the observed state of an AccessScope.
+type AccessScopeStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AccessScopeObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AccessScope restricts a Role to clusters and namespaces.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type AccessScope struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccessScopeSpec   `json:"spec"`
+	Status AccessScopeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccessScopeList contains a list of AccessScope
+type AccessScopeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessScope `json:"items"`
+}
+
+// AccessScope type metadata.
+var (
+	AccessScopeKind             = reflect.TypeOf(AccessScope{}).Name()
+	AccessScopeGroupKind        = schema.GroupKind{Group: Group, Kind: AccessScopeKind}.String()
+	AccessScopeKindAPIVersion   = AccessScopeKind + "." + SchemeGroupVersion.String()
+	AccessScopeGroupVersionKind = SchemeGroupVersion.WithKind(AccessScopeKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&AccessScope{}, &AccessScopeList{})
+}
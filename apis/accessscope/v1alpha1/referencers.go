@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	clusterv1alpha1 "github.com/stehessel/provider-stackrox/apis/cluster/v1alpha1"
+)
+
+// ClusterName extracts the Central name of a Cluster.
+func ClusterName() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*clusterv1alpha1.Cluster)
+		if !ok {
+			return ""
+		}
+		return cr.Spec.ForProvider.Name
+	}
+}
+
+// ResolveReferences of this AccessScope. The included clusters are resolved
+// only while IncludedClusters is empty, unless the selector or a reference
+// uses the Always resolve policy.
+func (mg *AccessScope) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.ForProvider.Rules.IncludedClusters,
+		References:    mg.Spec.ForProvider.Rules.IncludedClusterRefs,
+		Selector:      mg.Spec.ForProvider.Rules.IncludedClusterSelector,
+		To:            reference.To{Managed: &clusterv1alpha1.Cluster{}, List: &clusterv1alpha1.ClusterList{}},
+		Extract:       ClusterName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.rules.includedClusters")
+	}
+	mg.Spec.ForProvider.Rules.IncludedClusters = rsp.ResolvedValues
+	mg.Spec.ForProvider.Rules.IncludedClusterRefs = rsp.ResolvedReferences
+
+	return nil
+}
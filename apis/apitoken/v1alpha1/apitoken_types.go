@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// APITokenParameters are the configurable fields of an APIToken. Tokens
+// cannot be modified, so changing a parameter revokes the token and
+// generates a new one.
+type APITokenParameters struct {
+	// Name of the API token.
+	Name string `json:"name"`
+
+	// Roles granted to the API token.
+	// +kubebuilder:validation:MinItems=1
+	Roles []string `json:"roles"`
+}
+
+// APITokenObservation are the observable fields of an APIToken.
+type APITokenObservation struct {
+	// ID of the API token.
+	ID string `json:"id,omitempty"`
+
+	// Name of the API token.
+	Name string `json:"name,omitempty"`
+
+	// Roles granted to the API token.
+	Roles []string `json:"roles,omitempty"`
+
+	// IssuedAt timestamp of the API token.
+	IssuedAt *metav1.Time `json:"issuedAt,omitempty"`
+
+	// Expiration timestamp of the API token.
+	Expiration *metav1.Time `json:"expiration,omitempty"`
+
+	// Revoked is true if the API token has been revoked.
+	Revoked bool `json:"revoked,omitempty"`
+}
+
+// An APITokenSpec defines the desired state of an APIToken.
+type APITokenSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       APITokenParameters `json:"forProvider"`
+}
+
+// An APITokenStatus represents the observed state of an APIToken.
+type APITokenStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          APITokenObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An APIToken is a token to access the Central API. The token is published
+// as connection secret.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="EXPIRATION",type="string",JSONPath=".status.atProvider.expiration"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type APIToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APITokenSpec   `json:"spec"`
+	Status APITokenStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APITokenList contains a list of APIToken
+type APITokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIToken `json:"items"`
+}
+
+// APIToken type metadata.
+var (
+	APITokenKind             = reflect.TypeOf(APIToken{}).Name()
+	APITokenGroupKind        = schema.GroupKind{Group: Group, Kind: APITokenKind}.String()
+	APITokenKindAPIVersion   = APITokenKind + "." + SchemeGroupVersion.String()
+	APITokenGroupVersionKind = SchemeGroupVersion.WithKind(APITokenKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&APIToken{}, &APITokenList{})
+}
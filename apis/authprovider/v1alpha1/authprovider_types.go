@@ -0,0 +1,236 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AnnotationKeySecretHash records the hash of the client secret an auth
+// provider was created with. The status written on creation is not persisted,
+// so it is recorded as an annotation.
+const AnnotationKeySecretHash = "authprovider.stackrox.crossplane.io/secret-hash"
+
+// CallbackMode of an OIDC auth provider.
+// +kubebuilder:validation:Enum=auto;post;query;fragment
+type CallbackMode string
+
+// OIDC callback modes.
+const (
+	CallbackModeAuto     CallbackMode = "auto"
+	CallbackModePost     CallbackMode = "post"
+	CallbackModeQuery    CallbackMode = "query"
+	CallbackModeFragment CallbackMode = "fragment"
+)
+
+// OIDCConfig configures an OpenID Connect auth provider.
+type OIDCConfig struct {
+	// Issuer URL of the OpenID Connect provider.
+	Issuer string `json:"issuer"`
+
+	// ClientID registered with the OpenID Connect provider.
+	ClientID string `json:"clientID"`
+
+	// ClientSecretSecretRef references the client secret. Public clients
+	// without a client secret are used if unset.
+	// +kubebuilder:validation:Optional
+	ClientSecretSecretRef *xpv1.SecretKeySelector `json:"clientSecretSecretRef,omitempty"`
+
+	// CallbackMode determines how the provider returns the authentication
+	// response to Central. The mode is selected by Central if set to auto.
+	// +kubebuilder:default=auto
+	// +kubebuilder:validation:Optional
+	CallbackMode CallbackMode `json:"callbackMode"`
+
+	// DisableOfflineAccessScope prevents Central from requesting the
+	// offline_access scope.
+	// +kubebuilder:validation:Optional
+	DisableOfflineAccessScope bool `json:"disableOfflineAccessScope"`
+}
+
+// SAMLStaticConfig configures the identity provider of a SAML auth provider
+// without fetching its metadata.
+type SAMLStaticConfig struct {
+	// IDPIssuer of the identity provider.
+	IDPIssuer string `json:"idpIssuer"`
+
+	// IDPSSOURL is the single sign-on URL of the identity provider.
+	IDPSSOURL string `json:"idpSSOURL"`
+
+	// IDPCertPEM contains the certificates of the identity provider in PEM
+	// format.
+	IDPCertPEM string `json:"idpCertPEM"`
+
+	// IDPNameIDFormat requested from the identity provider.
+	// +kubebuilder:validation:Optional
+	IDPNameIDFormat string `json:"idpNameIDFormat"`
+}
+
+// SAMLConfig configures a SAML 2.0 auth provider. Exactly one of
+// idpMetadataURL and static must be set.
+type SAMLConfig struct {
+	// SPIssuer is the service provider issuer, i.e. the audience of the
+	// SAML assertions.
+	SPIssuer string `json:"spIssuer"`
+
+	// IDPMetadataURL from which Central fetches the identity provider
+	// metadata.
+	// +kubebuilder:validation:Optional
+	IDPMetadataURL string `json:"idpMetadataURL"`
+
+	// Static configures the identity provider without metadata URL.
+	// +kubebuilder:validation:Optional
+	Static *SAMLStaticConfig `json:"static,omitempty"`
+}
+
+// OpenShiftConfig configures an OpenShift OAuth auth provider. Central uses
+// the OAuth server of the OpenShift cluster it runs on, so no further
+// settings are required.
+type OpenShiftConfig struct{}
+
+// RequiredAttribute is an attribute every user must have to log in.
+type RequiredAttribute struct {
+	// Key of the attribute.
+	Key string `json:"key"`
+
+	// Value the attribute must have.
+	Value string `json:"value"`
+}
+
+// AuthProviderParameters are the configurable fields of an AuthProvider.
+// Exactly one of oidc, saml and openshift must be set.
+type AuthProviderParameters struct {
+	// Name of the auth provider.
+	Name string `json:"name"`
+
+	// UIEndpoint is the address of Central used for callbacks of the
+	// auth provider.
+	UIEndpoint string `json:"uiEndpoint"`
+
+	// ExtraUIEndpoints are additional addresses of Central that may be used
+	// for callbacks.
+	// +kubebuilder:validation:Optional
+	ExtraUIEndpoints []string `json:"extraUIEndpoints,omitempty"`
+
+	// Enabled allows users to log in with the auth provider.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+
+	// RequiredAttributes users must have to log in.
+	// +kubebuilder:validation:Optional
+	RequiredAttributes []RequiredAttribute `json:"requiredAttributes,omitempty"`
+
+	// ClaimMappings copy claims of the identity provider token to user
+	// attributes. Keys are paths in the token separated by ".", values are
+	// the names of the resulting attributes.
+	// +kubebuilder:validation:Optional
+	ClaimMappings map[string]string `json:"claimMappings,omitempty"`
+
+	// OIDC configures an OpenID Connect auth provider.
+	// +kubebuilder:validation:Optional
+	OIDC *OIDCConfig `json:"oidc,omitempty"`
+
+	// SAML configures a SAML 2.0 auth provider.
+	// +kubebuilder:validation:Optional
+	SAML *SAMLConfig `json:"saml,omitempty"`
+
+	// OpenShift configures an OpenShift OAuth auth provider.
+	// +kubebuilder:validation:Optional
+	OpenShift *OpenShiftConfig `json:"openshift,omitempty"`
+}
+
+// AuthProviderObservation are the observable fields of an AuthProvider.
+type AuthProviderObservation struct {
+	// ID of the auth provider.
+	ID string `json:"id,omitempty"`
+
+	// Type of the auth provider.
+	Type string `json:"type,omitempty"`
+
+	// LoginURL of the auth provider.
+	LoginURL string `json:"loginURL,omitempty"`
+
+	// Validated is true once a user logged in successfully with the auth
+	// provider.
+	Validated bool `json:"validated,omitempty"`
+
+	// Active is true once the auth provider was used to log in.
+	Active bool `json:"active,omitempty"`
+
+	// SecretHash is the hash of the client secret last written to Central.
+	// Central masks the client secret in its responses, so a changed secret
+	// is detected by comparing it.
+	SecretHash string `json:"secretHash,omitempty"`
+}
+
+// An AuthProviderSpec defines the desired state of an AuthProvider.
+type AuthProviderSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AuthProviderParameters `json:"forProvider"`
+}
+
+// An AuthProviderStatus represents the observed state of an AuthProvider.
+type AuthProviderStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AuthProviderObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AuthProvider allows users to log in to Central with an identity provider.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="ACTIVE",type="boolean",JSONPath=".status.atProvider.active"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type AuthProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AuthProviderSpec   `json:"spec"`
+	Status AuthProviderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AuthProviderList contains a list of AuthProvider
+type AuthProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AuthProvider `json:"items"`
+}
+
+// AuthProvider type metadata.
+var (
+	AuthProviderKind             = reflect.TypeOf(AuthProvider{}).Name()
+	AuthProviderGroupKind        = schema.GroupKind{Group: Group, Kind: AuthProviderKind}.String()
+	AuthProviderKindAPIVersion   = AuthProviderKind + "." + SchemeGroupVersion.String()
+	AuthProviderGroupVersionKind = SchemeGroupVersion.WithKind(AuthProviderKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&AuthProvider{}, &AuthProviderList{})
+}
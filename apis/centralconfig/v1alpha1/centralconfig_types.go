@@ -0,0 +1,227 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AnnotationKeyPreviousConfig records the values of the managed fields as
+// they were before the CentralConfig took over, so that they can be restored
+// on deletion.
+const AnnotationKeyPreviousConfig = "centralconfig.stackrox.crossplane.io/previous-config"
+
+// LoginNotice is shown on the login page.
+type LoginNotice struct {
+	// Enabled shows the login notice.
+	Enabled bool `json:"enabled"`
+
+	// Text of the login notice.
+	// +kubebuilder:validation:Optional
+	Text string `json:"text,omitempty"`
+}
+
+// BannerConfig configures a banner shown on every page.
+type BannerConfig struct {
+	// Enabled shows the banner.
+	Enabled bool `json:"enabled"`
+
+	// Text of the banner.
+	// +kubebuilder:validation:Optional
+	Text string `json:"text,omitempty"`
+
+	// Size of the banner text. Central picks a default if unset.
+	// +kubebuilder:validation:Enum=SMALL;MEDIUM;LARGE
+	// +kubebuilder:validation:Optional
+	Size string `json:"size,omitempty"`
+
+	// Color of the banner text.
+	// +kubebuilder:validation:Optional
+	Color string `json:"color,omitempty"`
+
+	// BackgroundColor of the banner.
+	// +kubebuilder:validation:Optional
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+}
+
+// TelemetryConfig configures the collection of telemetry data.
+type TelemetryConfig struct {
+	// Enabled turns on telemetry collection.
+	Enabled bool `json:"enabled"`
+}
+
+// PublicConfig is the configuration visible to all users. Unset fields are
+// not managed.
+type PublicConfig struct {
+	// +kubebuilder:validation:Optional
+	LoginNotice *LoginNotice `json:"loginNotice,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Header *BannerConfig `json:"header,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Footer *BannerConfig `json:"footer,omitempty"`
+
+	// Telemetry is configured through Central's telemetry service.
+	// +kubebuilder:validation:Optional
+	Telemetry *TelemetryConfig `json:"telemetry,omitempty"`
+}
+
+// AlertRetentionConfig configures how long alerts are retained, in days. A
+// value of 0 retains alerts forever.
+type AlertRetentionConfig struct {
+	// ResolvedDeployRetentionDurationDays applies to resolved deploy time
+	// alerts.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	ResolvedDeployRetentionDurationDays int32 `json:"resolvedDeployRetentionDurationDays"`
+
+	// DeletedRuntimeRetentionDurationDays applies to runtime alerts of
+	// deleted deployments.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	DeletedRuntimeRetentionDurationDays int32 `json:"deletedRuntimeRetentionDurationDays"`
+
+	// AllRuntimeRetentionDurationDays applies to all runtime alerts and takes
+	// precedence over the other runtime retentions.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AllRuntimeRetentionDurationDays int32 `json:"allRuntimeRetentionDurationDays"`
+
+	// AttemptedDeployRetentionDurationDays applies to attempted deploy time
+	// alerts.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AttemptedDeployRetentionDurationDays int32 `json:"attemptedDeployRetentionDurationDays"`
+
+	// AttemptedRuntimeRetentionDurationDays applies to attempted runtime
+	// alerts.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AttemptedRuntimeRetentionDurationDays int32 `json:"attemptedRuntimeRetentionDurationDays"`
+}
+
+// DecommissionedClusterRetentionConfig configures how long clusters that
+// stopped reporting are kept.
+type DecommissionedClusterRetentionConfig struct {
+	// RetentionDurationDays after which decommissioned clusters are removed.
+	// A value of 0 keeps them forever.
+	// +kubebuilder:validation:Minimum=0
+	RetentionDurationDays int32 `json:"retentionDurationDays"`
+
+	// IgnoreClusterLabels exempts clusters with any of these labels from
+	// removal.
+	// +kubebuilder:validation:Optional
+	IgnoreClusterLabels map[string]string `json:"ignoreClusterLabels,omitempty"`
+}
+
+// PrivateConfig is the configuration visible to administrators. Unset fields
+// are not managed.
+type PrivateConfig struct {
+	// +kubebuilder:validation:Optional
+	AlertRetention *AlertRetentionConfig `json:"alertRetention,omitempty"`
+
+	// ImageRetentionDurationDays after which unused images are removed.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	ImageRetentionDurationDays *int32 `json:"imageRetentionDurationDays,omitempty"`
+
+	// ExpiredVulnRequestRetentionDurationDays after which expired
+	// vulnerability requests are removed.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	ExpiredVulnRequestRetentionDurationDays *int32 `json:"expiredVulnRequestRetentionDurationDays,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	DecommissionedClusterRetention *DecommissionedClusterRetentionConfig `json:"decommissionedClusterRetention,omitempty"`
+}
+
+// CentralConfigParameters are the configurable fields of a CentralConfig.
+type CentralConfigParameters struct {
+	// +kubebuilder:validation:Optional
+	PublicConfig *PublicConfig `json:"publicConfig,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	PrivateConfig *PrivateConfig `json:"privateConfig,omitempty"`
+}
+
+// CentralConfigObservation are the observable fields of a CentralConfig.
+type CentralConfigObservation struct {
+	// PublicConfig as currently set in Central.
+	PublicConfig *PublicConfig `json:"publicConfig,omitempty"`
+
+	// PrivateConfig as currently set in Central.
+	PrivateConfig *PrivateConfig `json:"privateConfig,omitempty"`
+}
+
+// A CentralConfigSpec defines the desired state of a CentralConfig.
+type CentralConfigSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CentralConfigParameters `json:"forProvider"`
+}
+
+// A CentralConfigStatus represents the observed state of a CentralConfig.
+type CentralConfigStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CentralConfigObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CentralConfig manages the system configuration of a Central. The
+// configuration is a singleton, so only one CentralConfig should reference a
+// given ProviderConfig. Deleting a CentralConfig with the Delete deletion
+// policy restores the values the managed fields had before it took over, the
+// Orphan deletion policy leaves the current values in place.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type CentralConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CentralConfigSpec   `json:"spec"`
+	Status CentralConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CentralConfigList contains a list of CentralConfig
+type CentralConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CentralConfig `json:"items"`
+}
+
+// CentralConfig type metadata.
+var (
+	CentralConfigKind             = reflect.TypeOf(CentralConfig{}).Name()
+	CentralConfigGroupKind        = schema.GroupKind{Group: Group, Kind: CentralConfigKind}.String()
+	CentralConfigKindAPIVersion   = CentralConfigKind + "." + SchemeGroupVersion.String()
+	CentralConfigGroupVersionKind = SchemeGroupVersion.WithKind(CentralConfigKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CentralConfig{}, &CentralConfigList{})
+}
@@ -23,54 +23,170 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
 )
 
-// ClusterParameters are the configurable fields of a Cluster.
-type ClusterParameters struct {
+// Annotations that trigger operations on the sensor of a secured cluster
+// whenever their value changes, e.g. when they are set to the current
+// timestamp.
+const (
+	// AnnotationKeyUpgrade triggers an upgrade of the sensor to the version
+	// of Central.
+	AnnotationKeyUpgrade = "cluster.stackrox.crossplane.io/upgrade"
+
+	// AnnotationKeyRotateCerts triggers a rotation of the sensor
+	// certificates.
+	AnnotationKeyRotateCerts = "cluster.stackrox.crossplane.io/rotate-certs"
+
+	// AnnotationKeyGenerateBundle triggers the generation of a new sensor
+	// deployment bundle, e.g. when the certificates of the last one expire.
+	AnnotationKeyGenerateBundle = "cluster.stackrox.crossplane.io/generate-bundle"
+)
+
+// Annotations that record the values of the upgrade and rotate-certs
+// annotations when the cluster was created. A new cluster has no sensor yet,
+// so these triggers are consumed without acting on them. The status written on
+// creation is not persisted, so they are recorded as annotations.
+const (
+	AnnotationKeyConsumedUpgrade     = "cluster.stackrox.crossplane.io/consumed-upgrade"
+	AnnotationKeyConsumedRotateCerts = "cluster.stackrox.crossplane.io/consumed-rotate-certs"
+)
+
+// AdmissionControllerConfig configures the enforcement of the admission
+// controller at runtime.
+type AdmissionControllerConfig struct {
+	// Enabled enforces policies on object creations.
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+
+	// TimeoutSeconds the admission controller waits for an evaluation.
+	// Central owns the timeout if it is 0 and sets it to its default.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	TimeoutSeconds int32 `json:"timeoutSeconds" stackrox:"serverOwned"`
+
+	// ScanInline scans images that have no scan yet during admission.
+	// +kubebuilder:validation:Optional
+	ScanInline bool `json:"scanInline"`
+
+	// DisableBypass rejects the bypass annotation on deployments.
+	// +kubebuilder:validation:Optional
+	DisableBypass bool `json:"disableBypass"`
+
+	// EnforceOnUpdates enforces policies on object updates.
+	// +kubebuilder:validation:Optional
+	EnforceOnUpdates bool `json:"enforceOnUpdates"`
+}
+
+// DynamicConfig is the configuration that sensor picks up without a
+// redeployment.
+type DynamicConfig struct {
+	// +kubebuilder:validation:Optional
+	AdmissionControllerConfig AdmissionControllerConfig `json:"admissionControllerConfig"`
+
+	// RegistryOverride replaces the registry of the images of the secured
+	// cluster services.
+	// +kubebuilder:validation:Optional
+	RegistryOverride string `json:"registryOverride"`
+
+	// DisableAuditLogs disables the collection of Kubernetes audit logs.
+	// Central owns this field for clusters other than OPENSHIFT4_CLUSTER and
+	// always disables their audit logs.
+	// +kubebuilder:validation:Optional
+	DisableAuditLogs bool `json:"disableAuditLogs"`
+}
+
+// SensorBundle configures the sensor deployment bundle of a cluster. The files
+// of the bundle are published as connection details once the cluster is
+// created and whenever the generate-bundle annotation changes, provided that
+// a connection secret or store is configured.
+type SensorBundle struct {
+	// CreateUpgraderSA creates the service account of the sensor upgrader.
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
-	AdmissionController bool `json:"admissionController"`
+	CreateUpgraderSA *bool `json:"createUpgraderSA,omitempty"`
+
+	// IstioVersion the bundle is rendered for, if Istio is used.
+	// +kubebuilder:validation:Optional
+	IstioVersion string `json:"istioVersion,omitempty"`
+
+	// DisablePodSecurityPolicies omits the pod security policies, which are
+	// not supported since Kubernetes 1.25.
+	// +kubebuilder:validation:Optional
+	DisablePodSecurityPolicies bool `json:"disablePodSecurityPolicies,omitempty"`
+}
+
+// ClusterParameters are the configurable fields of a Cluster. Unset fields
+// of a new cluster take the documented defaults, unset fields of an imported
+// cluster are late-initialized from Central.
+type ClusterParameters struct {
+	// AdmissionController deploys the admission controller. Defaults to
+	// true.
+	// +kubebuilder:validation:Optional
+	AdmissionController *bool `json:"admissionController,omitempty"`
 
-	// +kubebuilder:default=false
+	// AdmissionControllerEvents enforces policies on Kubernetes events.
+	// Defaults to false.
 	// +kubebuilder:validation:Optional
-	AdmissionControllerEvents bool `json:"admissionControllerEvents"`
+	AdmissionControllerEvents *bool `json:"admissionControllerEvents,omitempty"`
 
-	// +kubebuilder:default=false
+	// AdmissionControllerUpdates enforces policies on object updates.
+	// Defaults to false.
 	// +kubebuilder:validation:Optional
-	AdmissionControllerUpdates bool `json:"admissionControllerUpdates"`
+	AdmissionControllerUpdates *bool `json:"admissionControllerUpdates,omitempty"`
 
-	CentralAPIEndpoint string `json:"centralAPIEndpoint"`
+	// CentralAPIEndpoint the sensor connects to. Central strips the
+	// scheme and defaults to central.stackrox:443.
+	// +kubebuilder:validation:Optional
+	CentralAPIEndpoint string `json:"centralAPIEndpoint,omitempty"`
 
-	// +kubebuilder:default=EBPF
+	// CollectionMethod of the collector. Defaults to EBPF. Central stores
+	// UNSET_COLLECTION as KERNEL_MODULE.
 	// +kubebuilder:validation:Enum=UNSET_COLLECTION;NO_COLLECTION;KERNEL_MODULE;EBPF
 	// +kubebuilder:validation:Optional
-	CollectionMethod string `json:"collectionMethod"`
+	CollectionMethod string `json:"collectionMethod,omitempty"`
 
-	// +kubebuilder:default=registry.redhat.io/advanced-cluster-security/rhacs-collector-rhel8
+	// CollectorImage defaults to
+	// registry.redhat.io/advanced-cluster-security/rhacs-collector-rhel8.
 	// +kubebuilder:validation:Optional
-	CollectorImage string `json:"collectorImage"`
+	CollectorImage string `json:"collectorImage,omitempty"`
 
+	// DynamicConfig of the cluster.
 	// +kubebuilder:validation:Optional
-	Labels map[string]string `json:"labels"`
+	DynamicConfig *DynamicConfig `json:"dynamicConfig,omitempty"`
 
-	// +kubebuilder:default=registry.redhat.io/advanced-cluster-security/rhacs-main-rhel8
 	// +kubebuilder:validation:Optional
-	MainImage string `json:"mainImage"`
+	Labels map[string]string `json:"labels,omitempty"`
 
-	Name string `json:"name"`
+	// MainImage defaults to
+	// registry.redhat.io/advanced-cluster-security/rhacs-main-rhel8.
+	// +kubebuilder:validation:Optional
+	MainImage string `json:"mainImage,omitempty"`
 
-	// +kubebuilder:default=true
+	// Name of the cluster. It is required to create a cluster and is
+	// late-initialized for clusters imported by their ID.
 	// +kubebuilder:validation:Optional
-	SlimCollector bool `json:"slimCollector"`
+	Name string `json:"name,omitempty"`
 
-	// +kubebuilder:default=true
+	// SensorBundle configures the published sensor deployment bundle.
+	// +kubebuilder:validation:Optional
+	SensorBundle *SensorBundle `json:"sensorBundle,omitempty"`
+
+	// SlimCollector deploys the collector without built-in kernel support.
+	// Defaults to true.
 	// +kubebuilder:validation:Optional
-	Tolerations bool `json:"tolerations"`
+	SlimCollector *bool `json:"slimCollector,omitempty"`
 
-	// +kubebuilder:default=GENERIC_CLUSTER
+	// Tolerations deploys the collector with tolerations for tainted nodes.
+	// Defaults to true.
+	// +kubebuilder:validation:Optional
+	Tolerations *bool `json:"tolerations,omitempty"`
+
+	// Type of the cluster. Defaults to GENERIC_CLUSTER.
 	// +kubebuilder:validation:Enum=GENERIC_CLUSTER;KUBERNETES_CLUSTER;OPENSHIFT_CLUSTER;OPENSHIFT4_CLUSTER
 	// +kubebuilder:validation:Optional
-	Type string `json:"type"`
+	Type string `json:"type,omitempty"`
 }
 
 // SensorDeployment contains information about the last Sensor connected to the cluster.
@@ -83,6 +199,124 @@ type SensorDeployment struct {
 	SystemNamespaceID   string `json:"systemNamespaceID,omitempty"`
 }
 
+// UpgradeProcess is an upgrade or certificate rotation of the sensor.
+type UpgradeProcess struct {
+	// ID of the process.
+	ID string `json:"id,omitempty"`
+
+	// Type of the process, either UPGRADE or CERT_ROTATION.
+	Type string `json:"type,omitempty"`
+
+	// Active is true while the process runs.
+	Active bool `json:"active,omitempty"`
+
+	// TargetVersion of an upgrade.
+	TargetVersion string `json:"targetVersion,omitempty"`
+
+	// InitiatedAt is the time the process was triggered.
+	InitiatedAt *metav1.Time `json:"initiatedAt,omitempty"`
+
+	// State of the process.
+	State string `json:"state,omitempty"`
+
+	// Detail of the state.
+	Detail string `json:"detail,omitempty"`
+
+	// Since is the time the process entered its state.
+	Since *metav1.Time `json:"since,omitempty"`
+}
+
+// UpgradeStatus of the sensor of a cluster.
+type UpgradeStatus struct {
+	// Upgradability of the sensor.
+	Upgradability string `json:"upgradability,omitempty"`
+
+	// UpgradabilityStatusReason explains the upgradability.
+	UpgradabilityStatusReason string `json:"upgradabilityStatusReason,omitempty"`
+
+	// MostRecentProcess is the last upgrade or certificate rotation.
+	MostRecentProcess *UpgradeProcess `json:"mostRecentProcess,omitempty"`
+}
+
+// CollectorHealthInfo is the health of the collector pods of a cluster.
+type CollectorHealthInfo struct {
+	// Version of the collector.
+	Version string `json:"version,omitempty"`
+
+	// TotalDesiredPods of the collector daemon set.
+	TotalDesiredPods *int32 `json:"totalDesiredPods,omitempty"`
+
+	// TotalReadyPods of the collector daemon set.
+	TotalReadyPods *int32 `json:"totalReadyPods,omitempty"`
+
+	// TotalRegisteredNodes of the cluster.
+	TotalRegisteredNodes *int32 `json:"totalRegisteredNodes,omitempty"`
+
+	// StatusErrors that occurred while the health was collected.
+	StatusErrors []string `json:"statusErrors,omitempty"`
+}
+
+// ClusterHealthStatus is the health of the secured cluster services. Each
+// status is one of UNINITIALIZED, UNAVAILABLE, UNHEALTHY, DEGRADED and
+// HEALTHY.
+type ClusterHealthStatus struct {
+	// OverallHealthStatus of the cluster.
+	OverallHealthStatus string `json:"overallHealthStatus,omitempty"`
+
+	// SensorHealthStatus of the cluster.
+	SensorHealthStatus string `json:"sensorHealthStatus,omitempty"`
+
+	// CollectorHealthStatus of the cluster.
+	CollectorHealthStatus string `json:"collectorHealthStatus,omitempty"`
+
+	// AdmissionControlHealthStatus of the cluster.
+	AdmissionControlHealthStatus string `json:"admissionControlHealthStatus,omitempty"`
+
+	// LastContact is the last time sensor contacted Central.
+	LastContact *metav1.Time `json:"lastContact,omitempty"`
+
+	// CollectorHealthInfo of the cluster.
+	CollectorHealthInfo *CollectorHealthInfo `json:"collectorHealthInfo,omitempty"`
+}
+
+// StaticConfig is the configuration that is rendered into the deployment of
+// the secured cluster services.
+type StaticConfig struct {
+	Type string `json:"type,omitempty"`
+
+	MainImage string `json:"mainImage,omitempty"`
+
+	CentralAPIEndpoint string `json:"centralAPIEndpoint,omitempty"`
+
+	CollectionMethod string `json:"collectionMethod,omitempty"`
+
+	CollectorImage string `json:"collectorImage,omitempty"`
+
+	AdmissionController bool `json:"admissionController,omitempty"`
+
+	AdmissionControllerUpdates bool `json:"admissionControllerUpdates,omitempty"`
+
+	AdmissionControllerEvents bool `json:"admissionControllerEvents,omitempty"`
+
+	Tolerations bool `json:"tolerations,omitempty"`
+
+	SlimCollector bool `json:"slimCollector,omitempty"`
+}
+
+// HelmConfig is the configuration of a cluster that is managed by a Helm
+// chart or the operator.
+type HelmConfig struct {
+	// ConfigFingerprint of the rendered configuration.
+	ConfigFingerprint string `json:"configFingerprint,omitempty"`
+
+	// ClusterLabels set by the chart.
+	ClusterLabels map[string]string `json:"clusterLabels,omitempty"`
+
+	StaticConfig *StaticConfig `json:"staticConfig,omitempty"`
+
+	DynamicConfig *DynamicConfig `json:"dynamicConfig,omitempty"`
+}
+
 // ClusterObservation are the observable fields of a Cluster.
 type ClusterObservation struct {
 	AdmissionController bool `json:"admissionController,omitempty"`
@@ -98,6 +332,12 @@ type ClusterObservation struct {
 
 	CollectorImage string `json:"collectorImage,omitempty"`
 
+	DynamicConfig *DynamicConfig `json:"dynamicConfig,omitempty"`
+
+	// HelmConfig is only present if the cluster is managed by a Helm chart
+	// or the operator.
+	HelmConfig *HelmConfig `json:"helmConfig,omitempty"`
+
 	ID string `json:"id,omitempty"`
 
 	InitBundleID string `json:"initBundleID,omitempty"`
@@ -113,18 +353,50 @@ type ClusterObservation struct {
 
 	Name string `json:"name,omitempty"`
 
+	// Priority is the risk rank of the cluster computed by Central.
+	Priority int64 `json:"priority,omitempty"`
+
 	SlimCollector bool `json:"slimCollector,omitempty"`
 
 	Tolerations bool `json:"tolerations,omitempty"`
 
 	// +kubebuilder:validation:Enum=GENERIC_CLUSTER;KUBERNETES_CLUSTER;OPENSHIFT_CLUSTER;OPENSHIFT4_CLUSTER
 	Type string `json:"type,omitempty"`
+
+	// HealthStatus of the secured cluster services.
+	HealthStatus *ClusterHealthStatus `json:"healthStatus,omitempty"`
+
+	// UpgradeStatus of the sensor.
+	UpgradeStatus *UpgradeStatus `json:"upgradeStatus,omitempty"`
+
+	// LastUpgrade is the value of the upgrade annotation of the last
+	// triggered upgrade.
+	LastUpgrade string `json:"lastUpgrade,omitempty"`
+
+	// LastCertRotation is the value of the rotate-certs annotation of the
+	// last triggered certificate rotation.
+	LastCertRotation string `json:"lastCertRotation,omitempty"`
+
+	// LastBundle is the value of the generate-bundle annotation of the last
+	// published sensor deployment bundle.
+	LastBundle string `json:"lastBundle,omitempty"`
+
+	// BundleGeneratedAt is the time the last sensor deployment bundle was
+	// published.
+	BundleGeneratedAt *metav1.Time `json:"bundleGeneratedAt,omitempty"`
 }
 
 // A ClusterSpec defines the desired state of a Cluster.
 type ClusterSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       ClusterParameters `json:"forProvider"`
+
+	// ManagementPolicies are the actions that the provider may take on the
+	// external cluster. They are only honored if the provider runs with
+	// --enable-management-policies.
+	// +kubebuilder:default={"*"}
+	// +optional
+	ManagementPolicies apisv1alpha1.ManagementPolicies `json:"managementPolicies,omitempty"`
 }
 
 // A ClusterStatus represents the observed state of a Cluster.
@@ -139,6 +411,7 @@ type ClusterStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="HEALTH",type="string",JSONPath=".status.atProvider.healthStatus.overallHealthStatus"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
@@ -150,6 +423,16 @@ type Cluster struct {
 	Status ClusterStatus `json:"status,omitempty"`
 }
 
+// GetManagementPolicies of this Cluster.
+func (mg *Cluster) GetManagementPolicies() apisv1alpha1.ManagementPolicies {
+	return mg.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies of this Cluster.
+func (mg *Cluster) SetManagementPolicies(p apisv1alpha1.ManagementPolicies) {
+	mg.Spec.ManagementPolicies = p
+}
+
 // +kubebuilder:object:root=true
 
 // ClusterList contains a list of Cluster
@@ -22,9 +22,25 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionControllerConfig) DeepCopyInto(out *AdmissionControllerConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControllerConfig.
+func (in *AdmissionControllerConfig) DeepCopy() *AdmissionControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -52,6 +68,30 @@ func (in *Cluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHealthStatus) DeepCopyInto(out *ClusterHealthStatus) {
+	*out = *in
+	if in.LastContact != nil {
+		in, out := &in.LastContact, &out.LastContact
+		*out = (*in).DeepCopy()
+	}
+	if in.CollectorHealthInfo != nil {
+		in, out := &in.CollectorHealthInfo, &out.CollectorHealthInfo
+		*out = new(CollectorHealthInfo)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHealthStatus.
+func (in *ClusterHealthStatus) DeepCopy() *ClusterHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -87,6 +127,16 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterObservation) DeepCopyInto(out *ClusterObservation) {
 	*out = *in
+	if in.DynamicConfig != nil {
+		in, out := &in.DynamicConfig, &out.DynamicConfig
+		*out = new(DynamicConfig)
+		**out = **in
+	}
+	if in.HelmConfig != nil {
+		in, out := &in.HelmConfig, &out.HelmConfig
+		*out = new(HelmConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -95,6 +145,20 @@ func (in *ClusterObservation) DeepCopyInto(out *ClusterObservation) {
 		}
 	}
 	out.MostRecentSensor = in.MostRecentSensor
+	if in.HealthStatus != nil {
+		in, out := &in.HealthStatus, &out.HealthStatus
+		*out = new(ClusterHealthStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStatus != nil {
+		in, out := &in.UpgradeStatus, &out.UpgradeStatus
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BundleGeneratedAt != nil {
+		in, out := &in.BundleGeneratedAt, &out.BundleGeneratedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObservation.
@@ -110,6 +174,26 @@ func (in *ClusterObservation) DeepCopy() *ClusterObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 	*out = *in
+	if in.AdmissionController != nil {
+		in, out := &in.AdmissionController, &out.AdmissionController
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdmissionControllerEvents != nil {
+		in, out := &in.AdmissionControllerEvents, &out.AdmissionControllerEvents
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdmissionControllerUpdates != nil {
+		in, out := &in.AdmissionControllerUpdates, &out.AdmissionControllerUpdates
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DynamicConfig != nil {
+		in, out := &in.DynamicConfig, &out.DynamicConfig
+		*out = new(DynamicConfig)
+		**out = **in
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -117,6 +201,21 @@ func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.SensorBundle != nil {
+		in, out := &in.SensorBundle, &out.SensorBundle
+		*out = new(SensorBundle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlimCollector != nil {
+		in, out := &in.SlimCollector, &out.SlimCollector
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterParameters.
@@ -134,6 +233,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ManagementPolicies != nil {
+		in, out := &in.ManagementPolicies, &out.ManagementPolicies
+		*out = make(apisv1alpha1.ManagementPolicies, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -163,6 +267,109 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorHealthInfo) DeepCopyInto(out *CollectorHealthInfo) {
+	*out = *in
+	if in.TotalDesiredPods != nil {
+		in, out := &in.TotalDesiredPods, &out.TotalDesiredPods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TotalReadyPods != nil {
+		in, out := &in.TotalReadyPods, &out.TotalReadyPods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TotalRegisteredNodes != nil {
+		in, out := &in.TotalRegisteredNodes, &out.TotalRegisteredNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StatusErrors != nil {
+		in, out := &in.StatusErrors, &out.StatusErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorHealthInfo.
+func (in *CollectorHealthInfo) DeepCopy() *CollectorHealthInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorHealthInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicConfig) DeepCopyInto(out *DynamicConfig) {
+	*out = *in
+	out.AdmissionControllerConfig = in.AdmissionControllerConfig
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicConfig.
+func (in *DynamicConfig) DeepCopy() *DynamicConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmConfig) DeepCopyInto(out *HelmConfig) {
+	*out = *in
+	if in.ClusterLabels != nil {
+		in, out := &in.ClusterLabels, &out.ClusterLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StaticConfig != nil {
+		in, out := &in.StaticConfig, &out.StaticConfig
+		*out = new(StaticConfig)
+		**out = **in
+	}
+	if in.DynamicConfig != nil {
+		in, out := &in.DynamicConfig, &out.DynamicConfig
+		*out = new(DynamicConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmConfig.
+func (in *HelmConfig) DeepCopy() *HelmConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensorBundle) DeepCopyInto(out *SensorBundle) {
+	*out = *in
+	if in.CreateUpgraderSA != nil {
+		in, out := &in.CreateUpgraderSA, &out.CreateUpgraderSA
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensorBundle.
+func (in *SensorBundle) DeepCopy() *SensorBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(SensorBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SensorDeployment) DeepCopyInto(out *SensorDeployment) {
 	*out = *in
@@ -177,3 +384,61 @@ func (in *SensorDeployment) DeepCopy() *SensorDeployment {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticConfig) DeepCopyInto(out *StaticConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticConfig.
+func (in *StaticConfig) DeepCopy() *StaticConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeProcess) DeepCopyInto(out *UpgradeProcess) {
+	*out = *in
+	if in.InitiatedAt != nil {
+		in, out := &in.InitiatedAt, &out.InitiatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeProcess.
+func (in *UpgradeProcess) DeepCopy() *UpgradeProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.MostRecentProcess != nil {
+		in, out := &in.MostRecentProcess, &out.MostRecentProcess
+		*out = new(UpgradeProcess)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
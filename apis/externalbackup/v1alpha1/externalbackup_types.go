@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AnnotationKeyTrigger triggers an on-demand backup whenever its value
+// changes, e.g. when it is set to the current timestamp.
+const AnnotationKeyTrigger = "externalbackup.stackrox.crossplane.io/trigger"
+
+// AnnotationKeyConsumedTrigger records the value of the trigger annotation
+// when the external backup was created. A new backup has nothing to catch up
+// on, so the trigger is consumed without running a backup.
+const AnnotationKeyConsumedTrigger = "externalbackup.stackrox.crossplane.io/consumed-trigger"
+
+// Schedule of the backups. Times are in UTC.
+type Schedule struct {
+	// Interval between two backups. Central does not support hourly
+	// backups, its schedules run at most once per day.
+	// +kubebuilder:validation:Enum=DAILY;WEEKLY
+	Interval string `json:"interval"`
+
+	// Hour of the day the backup runs at.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	Hour int32 `json:"hour"`
+
+	// Minute of the hour the backup runs at.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=59
+	// +kubebuilder:validation:Optional
+	Minute int32 `json:"minute"`
+
+	// Weekday of weekly backups, starting with Sunday as 0.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=6
+	// +kubebuilder:validation:Optional
+	Weekday int32 `json:"weekday"`
+}
+
+// S3Config configures a backup to Amazon S3 or an S3 compatible store.
+type S3Config struct {
+	// Bucket the backups are stored in.
+	Bucket string `json:"bucket"`
+
+	// ObjectPrefix of the backup objects in the bucket.
+	// +kubebuilder:validation:Optional
+	ObjectPrefix string `json:"objectPrefix"`
+
+	// Region of the bucket.
+	Region string `json:"region"`
+
+	// Endpoint of an S3 compatible store. Defaults to Amazon S3.
+	// +kubebuilder:validation:Optional
+	Endpoint string `json:"endpoint"`
+
+	// UseIAM authenticates with the IAM role of the Central pod.
+	// +kubebuilder:validation:Optional
+	UseIAM bool `json:"useIAM"`
+
+	// AccessKeyIDSecretRef references the AWS access key ID.
+	// +kubebuilder:validation:Optional
+	AccessKeyIDSecretRef *xpv1.SecretKeySelector `json:"accessKeyIDSecretRef,omitempty"`
+
+	// SecretAccessKeySecretRef references the AWS secret access key.
+	// +kubebuilder:validation:Optional
+	SecretAccessKeySecretRef *xpv1.SecretKeySelector `json:"secretAccessKeySecretRef,omitempty"`
+}
+
+// GCSConfig configures a backup to Google Cloud Storage.
+type GCSConfig struct {
+	// Bucket the backups are stored in.
+	Bucket string `json:"bucket"`
+
+	// ObjectPrefix of the backup objects in the bucket.
+	// +kubebuilder:validation:Optional
+	ObjectPrefix string `json:"objectPrefix"`
+
+	// ServiceAccountSecretRef references the JSON key of the service account.
+	// Required unless workload identity is used.
+	// +kubebuilder:validation:Optional
+	ServiceAccountSecretRef *xpv1.SecretKeySelector `json:"serviceAccountSecretRef,omitempty"`
+
+	// UseWorkloadIdentity authenticates with the workload identity of the
+	// Central pod.
+	// +kubebuilder:validation:Optional
+	UseWorkloadIdentity bool `json:"useWorkloadIdentity"`
+}
+
+// ExternalBackupParameters are the configurable fields of an ExternalBackup.
+// Exactly one of the storage configs must be set.
+type ExternalBackupParameters struct {
+	// Name of the external backup.
+	Name string `json:"name"`
+
+	// Schedule of the backups.
+	Schedule Schedule `json:"schedule"`
+
+	// BackupsToKeep is the number of backups retained in the store.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	BackupsToKeep int32 `json:"backupsToKeep"`
+
+	// +kubebuilder:validation:Optional
+	S3 *S3Config `json:"s3,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	GCS *GCSConfig `json:"gcs,omitempty"`
+}
+
+// ExternalBackupObservation are the observable fields of an ExternalBackup.
+type ExternalBackupObservation struct {
+	// ID of the external backup.
+	ID string `json:"id,omitempty"`
+
+	// Name of the external backup.
+	Name string `json:"name,omitempty"`
+
+	// Type of the external backup.
+	Type string `json:"type,omitempty"`
+
+	// LastTrigger is the value of the trigger annotation of the last
+	// on-demand backup.
+	LastTrigger string `json:"lastTrigger,omitempty"`
+}
+
+// An ExternalBackupSpec defines the desired state of an ExternalBackup.
+type ExternalBackupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ExternalBackupParameters `json:"forProvider"`
+}
+
+// An ExternalBackupStatus represents the observed state of an ExternalBackup.
+type ExternalBackupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ExternalBackupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ExternalBackup periodically backs up Central to object storage.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type ExternalBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExternalBackupSpec   `json:"spec"`
+	Status ExternalBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ExternalBackupList contains a list of ExternalBackup
+type ExternalBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExternalBackup `json:"items"`
+}
+
+// ExternalBackup type metadata.
+var (
+	ExternalBackupKind             = reflect.TypeOf(ExternalBackup{}).Name()
+	ExternalBackupGroupKind        = schema.GroupKind{Group: Group, Kind: ExternalBackupKind}.String()
+	ExternalBackupKindAPIVersion   = ExternalBackupKind + "." + SchemeGroupVersion.String()
+	ExternalBackupGroupVersionKind = SchemeGroupVersion.WithKind(ExternalBackupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ExternalBackup{}, &ExternalBackupList{})
+}
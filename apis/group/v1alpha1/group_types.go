@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// GroupParameters are the configurable fields of a Group. A group maps users
+// of an auth provider whose attribute key has the given value to a role.
+type GroupParameters struct {
+	// AuthProviderID of the auth provider the group applies to.
+	// +kubebuilder:validation:Optional
+	AuthProviderID string `json:"authProviderID,omitempty"`
+
+	// AuthProviderIDRef references an AuthProvider to retrieve its ID.
+	// +kubebuilder:validation:Optional
+	AuthProviderIDRef *xpv1.Reference `json:"authProviderIDRef,omitempty"`
+
+	// AuthProviderIDSelector selects an AuthProvider to retrieve its ID.
+	// +kubebuilder:validation:Optional
+	AuthProviderIDSelector *xpv1.Selector `json:"authProviderIDSelector,omitempty"`
+
+	// DefaultForProvider makes the group the default group of the auth
+	// provider, i.e. its role applies to all users of the auth provider.
+	// Key and value must be empty for the default group.
+	// +kubebuilder:validation:Optional
+	DefaultForProvider bool `json:"defaultForProvider,omitempty"`
+
+	// Key of the user attribute, e.g. groups. Required unless
+	// defaultForProvider is set.
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+
+	// Value the user attribute must have.
+	// +kubebuilder:validation:Optional
+	Value string `json:"value,omitempty"`
+
+	// RoleName of the role that applies to users in the group.
+	// +kubebuilder:validation:Optional
+	RoleName string `json:"roleName,omitempty"`
+
+	// RoleNameRef references a Role to retrieve its name.
+	// +kubebuilder:validation:Optional
+	RoleNameRef *xpv1.Reference `json:"roleNameRef,omitempty"`
+
+	// RoleNameSelector selects a Role to retrieve its name.
+	// +kubebuilder:validation:Optional
+	RoleNameSelector *xpv1.Selector `json:"roleNameSelector,omitempty"`
+}
+
+// GroupObservation are the observable fields of a Group.
+type GroupObservation struct {
+	// ID of the group.
+	ID string `json:"id,omitempty"`
+}
+
+// A GroupSpec defines the desired state of a Group.
+type GroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       GroupParameters `json:"forProvider"`
+}
+
+// A GroupStatus represents the observed state of a Group.
+type GroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          GroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Group maps users of an AuthProvider to a Role. Existing groups are
+// imported by setting their ID as external name.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.roleName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type Group struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GroupSpec   `json:"spec"`
+	Status GroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupList contains a list of Group
+type GroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Group `json:"items"`
+}
+
+// Group type metadata.
+var (
+	GroupKind             = reflect.TypeOf(Group{}).Name()
+	GroupGroupKind        = schema.GroupKind{Group: APIGroup, Kind: GroupKind}.String()
+	GroupKindAPIVersion   = GroupKind + "." + SchemeGroupVersion.String()
+	GroupGroupVersionKind = SchemeGroupVersion.WithKind(GroupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Group{}, &GroupList{})
+}
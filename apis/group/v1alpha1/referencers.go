@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	authproviderv1alpha1 "github.com/stehessel/provider-stackrox/apis/authprovider/v1alpha1"
+	rolev1alpha1 "github.com/stehessel/provider-stackrox/apis/role/v1alpha1"
+)
+
+// AuthProviderID extracts the Central ID of an AuthProvider.
+func AuthProviderID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*authproviderv1alpha1.AuthProvider)
+		if !ok {
+			return ""
+		}
+		return cr.Status.AtProvider.ID
+	}
+}
+
+// RoleName extracts the Central name of a Role.
+func RoleName() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*rolev1alpha1.Role)
+		if !ok {
+			return ""
+		}
+		return cr.Spec.ForProvider.Name
+	}
+}
+
+// ResolveReferences of this Group.
+func (mg *Group) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AuthProviderID,
+		Reference:    mg.Spec.ForProvider.AuthProviderIDRef,
+		Selector:     mg.Spec.ForProvider.AuthProviderIDSelector,
+		To:           reference.To{Managed: &authproviderv1alpha1.AuthProvider{}, List: &authproviderv1alpha1.AuthProviderList{}},
+		Extract:      AuthProviderID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.authProviderID")
+	}
+	mg.Spec.ForProvider.AuthProviderID = rsp.ResolvedValue
+	mg.Spec.ForProvider.AuthProviderIDRef = rsp.ResolvedReference
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.RoleName,
+		Reference:    mg.Spec.ForProvider.RoleNameRef,
+		Selector:     mg.Spec.ForProvider.RoleNameSelector,
+		To:           reference.To{Managed: &rolev1alpha1.Role{}, List: &rolev1alpha1.RoleList{}},
+		Extract:      RoleName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.roleName")
+	}
+	mg.Spec.ForProvider.RoleName = rsp.ResolvedValue
+	mg.Spec.ForProvider.RoleNameRef = rsp.ResolvedReference
+
+	return nil
+}
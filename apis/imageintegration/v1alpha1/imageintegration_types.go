@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// DockerConfig configures a Docker registry compatible integration.
+type DockerConfig struct {
+	// Endpoint of the registry.
+	Endpoint string `json:"endpoint"`
+
+	// Username to authenticate against the registry.
+	// +kubebuilder:validation:Optional
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password to authenticate against the registry.
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// Insecure disables TLS verification of the registry.
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure"`
+}
+
+// QuayRobotAccount defines the credentials of a Quay robot account.
+type QuayRobotAccount struct {
+	// Username of the robot account.
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password of the robot account.
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// QuayConfig configures a Quay integration.
+type QuayConfig struct {
+	// Endpoint of the Quay instance.
+	Endpoint string `json:"endpoint"`
+
+	// OAuthTokenSecretRef references the OAuth token. Required for scanner
+	// integrations.
+	// +kubebuilder:validation:Optional
+	OAuthTokenSecretRef *xpv1.SecretKeySelector `json:"oauthTokenSecretRef,omitempty"`
+
+	// Insecure disables TLS verification of the registry.
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure"`
+
+	// RobotAccount used for registry integrations.
+	// +kubebuilder:validation:Optional
+	RobotAccount *QuayRobotAccount `json:"robotAccount,omitempty"`
+}
+
+// ECRConfig configures an Amazon ECR integration.
+type ECRConfig struct {
+	// RegistryID of the ECR registry, i.e. the AWS account ID.
+	RegistryID string `json:"registryID"`
+
+	// Region of the ECR registry.
+	Region string `json:"region"`
+
+	// Endpoint of the ECR registry. Defaults to the regional endpoint.
+	// +kubebuilder:validation:Optional
+	Endpoint string `json:"endpoint"`
+
+	// UseIAM authenticates with the IAM role of the Central pod.
+	// +kubebuilder:validation:Optional
+	UseIAM bool `json:"useIAM"`
+
+	// AccessKeyIDSecretRef references the AWS access key ID.
+	// +kubebuilder:validation:Optional
+	AccessKeyIDSecretRef *xpv1.SecretKeySelector `json:"accessKeyIDSecretRef,omitempty"`
+
+	// SecretAccessKeySecretRef references the AWS secret access key.
+	// +kubebuilder:validation:Optional
+	SecretAccessKeySecretRef *xpv1.SecretKeySelector `json:"secretAccessKeySecretRef,omitempty"`
+
+	// UseAssumeRole assumes an IAM role to access the registry.
+	// +kubebuilder:validation:Optional
+	UseAssumeRole bool `json:"useAssumeRole"`
+
+	// AssumeRoleID of the IAM role to assume.
+	// +kubebuilder:validation:Optional
+	AssumeRoleID string `json:"assumeRoleID"`
+
+	// AssumeRoleExternalID used when assuming the IAM role.
+	// +kubebuilder:validation:Optional
+	AssumeRoleExternalID string `json:"assumeRoleExternalID"`
+}
+
+// ImageIntegrationParameters are the configurable fields of an
+// ImageIntegration. Exactly one of the integration configs must be set.
+type ImageIntegrationParameters struct {
+	// Name of the image integration.
+	Name string `json:"name"`
+
+	// Categories of the image integration.
+	// +kubebuilder:validation:MinItems=1
+	Categories []Category `json:"categories"`
+
+	// SkipTestIntegration skips testing the integration before it is created.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	SkipTestIntegration bool `json:"skipTestIntegration"`
+
+	// Docker configures a generic Docker registry.
+	// +kubebuilder:validation:Optional
+	Docker *DockerConfig `json:"docker,omitempty"`
+
+	// Artifactory configures a JFrog Artifactory registry.
+	// +kubebuilder:validation:Optional
+	Artifactory *DockerConfig `json:"artifactory,omitempty"`
+
+	// Nexus configures a Sonatype Nexus registry.
+	// +kubebuilder:validation:Optional
+	Nexus *DockerConfig `json:"nexus,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Quay *QuayConfig `json:"quay,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ECR *ECRConfig `json:"ecr,omitempty"`
+}
+
+// Category of an image integration.
+// +kubebuilder:validation:Enum=REGISTRY;SCANNER;NODE_SCANNER
+type Category string
+
+// ImageIntegrationObservation are the observable fields of an ImageIntegration.
+type ImageIntegrationObservation struct {
+	// ID of the image integration.
+	ID string `json:"id,omitempty"`
+
+	// Name of the image integration.
+	Name string `json:"name,omitempty"`
+
+	// Type of the image integration.
+	Type string `json:"type,omitempty"`
+
+	// Autogenerated is true if the integration was created from an image pull secret.
+	Autogenerated bool `json:"autogenerated,omitempty"`
+
+	// ClusterID of the cluster an autogenerated integration originates from.
+	ClusterID string `json:"clusterID,omitempty"`
+}
+
+// An ImageIntegrationSpec defines the desired state of an ImageIntegration.
+type ImageIntegrationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ImageIntegrationParameters `json:"forProvider"`
+}
+
+// An ImageIntegrationStatus represents the observed state of an ImageIntegration.
+type ImageIntegrationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ImageIntegrationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ImageIntegration connects Central to an image registry or scanner.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TESTED",type="string",JSONPath=".status.conditions[?(@.type=='Tested')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type ImageIntegration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImageIntegrationSpec   `json:"spec"`
+	Status ImageIntegrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ImageIntegrationList contains a list of ImageIntegration
+type ImageIntegrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImageIntegration `json:"items"`
+}
+
+// ImageIntegration type metadata.
+var (
+	ImageIntegrationKind             = reflect.TypeOf(ImageIntegration{}).Name()
+	ImageIntegrationGroupKind        = schema.GroupKind{Group: Group, Kind: ImageIntegrationKind}.String()
+	ImageIntegrationKindAPIVersion   = ImageIntegrationKind + "." + SchemeGroupVersion.String()
+	ImageIntegrationGroupVersionKind = SchemeGroupVersion.WithKind(ImageIntegrationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ImageIntegration{}, &ImageIntegrationList{})
+}
@@ -23,6 +23,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
 )
 
 // Attributes defines a map of user attributes.
@@ -51,8 +53,10 @@ type User struct {
 
 // InitBundleParameters are the configurable fields of a InitBundle.
 type InitBundleParameters struct {
-	// Name of the init bundle.
-	Name string `json:"name"`
+	// Name of the init bundle. Required unless an existing init bundle is
+	// imported by setting its ID as external name.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
 }
 
 // InitBundleObservation are the observable fields of a InitBundle.
@@ -80,6 +84,13 @@ type InitBundleObservation struct {
 type InitBundleSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       InitBundleParameters `json:"forProvider"`
+
+	// ManagementPolicies are the actions that the provider may take on the
+	// external init bundle. They are only honored if the provider runs with
+	// --enable-management-policies.
+	// +kubebuilder:default={"*"}
+	// +optional
+	ManagementPolicies apisv1alpha1.ManagementPolicies `json:"managementPolicies,omitempty"`
 }
 
 // A InitBundleStatus represents the observed state of a InitBundle.
@@ -105,6 +116,16 @@ type InitBundle struct {
 	Status InitBundleStatus `json:"status,omitempty"`
 }
 
+// GetManagementPolicies of this InitBundle.
+func (mg *InitBundle) GetManagementPolicies() apisv1alpha1.ManagementPolicies {
+	return mg.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies of this InitBundle.
+func (mg *InitBundle) SetManagementPolicies(p apisv1alpha1.ManagementPolicies) {
+	mg.Spec.ManagementPolicies = p
+}
+
 // +kubebuilder:object:root=true
 
 // InitBundleList contains a list of InitBundle
@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// PeerEntity identifies the peer of a connection.
+type PeerEntity struct {
+	// Type of the peer.
+	// +kubebuilder:validation:Enum=DEPLOYMENT;INTERNET;EXTERNAL_SOURCE
+	Type string `json:"type"`
+
+	// ID of an external source peer.
+	// +kubebuilder:validation:Optional
+	ID string `json:"id,omitempty"`
+
+	// Namespace of a deployment peer in the cluster of the baseline.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of a deployment peer.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+// Peer is a connection that is part of the baseline.
+type Peer struct {
+	// Entity of the peer.
+	Entity PeerEntity `json:"entity"`
+
+	// Port of the destination of the connection.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Protocol of the connection.
+	// +kubebuilder:validation:Enum=L4_PROTOCOL_TCP;L4_PROTOCOL_UDP;L4_PROTOCOL_ICMP;L4_PROTOCOL_RAW;L4_PROTOCOL_SCTP;L4_PROTOCOL_ANY
+	// +kubebuilder:default=L4_PROTOCOL_TCP
+	// +kubebuilder:validation:Optional
+	Protocol string `json:"protocol"`
+
+	// Ingress marks connections from the peer to the deployment. Connections
+	// from the deployment to the peer are egress.
+	// +kubebuilder:validation:Optional
+	Ingress bool `json:"ingress"`
+}
+
+// NetworkBaselineParameters are the configurable fields of a NetworkBaseline.
+type NetworkBaselineParameters struct {
+	// ClusterName of the cluster the deployment runs in.
+	// +kubebuilder:validation:Optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// ClusterNameRef references a Cluster to retrieve its name.
+	// +kubebuilder:validation:Optional
+	ClusterNameRef *xpv1.Reference `json:"clusterNameRef,omitempty"`
+
+	// ClusterNameSelector selects a Cluster to retrieve its name.
+	// +kubebuilder:validation:Optional
+	ClusterNameSelector *xpv1.Selector `json:"clusterNameSelector,omitempty"`
+
+	// Namespace of the deployment.
+	Namespace string `json:"namespace"`
+
+	// DeploymentName of the deployment whose baseline is managed.
+	DeploymentName string `json:"deploymentName"`
+
+	// Locked baselines do not learn new connections. Connections that are not
+	// part of a locked baseline are flagged as anomalous.
+	// +kubebuilder:validation:Optional
+	Locked bool `json:"locked"`
+
+	// Peers allowed by the baseline. All other observed peers are marked as
+	// anomalous. The peers of the baseline are not managed if none are
+	// declared.
+	// +kubebuilder:validation:Optional
+	Peers []Peer `json:"peers,omitempty"`
+}
+
+// NetworkBaselineObservation are the observable fields of a NetworkBaseline.
+type NetworkBaselineObservation struct {
+	// DeploymentID of the deployment.
+	DeploymentID string `json:"deploymentID,omitempty"`
+
+	// ClusterID of the cluster the deployment runs in.
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// Locked is true if the baseline is locked.
+	Locked bool `json:"locked,omitempty"`
+
+	// ObservationPeriodEnd is the time the baseline stops learning new
+	// connections.
+	ObservationPeriodEnd *metav1.Time `json:"observationPeriodEnd,omitempty"`
+}
+
+// A NetworkBaselineSpec defines the desired state of a NetworkBaseline.
+type NetworkBaselineSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       NetworkBaselineParameters `json:"forProvider"`
+}
+
+// A NetworkBaselineStatus represents the observed state of a NetworkBaseline.
+type NetworkBaselineStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          NetworkBaselineObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A NetworkBaseline manages the network baseline of a deployment. Deleting it
+// unlocks the baseline. The baseline itself is owned by the deployment and
+// keeps its peers.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NAMESPACE",type="string",JSONPath=".spec.forProvider.namespace"
+// +kubebuilder:printcolumn:name="DEPLOYMENT",type="string",JSONPath=".spec.forProvider.deploymentName"
+// +kubebuilder:printcolumn:name="LOCKED",type="boolean",JSONPath=".status.atProvider.locked"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type NetworkBaseline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkBaselineSpec   `json:"spec"`
+	Status NetworkBaselineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NetworkBaselineList contains a list of NetworkBaseline
+type NetworkBaselineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkBaseline `json:"items"`
+}
+
+// NetworkBaseline type metadata.
+var (
+	NetworkBaselineKind             = reflect.TypeOf(NetworkBaseline{}).Name()
+	NetworkBaselineGroupKind        = schema.GroupKind{Group: Group, Kind: NetworkBaselineKind}.String()
+	NetworkBaselineKindAPIVersion   = NetworkBaselineKind + "." + SchemeGroupVersion.String()
+	NetworkBaselineGroupVersionKind = SchemeGroupVersion.WithKind(NetworkBaselineKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&NetworkBaseline{}, &NetworkBaselineList{})
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AnnotationKeySecretHash records the hash of the secret values a notifier
+// was created with. The status written on creation is not persisted, so it is
+// recorded as an annotation.
+const AnnotationKeySecretHash = "notifier.stackrox.crossplane.io/secret-hash"
+
+// KeyValuePair defines a key and a value.
+type KeyValuePair struct {
+	// Key of the pair.
+	Key string `json:"key"`
+
+	// Value of the pair.
+	// +kubebuilder:validation:Optional
+	Value string `json:"value"`
+}
+
+// SlackConfig configures a Slack notifier.
+type SlackConfig struct {
+	// WebhookURL of the default Slack channel.
+	WebhookURL string `json:"webhookURL"`
+}
+
+// GenericConfig configures a generic webhook notifier.
+type GenericConfig struct {
+	// Endpoint the webhook sends requests to.
+	Endpoint string `json:"endpoint"`
+
+	// SkipTLSVerify disables TLS verification of the endpoint.
+	// +kubebuilder:validation:Optional
+	SkipTLSVerify bool `json:"skipTLSVerify"`
+
+	// CACert in PEM format used to verify the endpoint.
+	// +kubebuilder:validation:Optional
+	CACert string `json:"caCert"`
+
+	// Username for basic authentication.
+	// +kubebuilder:validation:Optional
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password for basic authentication.
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// Headers added to every request.
+	// +kubebuilder:validation:Optional
+	Headers []KeyValuePair `json:"headers"`
+
+	// ExtraFields added to every payload.
+	// +kubebuilder:validation:Optional
+	ExtraFields []KeyValuePair `json:"extraFields"`
+
+	// AuditLoggingEnabled forwards audit logs to the webhook.
+	// +kubebuilder:validation:Optional
+	AuditLoggingEnabled bool `json:"auditLoggingEnabled"`
+}
+
+// EmailConfig configures an email notifier.
+type EmailConfig struct {
+	// Server address of the mail server, e.g. smtp.example.com:465.
+	Server string `json:"server"`
+
+	// Sender address of the emails.
+	Sender string `json:"sender"`
+
+	// From is the display name of the sender.
+	// +kubebuilder:validation:Optional
+	From string `json:"from"`
+
+	// Username to authenticate against the mail server.
+	// +kubebuilder:validation:Optional
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password to authenticate against the mail server.
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// DisableTLS disables TLS to the mail server.
+	// +kubebuilder:validation:Optional
+	DisableTLS bool `json:"disableTLS"`
+
+	// StartTLSAuthMethod used if TLS is disabled.
+	// +kubebuilder:default=DISABLED
+	// +kubebuilder:validation:Enum=DISABLED;PLAIN;LOGIN
+	// +kubebuilder:validation:Optional
+	StartTLSAuthMethod string `json:"startTLSAuthMethod"`
+
+	// AllowUnauthenticatedSMTP allows sending emails without credentials.
+	// +kubebuilder:validation:Optional
+	AllowUnauthenticatedSMTP bool `json:"allowUnauthenticatedSMTP"`
+}
+
+// JiraPriorityMapping maps a policy severity to a Jira priority.
+type JiraPriorityMapping struct {
+	// Severity of the policy.
+	// +kubebuilder:validation:Enum=LOW_SEVERITY;MEDIUM_SEVERITY;HIGH_SEVERITY;CRITICAL_SEVERITY
+	Severity string `json:"severity"`
+
+	// PriorityName of the Jira priority.
+	PriorityName string `json:"priorityName"`
+}
+
+// JiraConfig configures a Jira notifier.
+type JiraConfig struct {
+	// URL of the Jira instance.
+	URL string `json:"url"`
+
+	// Username to authenticate against Jira.
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password or API token to authenticate against Jira.
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+
+	// IssueType of the created Jira issues.
+	IssueType string `json:"issueType"`
+
+	// PriorityMappings of policy severities to Jira priorities.
+	// +kubebuilder:validation:Optional
+	PriorityMappings []JiraPriorityMapping `json:"priorityMappings"`
+
+	// DefaultFieldsJSON defines default values of Jira issue fields as JSON.
+	// +kubebuilder:validation:Optional
+	DefaultFieldsJSON string `json:"defaultFieldsJSON"`
+}
+
+// PagerDutyConfig configures a PagerDuty notifier.
+type PagerDutyConfig struct {
+	// APIKeySecretRef references the PagerDuty integration key.
+	APIKeySecretRef xpv1.SecretKeySelector `json:"apiKeySecretRef"`
+}
+
+// SplunkConfig configures a Splunk notifier.
+type SplunkConfig struct {
+	// HTTPEndpoint of the Splunk HTTP event collector.
+	HTTPEndpoint string `json:"httpEndpoint"`
+
+	// HTTPTokenSecretRef references the Splunk HTTP event collector token.
+	HTTPTokenSecretRef xpv1.SecretKeySelector `json:"httpTokenSecretRef"`
+
+	// Insecure disables TLS verification of the endpoint.
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure"`
+
+	// Truncate limit of the payload size in bytes.
+	// +kubebuilder:validation:Optional
+	Truncate int64 `json:"truncate"`
+
+	// AuditLoggingEnabled forwards audit logs to Splunk.
+	// +kubebuilder:validation:Optional
+	AuditLoggingEnabled bool `json:"auditLoggingEnabled"`
+
+	// SourceTypes maps event types to Splunk source types.
+	// +kubebuilder:validation:Optional
+	SourceTypes map[string]string `json:"sourceTypes"`
+}
+
+// SumoLogicConfig configures a Sumo Logic notifier.
+type SumoLogicConfig struct {
+	// HTTPSourceAddress of the Sumo Logic HTTP source.
+	HTTPSourceAddress string `json:"httpSourceAddress"`
+
+	// SkipTLSVerify disables TLS verification of the endpoint.
+	// +kubebuilder:validation:Optional
+	SkipTLSVerify bool `json:"skipTLSVerify"`
+}
+
+// SyslogTCPConfig configures the TCP endpoint of a syslog notifier.
+type SyslogTCPConfig struct {
+	// Hostname of the syslog server.
+	Hostname string `json:"hostname"`
+
+	// Port of the syslog server.
+	Port int32 `json:"port"`
+
+	// SkipTLSVerify disables TLS verification of the server.
+	// +kubebuilder:validation:Optional
+	SkipTLSVerify bool `json:"skipTLSVerify"`
+
+	// UseTLS enables TLS.
+	// +kubebuilder:validation:Optional
+	UseTLS bool `json:"useTLS"`
+}
+
+// SyslogConfig configures a syslog notifier.
+type SyslogConfig struct {
+	// LocalFacility of the syslog messages.
+	// +kubebuilder:default=LOCAL0
+	// +kubebuilder:validation:Enum=LOCAL0;LOCAL1;LOCAL2;LOCAL3;LOCAL4;LOCAL5;LOCAL6;LOCAL7
+	// +kubebuilder:validation:Optional
+	LocalFacility string `json:"localFacility"`
+
+	// TCPConfig of the syslog endpoint.
+	TCPConfig SyslogTCPConfig `json:"tcpConfig"`
+
+	// ExtraFields added to every message.
+	// +kubebuilder:validation:Optional
+	ExtraFields []KeyValuePair `json:"extraFields"`
+}
+
+// AWSSecurityHubConfig configures an AWS Security Hub notifier.
+type AWSSecurityHubConfig struct {
+	// Region of AWS Security Hub.
+	Region string `json:"region"`
+
+	// AccountID of the AWS account.
+	AccountID string `json:"accountID"`
+
+	// AccessKeyIDSecretRef references the AWS access key ID.
+	AccessKeyIDSecretRef xpv1.SecretKeySelector `json:"accessKeyIDSecretRef"`
+
+	// SecretAccessKeySecretRef references the AWS secret access key.
+	SecretAccessKeySecretRef xpv1.SecretKeySelector `json:"secretAccessKeySecretRef"`
+}
+
+// NotifierParameters are the configurable fields of a Notifier. Exactly one
+// of the notifier configs must be set.
+type NotifierParameters struct {
+	// Name of the notifier.
+	Name string `json:"name"`
+
+	// UIEndpoint of Central that is linked in notifications.
+	// +kubebuilder:validation:Optional
+	UIEndpoint string `json:"uiEndpoint"`
+
+	// LabelKey of the deployment annotation that overrides the default
+	// recipient of a notification.
+	// +kubebuilder:validation:Optional
+	LabelKey string `json:"labelKey"`
+
+	// LabelDefault is the default recipient of a notification, e.g. an email
+	// address or Jira project. Must not be set for Slack notifiers, whose
+	// webhook URL is their default recipient.
+	// +kubebuilder:validation:Optional
+	LabelDefault string `json:"labelDefault"`
+
+	// +kubebuilder:validation:Optional
+	Slack *SlackConfig `json:"slack,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Generic *GenericConfig `json:"generic,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Email *EmailConfig `json:"email,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Jira *JiraConfig `json:"jira,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	PagerDuty *PagerDutyConfig `json:"pagerDuty,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Splunk *SplunkConfig `json:"splunk,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	SumoLogic *SumoLogicConfig `json:"sumoLogic,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Syslog *SyslogConfig `json:"syslog,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	AWSSecurityHub *AWSSecurityHubConfig `json:"awsSecurityHub,omitempty"`
+}
+
+// NotifierObservation are the observable fields of a Notifier.
+type NotifierObservation struct {
+	// ID of the notifier.
+	ID string `json:"id,omitempty"`
+
+	// Name of the notifier.
+	Name string `json:"name,omitempty"`
+
+	// Type of the notifier.
+	Type string `json:"type,omitempty"`
+
+	// SecretHash is the hash of the secret values last written to Central.
+	// Central masks secrets in its responses, so changed secrets are detected
+	// by comparing it.
+	SecretHash string `json:"secretHash,omitempty"`
+}
+
+// A NotifierSpec defines the desired state of a Notifier.
+type NotifierSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       NotifierParameters `json:"forProvider"`
+}
+
+// A NotifierStatus represents the observed state of a Notifier.
+type NotifierStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          NotifierObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Notifier sends policy violations and audit logs to an external system.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type Notifier struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NotifierSpec   `json:"spec"`
+	Status NotifierStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NotifierList contains a list of Notifier
+type NotifierList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Notifier `json:"items"`
+}
+
+// Notifier type metadata.
+var (
+	NotifierKind             = reflect.TypeOf(Notifier{}).Name()
+	NotifierGroupKind        = schema.GroupKind{Group: Group, Kind: NotifierKind}.String()
+	NotifierKindAPIVersion   = NotifierKind + "." + SchemeGroupVersion.String()
+	NotifierGroupVersionKind = SchemeGroupVersion.WithKind(NotifierKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Notifier{}, &NotifierList{})
+}
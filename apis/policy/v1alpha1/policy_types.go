@@ -0,0 +1,276 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ScopeLabel selects objects by a label key and value.
+type ScopeLabel struct {
+	// Key of the label.
+	Key string `json:"key"`
+
+	// Value of the label.
+	// +kubebuilder:validation:Optional
+	Value string `json:"value"`
+}
+
+// Scope restricts a policy to a cluster, namespace and/or label.
+type Scope struct {
+	// Cluster ID the scope applies to.
+	// +kubebuilder:validation:Optional
+	Cluster string `json:"cluster"`
+
+	// Namespace the scope applies to.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace"`
+
+	// Label the scope applies to.
+	// +kubebuilder:validation:Optional
+	Label *ScopeLabel `json:"label,omitempty"`
+}
+
+// ExclusionDeployment excludes deployments from a policy.
+type ExclusionDeployment struct {
+	// Name of the excluded deployment.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name"`
+
+	// Scope of the excluded deployment.
+	// +kubebuilder:validation:Optional
+	Scope *Scope `json:"scope,omitempty"`
+}
+
+// ExclusionImage excludes images from a policy.
+type ExclusionImage struct {
+	// Name of the excluded image.
+	Name string `json:"name"`
+}
+
+// Exclusion defines deployments or images that are exempt from a policy.
+type Exclusion struct {
+	// Name of the exclusion.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name"`
+
+	// Deployment to exclude.
+	// +kubebuilder:validation:Optional
+	Deployment *ExclusionDeployment `json:"deployment,omitempty"`
+
+	// Image to exclude.
+	// +kubebuilder:validation:Optional
+	Image *ExclusionImage `json:"image,omitempty"`
+
+	// Expiration timestamp of the exclusion.
+	// +kubebuilder:validation:Optional
+	Expiration *metav1.Time `json:"expiration,omitempty"`
+}
+
+// PolicyGroup defines a single policy criterion.
+type PolicyGroup struct {
+	// FieldName of the policy criterion, e.g. "Image Tag".
+	FieldName string `json:"fieldName"`
+
+	// BooleanOperator used to combine the values.
+	// +kubebuilder:default=OR
+	// +kubebuilder:validation:Enum=OR;AND
+	// +kubebuilder:validation:Optional
+	BooleanOperator string `json:"booleanOperator"`
+
+	// Negate the policy criterion.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	Negate bool `json:"negate"`
+
+	// Values of the policy criterion.
+	Values []string `json:"values"`
+}
+
+// PolicySection defines a set of policy criteria that must all match.
+type PolicySection struct {
+	// SectionName of the policy section.
+	// +kubebuilder:validation:Optional
+	SectionName string `json:"sectionName"`
+
+	// PolicyGroups of the policy section.
+	PolicyGroups []PolicyGroup `json:"policyGroups"`
+}
+
+// MitreAttackVector maps a policy to a MITRE ATT&CK tactic and techniques.
+type MitreAttackVector struct {
+	// Tactic ID.
+	Tactic string `json:"tactic"`
+
+	// Techniques IDs.
+	// +kubebuilder:validation:Optional
+	Techniques []string `json:"techniques"`
+}
+
+// PolicyParameters are the configurable fields of a Policy.
+type PolicyParameters struct {
+	// Name of the policy.
+	Name string `json:"name"`
+
+	// Description of the policy.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description"`
+
+	// Rationale of the policy.
+	// +kubebuilder:validation:Optional
+	Rationale string `json:"rationale"`
+
+	// Remediation of policy violations.
+	// +kubebuilder:validation:Optional
+	Remediation string `json:"remediation"`
+
+	// Disabled policies are not evaluated.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	Disabled bool `json:"disabled"`
+
+	// Categories of the policy.
+	// +kubebuilder:validation:Optional
+	Categories []string `json:"categories"`
+
+	// LifecycleStages at which the policy is evaluated.
+	// +kubebuilder:validation:MinItems=1
+	LifecycleStages []LifecycleStage `json:"lifecycleStages"`
+
+	// EventSource of runtime policies.
+	// +kubebuilder:default=NOT_APPLICABLE
+	// +kubebuilder:validation:Enum=NOT_APPLICABLE;DEPLOYMENT_EVENT;AUDIT_LOG_EVENT
+	// +kubebuilder:validation:Optional
+	EventSource string `json:"eventSource"`
+
+	// Exclusions of the policy.
+	// +kubebuilder:validation:Optional
+	Exclusions []Exclusion `json:"exclusions"`
+
+	// Scope of the policy. An empty scope applies to all deployments.
+	// +kubebuilder:validation:Optional
+	Scope []Scope `json:"scope"`
+
+	// Severity of the policy.
+	// +kubebuilder:default=LOW_SEVERITY
+	// +kubebuilder:validation:Enum=LOW_SEVERITY;MEDIUM_SEVERITY;HIGH_SEVERITY;CRITICAL_SEVERITY
+	// +kubebuilder:validation:Optional
+	Severity string `json:"severity"`
+
+	// EnforcementActions taken on policy violations.
+	// +kubebuilder:validation:Optional
+	EnforcementActions []EnforcementAction `json:"enforcementActions"`
+
+	// Notifiers IDs that receive policy violations.
+	// +kubebuilder:validation:Optional
+	Notifiers []string `json:"notifiers"`
+
+	// PolicySections contain the criteria of the policy.
+	// +kubebuilder:validation:MinItems=1
+	PolicySections []PolicySection `json:"policySections"`
+
+	// MitreAttackVectors of the policy.
+	// +kubebuilder:validation:Optional
+	MitreAttackVectors []MitreAttackVector `json:"mitreAttackVectors"`
+}
+
+// LifecycleStage at which a policy is evaluated.
+// +kubebuilder:validation:Enum=DEPLOY;BUILD;RUNTIME
+type LifecycleStage string
+
+// EnforcementAction taken on a policy violation.
+// +kubebuilder:validation:Enum=SCALE_TO_ZERO_ENFORCEMENT;UNSATISFIABLE_NODE_CONSTRAINT_ENFORCEMENT;KILL_POD_ENFORCEMENT;FAIL_BUILD_ENFORCEMENT;FAIL_KUBE_REQUEST_ENFORCEMENT;FAIL_DEPLOYMENT_CREATE_ENFORCEMENT;FAIL_DEPLOYMENT_UPDATE_ENFORCEMENT
+type EnforcementAction string
+
+// PolicyObservation are the observable fields of a Policy.
+type PolicyObservation struct {
+	// ID of the policy.
+	ID string `json:"id,omitempty"`
+
+	// Name of the policy.
+	Name string `json:"name,omitempty"`
+
+	// CriteriaLocked is true if the policy criteria cannot be changed.
+	CriteriaLocked bool `json:"criteriaLocked,omitempty"`
+
+	// IsDefault is true for policies shipped with Central.
+	IsDefault bool `json:"isDefault,omitempty"`
+
+	// LastUpdated timestamp of the policy.
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+
+	// MitreVectorsLocked is true if the MITRE ATT&CK vectors cannot be changed.
+	MitreVectorsLocked bool `json:"mitreVectorsLocked,omitempty"`
+
+	// PolicyVersion of the policy.
+	PolicyVersion string `json:"policyVersion,omitempty"`
+}
+
+// A PolicySpec defines the desired state of a Policy.
+type PolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       PolicyParameters `json:"forProvider"`
+}
+
+// A PolicyStatus represents the observed state of a Policy.
+type PolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          PolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Policy is a security policy enforced by Central.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type Policy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicySpec   `json:"spec"`
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyList contains a list of Policy
+type PolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Policy `json:"items"`
+}
+
+// Policy type metadata.
+var (
+	PolicyKind             = reflect.TypeOf(Policy{}).Name()
+	PolicyGroupKind        = schema.GroupKind{Group: Group, Kind: PolicyKind}.String()
+	PolicyKindAPIVersion   = PolicyKind + "." + SchemeGroupVersion.String()
+	PolicyGroupVersionKind = SchemeGroupVersion.WithKind(PolicyKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Policy{}, &PolicyList{})
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ProcessBaselineParameters are the configurable fields of a ProcessBaseline.
+type ProcessBaselineParameters struct {
+	// ClusterID of the cluster the deployment runs in.
+	// +kubebuilder:validation:Optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// ClusterIDRef references a Cluster to retrieve its ID.
+	// +kubebuilder:validation:Optional
+	ClusterIDRef *xpv1.Reference `json:"clusterIDRef,omitempty"`
+
+	// ClusterIDSelector selects a Cluster to retrieve its ID.
+	// +kubebuilder:validation:Optional
+	ClusterIDSelector *xpv1.Selector `json:"clusterIDSelector,omitempty"`
+
+	// Namespace of the deployment.
+	Namespace string `json:"namespace"`
+
+	// DeploymentName of the deployment.
+	DeploymentName string `json:"deploymentName"`
+
+	// ContainerName of the container whose baseline is managed.
+	ContainerName string `json:"containerName"`
+
+	// Locked baselines flag processes that are not part of the baseline as
+	// violations.
+	// +kubebuilder:validation:Optional
+	Locked bool `json:"locked"`
+
+	// Processes allowed by the baseline, given by their executable path.
+	// Processes that are not declared are removed from the baseline. The
+	// processes of the baseline are not managed if none are declared.
+	// +kubebuilder:validation:Optional
+	Processes []string `json:"processes,omitempty"`
+}
+
+// BaselineElement is a process of the baseline.
+type BaselineElement struct {
+	// ProcessName is the executable path of the process.
+	ProcessName string `json:"processName"`
+
+	// Auto is true if the process was added by Central when it was observed.
+	Auto bool `json:"auto,omitempty"`
+}
+
+// ProcessBaselineObservation are the observable fields of a ProcessBaseline.
+type ProcessBaselineObservation struct {
+	// ID of the process baseline.
+	ID string `json:"id,omitempty"`
+
+	// DeploymentID of the deployment.
+	DeploymentID string `json:"deploymentID,omitempty"`
+
+	// UserLocked is true if the baseline was locked by a user.
+	UserLocked bool `json:"userLocked,omitempty"`
+
+	// StackRoxLocked is true once the observation period of the baseline
+	// ended and Central locked it.
+	StackRoxLocked bool `json:"stackRoxLocked,omitempty"`
+
+	// StackRoxLockedTime is the end of the observation period.
+	StackRoxLockedTime *metav1.Time `json:"stackRoxLockedTime,omitempty"`
+
+	// Elements are the processes of the baseline.
+	Elements []BaselineElement `json:"elements,omitempty"`
+}
+
+// A ProcessBaselineSpec defines the desired state of a ProcessBaseline.
+type ProcessBaselineSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ProcessBaselineParameters `json:"forProvider"`
+}
+
+// A ProcessBaselineStatus represents the observed state of a ProcessBaseline.
+type ProcessBaselineStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ProcessBaselineObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ProcessBaseline manages the process baseline of a container. Deleting it
+// unlocks the baseline. The baseline itself is owned by the deployment and
+// keeps its processes.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DEPLOYMENT",type="string",JSONPath=".spec.forProvider.deploymentName"
+// +kubebuilder:printcolumn:name="CONTAINER",type="string",JSONPath=".spec.forProvider.containerName"
+// +kubebuilder:printcolumn:name="USER-LOCKED",type="boolean",JSONPath=".status.atProvider.userLocked"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type ProcessBaseline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProcessBaselineSpec   `json:"spec"`
+	Status ProcessBaselineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProcessBaselineList contains a list of ProcessBaseline
+type ProcessBaselineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProcessBaseline `json:"items"`
+}
+
+// ProcessBaseline type metadata.
+var (
+	ProcessBaselineKind             = reflect.TypeOf(ProcessBaseline{}).Name()
+	ProcessBaselineGroupKind        = schema.GroupKind{Group: Group, Kind: ProcessBaselineKind}.String()
+	ProcessBaselineKindAPIVersion   = ProcessBaselineKind + "." + SchemeGroupVersion.String()
+	ProcessBaselineGroupVersionKind = SchemeGroupVersion.WithKind(ProcessBaselineKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ProcessBaseline{}, &ProcessBaselineList{})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	clusterv1alpha1 "github.com/stehessel/provider-stackrox/apis/cluster/v1alpha1"
+)
+
+// ClusterID extracts the Central ID of a Cluster.
+func ClusterID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*clusterv1alpha1.Cluster)
+		if !ok {
+			return ""
+		}
+		return cr.Status.AtProvider.ID
+	}
+}
+
+// ResolveReferences of this ProcessBaseline.
+func (mg *ProcessBaseline) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ClusterID,
+		Reference:    mg.Spec.ForProvider.ClusterIDRef,
+		Selector:     mg.Spec.ForProvider.ClusterIDSelector,
+		To:           reference.To{Managed: &clusterv1alpha1.Cluster{}, List: &clusterv1alpha1.ClusterList{}},
+		Extract:      ClusterID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.clusterID")
+	}
+	mg.Spec.ForProvider.ClusterID = rsp.ResolvedValue
+	mg.Spec.ForProvider.ClusterIDRef = rsp.ResolvedReference
+
+	return nil
+}
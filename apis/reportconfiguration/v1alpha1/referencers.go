@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	accessscopev1alpha1 "github.com/stehessel/provider-stackrox/apis/accessscope/v1alpha1"
+	notifierv1alpha1 "github.com/stehessel/provider-stackrox/apis/notifier/v1alpha1"
+)
+
+// AccessScopeID extracts the Central ID of an AccessScope.
+func AccessScopeID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*accessscopev1alpha1.AccessScope)
+		if !ok {
+			return ""
+		}
+		return cr.Status.AtProvider.ID
+	}
+}
+
+// NotifierID extracts the Central ID of a Notifier.
+func NotifierID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*notifierv1alpha1.Notifier)
+		if !ok {
+			return ""
+		}
+		return cr.Status.AtProvider.ID
+	}
+}
+
+// ResolveReferences of this ReportConfiguration.
+func (mg *ReportConfiguration) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ScopeID,
+		Reference:    mg.Spec.ForProvider.ScopeIDRef,
+		Selector:     mg.Spec.ForProvider.ScopeIDSelector,
+		To:           reference.To{Managed: &accessscopev1alpha1.AccessScope{}, List: &accessscopev1alpha1.AccessScopeList{}},
+		Extract:      AccessScopeID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.scopeID")
+	}
+	mg.Spec.ForProvider.ScopeID = rsp.ResolvedValue
+	mg.Spec.ForProvider.ScopeIDRef = rsp.ResolvedReference
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.EmailConfig.NotifierID,
+		Reference:    mg.Spec.ForProvider.EmailConfig.NotifierIDRef,
+		Selector:     mg.Spec.ForProvider.EmailConfig.NotifierIDSelector,
+		To:           reference.To{Managed: &notifierv1alpha1.Notifier{}, List: &notifierv1alpha1.NotifierList{}},
+		Extract:      NotifierID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.emailConfig.notifierID")
+	}
+	mg.Spec.ForProvider.EmailConfig.NotifierID = rsp.ResolvedValue
+	mg.Spec.ForProvider.EmailConfig.NotifierIDRef = rsp.ResolvedReference
+
+	return nil
+}
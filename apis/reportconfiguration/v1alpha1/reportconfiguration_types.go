@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AnnotationKeyRun runs the report on demand whenever its value changes, e.g.
+// when it is set to the current timestamp.
+const AnnotationKeyRun = "reportconfiguration.stackrox.crossplane.io/run"
+
+// AnnotationKeyConsumedRun records the value of the run annotation when the
+// report configuration was created. A new report has nothing to catch up on,
+// so the run is consumed without running the report.
+const AnnotationKeyConsumedRun = "reportconfiguration.stackrox.crossplane.io/consumed-run"
+
+// Severity of a vulnerability.
+// +kubebuilder:validation:Enum=LOW_VULNERABILITY_SEVERITY;MODERATE_VULNERABILITY_SEVERITY;IMPORTANT_VULNERABILITY_SEVERITY;CRITICAL_VULNERABILITY_SEVERITY
+type Severity string
+
+// VulnReportFilters select the vulnerabilities included in the report.
+type VulnReportFilters struct {
+	// Fixability of the reported vulnerabilities.
+	// +kubebuilder:validation:Enum=BOTH;FIXABLE;NOT_FIXABLE
+	// +kubebuilder:default=BOTH
+	// +kubebuilder:validation:Optional
+	Fixability string `json:"fixability"`
+
+	// SinceLastReport only reports vulnerabilities discovered since the last
+	// report.
+	// +kubebuilder:validation:Optional
+	SinceLastReport bool `json:"sinceLastReport"`
+
+	// Severities of the reported vulnerabilities.
+	// +kubebuilder:validation:MinItems=1
+	Severities []Severity `json:"severities"`
+}
+
+// Schedule of the report. Times are in UTC.
+type Schedule struct {
+	// Interval between two reports.
+	// +kubebuilder:validation:Enum=WEEKLY;MONTHLY
+	Interval string `json:"interval"`
+
+	// Hour of the day the report runs at.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	Hour int32 `json:"hour"`
+
+	// Minute of the hour the report runs at.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=59
+	// +kubebuilder:validation:Optional
+	Minute int32 `json:"minute"`
+
+	// DaysOfWeek of weekly reports, starting with Sunday as 0.
+	// +kubebuilder:validation:Optional
+	DaysOfWeek []int32 `json:"daysOfWeek,omitempty"`
+
+	// DaysOfMonth of monthly reports, starting with 1.
+	// +kubebuilder:validation:Optional
+	DaysOfMonth []int32 `json:"daysOfMonth,omitempty"`
+}
+
+// EmailConfig sends the report with an email notifier.
+type EmailConfig struct {
+	// NotifierID of the email notifier.
+	// +kubebuilder:validation:Optional
+	NotifierID string `json:"notifierID,omitempty"`
+
+	// NotifierIDRef references a Notifier to retrieve its ID.
+	// +kubebuilder:validation:Optional
+	NotifierIDRef *xpv1.Reference `json:"notifierIDRef,omitempty"`
+
+	// NotifierIDSelector selects a Notifier to retrieve its ID.
+	// +kubebuilder:validation:Optional
+	NotifierIDSelector *xpv1.Selector `json:"notifierIDSelector,omitempty"`
+
+	// MailingLists the report is sent to.
+	// +kubebuilder:validation:MinItems=1
+	MailingLists []string `json:"mailingLists"`
+}
+
+// ReportConfigurationParameters are the configurable fields of a ReportConfiguration.
+type ReportConfigurationParameters struct {
+	// Name of the report configuration.
+	Name string `json:"name"`
+
+	// Description of the report configuration.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description"`
+
+	// VulnReportFilters select the reported vulnerabilities.
+	VulnReportFilters VulnReportFilters `json:"vulnReportFilters"`
+
+	// Schedule of the report.
+	Schedule Schedule `json:"schedule"`
+
+	// ScopeID of the access scope whose deployments are reported.
+	// +kubebuilder:validation:Optional
+	ScopeID string `json:"scopeID,omitempty"`
+
+	// ScopeIDRef references an AccessScope to retrieve its ID.
+	// +kubebuilder:validation:Optional
+	ScopeIDRef *xpv1.Reference `json:"scopeIDRef,omitempty"`
+
+	// ScopeIDSelector selects an AccessScope to retrieve its ID.
+	// +kubebuilder:validation:Optional
+	ScopeIDSelector *xpv1.Selector `json:"scopeIDSelector,omitempty"`
+
+	// EmailConfig of the report.
+	EmailConfig EmailConfig `json:"emailConfig"`
+}
+
+// LastRunStatus is the status of the last report run.
+type LastRunStatus struct {
+	// Status of the run, either SUCCESS or FAILURE.
+	Status string `json:"status,omitempty"`
+
+	// Time of the run.
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// Error of a failed run.
+	Error string `json:"error,omitempty"`
+}
+
+// ReportConfigurationObservation are the observable fields of a ReportConfiguration.
+type ReportConfigurationObservation struct {
+	// ID of the report configuration.
+	ID string `json:"id,omitempty"`
+
+	// Name of the report configuration.
+	Name string `json:"name,omitempty"`
+
+	// LastRunStatus of the report.
+	LastRunStatus *LastRunStatus `json:"lastRunStatus,omitempty"`
+
+	// LastSuccessfulRunTime of the report.
+	LastSuccessfulRunTime *metav1.Time `json:"lastSuccessfulRunTime,omitempty"`
+
+	// LastRun is the value of the run annotation of the last on-demand run.
+	LastRun string `json:"lastRun,omitempty"`
+}
+
+// A ReportConfigurationSpec defines the desired state of a ReportConfiguration.
+type ReportConfigurationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ReportConfigurationParameters `json:"forProvider"`
+}
+
+// A ReportConfigurationStatus represents the observed state of a ReportConfiguration.
+type ReportConfigurationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ReportConfigurationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ReportConfiguration periodically emails a vulnerability report.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="LAST-RUN",type="string",JSONPath=".status.atProvider.lastRunStatus.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type ReportConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReportConfigurationSpec   `json:"spec"`
+	Status ReportConfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReportConfigurationList contains a list of ReportConfiguration
+type ReportConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReportConfiguration `json:"items"`
+}
+
+// ReportConfiguration type metadata.
+var (
+	ReportConfigurationKind             = reflect.TypeOf(ReportConfiguration{}).Name()
+	ReportConfigurationGroupKind        = schema.GroupKind{Group: Group, Kind: ReportConfigurationKind}.String()
+	ReportConfigurationKindAPIVersion   = ReportConfigurationKind + "." + SchemeGroupVersion.String()
+	ReportConfigurationGroupVersionKind = SchemeGroupVersion.WithKind(ReportConfigurationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ReportConfiguration{}, &ReportConfigurationList{})
+}
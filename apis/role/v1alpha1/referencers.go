@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	accessscopev1alpha1 "github.com/stehessel/provider-stackrox/apis/accessscope/v1alpha1"
+	permissionsetv1alpha1 "github.com/stehessel/provider-stackrox/apis/permissionset/v1alpha1"
+)
+
+// PermissionSetID extracts the Central ID of a PermissionSet.
+func PermissionSetID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*permissionsetv1alpha1.PermissionSet)
+		if !ok {
+			return ""
+		}
+		return cr.Status.AtProvider.ID
+	}
+}
+
+// AccessScopeID extracts the Central ID of an AccessScope.
+func AccessScopeID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*accessscopev1alpha1.AccessScope)
+		if !ok {
+			return ""
+		}
+		return cr.Status.AtProvider.ID
+	}
+}
+
+// ResolveReferences of this Role.
+func (mg *Role) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.PermissionSetID,
+		Reference:    mg.Spec.ForProvider.PermissionSetIDRef,
+		Selector:     mg.Spec.ForProvider.PermissionSetIDSelector,
+		To:           reference.To{Managed: &permissionsetv1alpha1.PermissionSet{}, List: &permissionsetv1alpha1.PermissionSetList{}},
+		Extract:      PermissionSetID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.permissionSetID")
+	}
+	mg.Spec.ForProvider.PermissionSetID = rsp.ResolvedValue
+	mg.Spec.ForProvider.PermissionSetIDRef = rsp.ResolvedReference
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccessScopeID,
+		Reference:    mg.Spec.ForProvider.AccessScopeIDRef,
+		Selector:     mg.Spec.ForProvider.AccessScopeIDSelector,
+		To:           reference.To{Managed: &accessscopev1alpha1.AccessScope{}, List: &accessscopev1alpha1.AccessScopeList{}},
+		Extract:      AccessScopeID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accessScopeID")
+	}
+	mg.Spec.ForProvider.AccessScopeID = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccessScopeIDRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AnnotationKeyPreviousConfig records the sensor upgrade configuration as it
+// was before the SensorUpgradeConfig took over, so that it can be restored on
+// deletion.
+const AnnotationKeyPreviousConfig = "sensorupgradeconfig.stackrox.crossplane.io/previous-config"
+
+// SensorUpgradeConfigParameters are the configurable fields of a
+// SensorUpgradeConfig.
+type SensorUpgradeConfigParameters struct {
+	// EnableAutoUpgrade upgrades secured cluster sensors automatically when
+	// Central is upgraded.
+	EnableAutoUpgrade bool `json:"enableAutoUpgrade"`
+}
+
+// SensorUpgradeConfigObservation are the observable fields of a
+// SensorUpgradeConfig.
+type SensorUpgradeConfigObservation struct {
+	// EnableAutoUpgrade as currently set in Central.
+	EnableAutoUpgrade bool `json:"enableAutoUpgrade,omitempty"`
+
+	// AutoUpgradeFeature is SUPPORTED if Central can upgrade sensors
+	// automatically, NOT_SUPPORTED otherwise.
+	AutoUpgradeFeature string `json:"autoUpgradeFeature,omitempty"`
+}
+
+// A SensorUpgradeConfigSpec defines the desired state of a SensorUpgradeConfig.
+type SensorUpgradeConfigSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SensorUpgradeConfigParameters `json:"forProvider"`
+}
+
+// A SensorUpgradeConfigStatus represents the observed state of a
+// SensorUpgradeConfig.
+type SensorUpgradeConfigStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SensorUpgradeConfigObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SensorUpgradeConfig manages the sensor upgrade configuration of a
+// Central. The configuration is a singleton, so only one SensorUpgradeConfig
+// should reference a given ProviderConfig. Deleting a SensorUpgradeConfig with
+// the Delete deletion policy restores the previous configuration, the Orphan
+// deletion policy leaves the current configuration in place.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AUTO-UPGRADE",type="boolean",JSONPath=".status.atProvider.enableAutoUpgrade"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type SensorUpgradeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SensorUpgradeConfigSpec   `json:"spec"`
+	Status SensorUpgradeConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SensorUpgradeConfigList contains a list of SensorUpgradeConfig
+type SensorUpgradeConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SensorUpgradeConfig `json:"items"`
+}
+
+// SensorUpgradeConfig type metadata.
+var (
+	SensorUpgradeConfigKind             = reflect.TypeOf(SensorUpgradeConfig{}).Name()
+	SensorUpgradeConfigGroupKind        = schema.GroupKind{Group: Group, Kind: SensorUpgradeConfigKind}.String()
+	SensorUpgradeConfigKindAPIVersion   = SensorUpgradeConfigKind + "." + SchemeGroupVersion.String()
+	SensorUpgradeConfigGroupVersionKind = SchemeGroupVersion.WithKind(SensorUpgradeConfigKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SensorUpgradeConfig{}, &SensorUpgradeConfigList{})
+}
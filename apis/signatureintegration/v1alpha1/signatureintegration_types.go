@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A ConfigMapKeySelector is a reference to a key in a ConfigMap in an
+// arbitrary namespace.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key whose value is selected.
+	Key string `json:"key"`
+}
+
+// CosignPublicKey is a named public key used to verify cosign signatures.
+// Exactly one of the PEM sources must be set.
+type CosignPublicKey struct {
+	// Name of the public key.
+	Name string `json:"name"`
+
+	// PublicKeyPEM is the PEM encoded public key.
+	// +kubebuilder:validation:Optional
+	PublicKeyPEM string `json:"publicKeyPEM,omitempty"`
+
+	// PublicKeyPEMConfigMapRef references a ConfigMap key holding the PEM
+	// encoded public key.
+	// +kubebuilder:validation:Optional
+	PublicKeyPEMConfigMapRef *ConfigMapKeySelector `json:"publicKeyPEMConfigMapRef,omitempty"`
+
+	// PublicKeyPEMSecretRef references a Secret key holding the PEM encoded
+	// public key.
+	// +kubebuilder:validation:Optional
+	PublicKeyPEMSecretRef *xpv1.SecretKeySelector `json:"publicKeyPEMSecretRef,omitempty"`
+}
+
+// SignatureIntegrationParameters are the configurable fields of a SignatureIntegration.
+type SignatureIntegrationParameters struct {
+	// Name of the signature integration.
+	Name string `json:"name"`
+
+	// PublicKeys used to verify cosign signatures.
+	// +kubebuilder:validation:MinItems=1
+	PublicKeys []CosignPublicKey `json:"publicKeys"`
+}
+
+// SignatureIntegrationObservation are the observable fields of a SignatureIntegration.
+type SignatureIntegrationObservation struct {
+	// ID of the signature integration. Policies reference it in their
+	// image signature verification criteria.
+	ID string `json:"id,omitempty"`
+
+	// Name of the signature integration.
+	Name string `json:"name,omitempty"`
+}
+
+// A SignatureIntegrationSpec defines the desired state of a SignatureIntegration.
+type SignatureIntegrationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SignatureIntegrationParameters `json:"forProvider"`
+}
+
+// A SignatureIntegrationStatus represents the observed state of a SignatureIntegration.
+type SignatureIntegrationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SignatureIntegrationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SignatureIntegration verifies image signatures with cosign public keys.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.atProvider.id"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type SignatureIntegration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SignatureIntegrationSpec   `json:"spec"`
+	Status SignatureIntegrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SignatureIntegrationList contains a list of SignatureIntegration
+type SignatureIntegrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SignatureIntegration `json:"items"`
+}
+
+// SignatureIntegration type metadata.
+var (
+	SignatureIntegrationKind             = reflect.TypeOf(SignatureIntegration{}).Name()
+	SignatureIntegrationGroupKind        = schema.GroupKind{Group: Group, Kind: SignatureIntegrationKind}.String()
+	SignatureIntegrationKindAPIVersion   = SignatureIntegrationKind + "." + SchemeGroupVersion.String()
+	SignatureIntegrationGroupVersionKind = SchemeGroupVersion.WithKind(SignatureIntegrationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SignatureIntegration{}, &SignatureIntegrationList{})
+}
@@ -20,9 +20,26 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	accessscopev1alpha1 "github.com/stehessel/provider-stackrox/apis/accessscope/v1alpha1"
+	apitokenv1alpha1 "github.com/stehessel/provider-stackrox/apis/apitoken/v1alpha1"
+	authproviderv1alpha1 "github.com/stehessel/provider-stackrox/apis/authprovider/v1alpha1"
+	centralconfigv1alpha1 "github.com/stehessel/provider-stackrox/apis/centralconfig/v1alpha1"
 	clusterv1alpha1 "github.com/stehessel/provider-stackrox/apis/cluster/v1alpha1"
+	externalbackupv1alpha1 "github.com/stehessel/provider-stackrox/apis/externalbackup/v1alpha1"
+	groupv1alpha1 "github.com/stehessel/provider-stackrox/apis/group/v1alpha1"
+	imageintegrationv1alpha1 "github.com/stehessel/provider-stackrox/apis/imageintegration/v1alpha1"
 	initbundlev1alpha1 "github.com/stehessel/provider-stackrox/apis/initbundle/v1alpha1"
+	networkbaselinev1alpha1 "github.com/stehessel/provider-stackrox/apis/networkbaseline/v1alpha1"
+	notifierv1alpha1 "github.com/stehessel/provider-stackrox/apis/notifier/v1alpha1"
+	permissionsetv1alpha1 "github.com/stehessel/provider-stackrox/apis/permissionset/v1alpha1"
+	policyv1alpha1 "github.com/stehessel/provider-stackrox/apis/policy/v1alpha1"
+	processbaselinev1alpha1 "github.com/stehessel/provider-stackrox/apis/processbaseline/v1alpha1"
+	reportconfigurationv1alpha1 "github.com/stehessel/provider-stackrox/apis/reportconfiguration/v1alpha1"
+	rolev1alpha1 "github.com/stehessel/provider-stackrox/apis/role/v1alpha1"
+	sensorupgradeconfigv1alpha1 "github.com/stehessel/provider-stackrox/apis/sensorupgradeconfig/v1alpha1"
+	signatureintegrationv1alpha1 "github.com/stehessel/provider-stackrox/apis/signatureintegration/v1alpha1"
 	stackroxv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	vulnerabilityexceptionv1alpha1 "github.com/stehessel/provider-stackrox/apis/vulnerabilityexception/v1alpha1"
 )
 
 func init() {
@@ -31,6 +48,23 @@ func init() {
 		stackroxv1alpha1.SchemeBuilder.AddToScheme,
 		clusterv1alpha1.SchemeBuilder.AddToScheme,
 		initbundlev1alpha1.SchemeBuilder.AddToScheme,
+		policyv1alpha1.SchemeBuilder.AddToScheme,
+		notifierv1alpha1.SchemeBuilder.AddToScheme,
+		imageintegrationv1alpha1.SchemeBuilder.AddToScheme,
+		authproviderv1alpha1.SchemeBuilder.AddToScheme,
+		permissionsetv1alpha1.SchemeBuilder.AddToScheme,
+		accessscopev1alpha1.SchemeBuilder.AddToScheme,
+		rolev1alpha1.SchemeBuilder.AddToScheme,
+		groupv1alpha1.SchemeBuilder.AddToScheme,
+		apitokenv1alpha1.SchemeBuilder.AddToScheme,
+		externalbackupv1alpha1.SchemeBuilder.AddToScheme,
+		signatureintegrationv1alpha1.SchemeBuilder.AddToScheme,
+		centralconfigv1alpha1.SchemeBuilder.AddToScheme,
+		reportconfigurationv1alpha1.SchemeBuilder.AddToScheme,
+		vulnerabilityexceptionv1alpha1.SchemeBuilder.AddToScheme,
+		networkbaselinev1alpha1.SchemeBuilder.AddToScheme,
+		processbaselinev1alpha1.SchemeBuilder.AddToScheme,
+		sensorupgradeconfigv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -0,0 +1,203 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types and reasons of managed resources that Central tests before
+// creating them, e.g. image integrations and external backups.
+const (
+	TypeTested xpv1.ConditionType = "Tested"
+
+	ReasonTestSucceeded xpv1.ConditionReason = "TestSucceeded"
+	ReasonTestFailed    xpv1.ConditionReason = "TestFailed"
+	ReasonTestSkipped   xpv1.ConditionReason = "TestSkipped"
+)
+
+// TestSucceeded returns a condition that indicates that Central successfully
+// tested the external resource.
+func TestSucceeded() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTested,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTestSucceeded,
+	}
+}
+
+// TestFailed returns a condition that indicates that Central could not
+// successfully test the external resource.
+func TestFailed(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTested,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTestFailed,
+		Message:            err.Error(),
+	}
+}
+
+// TestSkipped returns a condition that indicates that the external resource
+// was created without a test.
+func TestSkipped() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTested,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTestSkipped,
+	}
+}
+
+// Condition type and reasons of the sensor upgrades and certificate rotations
+// of clusters.
+const (
+	TypeSensorUpgrade xpv1.ConditionType = "SensorUpgrade"
+
+	ReasonUpgradeInProgress xpv1.ConditionReason = "UpgradeInProgress"
+	ReasonUpgradeComplete   xpv1.ConditionReason = "UpgradeComplete"
+	ReasonUpgradeFailed     xpv1.ConditionReason = "UpgradeFailed"
+)
+
+// UpgradeInProgress returns a condition that indicates that an upgrade or
+// certificate rotation of the sensor is in progress.
+func UpgradeInProgress(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSensorUpgrade,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUpgradeInProgress,
+		Message:            msg,
+	}
+}
+
+// UpgradeComplete returns a condition that indicates that the last upgrade or
+// certificate rotation of the sensor completed.
+func UpgradeComplete(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSensorUpgrade,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUpgradeComplete,
+		Message:            msg,
+	}
+}
+
+// UpgradeFailed returns a condition that indicates that the last upgrade or
+// certificate rotation of the sensor failed.
+func UpgradeFailed(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSensorUpgrade,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUpgradeFailed,
+		Message:            msg,
+	}
+}
+
+// Reasons a cluster is not ready.
+const (
+	ReasonUninitialized   xpv1.ConditionReason = "Uninitialized"
+	ReasonSensorUnhealthy xpv1.ConditionReason = "SensorUnhealthy"
+	ReasonUnhealthy       xpv1.ConditionReason = "Unhealthy"
+)
+
+// Uninitialized returns a condition that indicates that no sensor connected
+// to the cluster yet.
+func Uninitialized() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUninitialized,
+	}
+}
+
+// SensorUnhealthy returns a condition that indicates that the sensor of the
+// cluster is not healthy.
+func SensorUnhealthy(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSensorUnhealthy,
+		Message:            msg,
+	}
+}
+
+// Unhealthy returns a condition that indicates that the secured cluster
+// services are not healthy.
+func Unhealthy(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnhealthy,
+		Message:            msg,
+	}
+}
+
+// Condition type and reasons of clusters whose configuration is owned by a
+// Helm chart or the operator rather than by Central.
+const (
+	TypeExternallyManaged xpv1.ConditionType = "ExternallyManaged"
+
+	ReasonManagedByHelm     xpv1.ConditionReason = "ManagedByHelm"
+	ReasonManagedByOperator xpv1.ConditionReason = "ManagedByOperator"
+	ReasonManagedByCentral  xpv1.ConditionReason = "ManagedByCentral"
+)
+
+// ManagedByHelm returns a condition that indicates that the static
+// configuration of the cluster is read-only because it is rendered by a Helm
+// chart.
+func ManagedByHelm() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeExternallyManaged,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonManagedByHelm,
+		Message:            "Static cluster configuration is read-only, it is owned by the Helm chart",
+	}
+}
+
+// ManagedByOperator returns a condition that indicates that the static
+// configuration of the cluster is read-only because it is rendered by the
+// operator.
+func ManagedByOperator() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeExternallyManaged,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonManagedByOperator,
+		Message:            "Static cluster configuration is read-only, it is owned by the SecuredCluster resource",
+	}
+}
+
+// ManagedByCentral returns a condition that indicates that the configuration
+// of the cluster is managed through Central.
+func ManagedByCentral() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeExternallyManaged,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonManagedByCentral,
+	}
+}
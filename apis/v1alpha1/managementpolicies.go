@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// A ManagementAction is an action that the provider may take on an external
+// resource.
+// +kubebuilder:validation:Enum=Observe;Create;Update;Delete;LateInitialize;*
+type ManagementAction string
+
+// Management actions.
+const (
+	// ManagementActionObserve means that the provider observes the external
+	// resource and reports its state in the status of the managed resource.
+	ManagementActionObserve ManagementAction = "Observe"
+
+	// ManagementActionCreate means that the provider creates the external
+	// resource if it does not exist.
+	ManagementActionCreate ManagementAction = "Create"
+
+	// ManagementActionUpdate means that the provider updates the external
+	// resource if it drifted from the managed resource.
+	ManagementActionUpdate ManagementAction = "Update"
+
+	// ManagementActionDelete means that the provider deletes the external
+	// resource when the managed resource is deleted.
+	ManagementActionDelete ManagementAction = "Delete"
+
+	// ManagementActionLateInitialize means that the provider fills unset
+	// parameters of the managed resource from the external resource.
+	ManagementActionLateInitialize ManagementAction = "LateInitialize"
+
+	// ManagementActionAll means that the provider may take all actions.
+	ManagementActionAll ManagementAction = "*"
+)
+
+// ManagementPolicies are the actions that the provider may take on an external
+// resource. An empty list allows all actions, while a list with only Observe
+// makes the managed resource observe-only.
+type ManagementPolicies []ManagementAction
+
+// Allows returns true if the management policies allow the supplied action.
+func (p ManagementPolicies) Allows(a ManagementAction) bool {
+	if len(p) == 0 {
+		return true
+	}
+	for _, it := range p {
+		if it == a || it == ManagementActionAll {
+			return true
+		}
+	}
+	return false
+}
+
+// A ManagementPoliciesAccessor is a managed resource with management policies.
+type ManagementPoliciesAccessor interface {
+	GetManagementPolicies() ManagementPolicies
+	SetManagementPolicies(p ManagementPolicies)
+}
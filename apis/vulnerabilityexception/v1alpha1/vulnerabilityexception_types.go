@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ImageScope limits an exception to the images of a repository.
+type ImageScope struct {
+	// Registry of the images, e.g. quay.io.
+	Registry string `json:"registry"`
+
+	// Remote of the images, e.g. stackrox-io/main.
+	Remote string `json:"remote"`
+
+	// Tag of the images. A value of ".*" matches all tags.
+	Tag string `json:"tag"`
+}
+
+// Deferral defers a vulnerability. A deferral without expiry lasts
+// indefinitely.
+type Deferral struct {
+	// ExpiresOn is the time at which the deferral expires.
+	// +kubebuilder:validation:Optional
+	ExpiresOn *metav1.Time `json:"expiresOn,omitempty"`
+
+	// ExpiresWhenFixed expires the deferral once a fix is available.
+	// +kubebuilder:validation:Optional
+	ExpiresWhenFixed bool `json:"expiresWhenFixed,omitempty"`
+}
+
+// FalsePositive marks a vulnerability as false positive.
+type FalsePositive struct{}
+
+// Approval approves the exception.
+type Approval struct {
+	// ProviderConfigReference of the identity that approves the exception.
+	// Central does not allow users to approve their own requests, so it
+	// usually differs from the ProviderConfig of the resource, which is used
+	// if it is unset.
+	// +kubebuilder:validation:Optional
+	ProviderConfigReference *xpv1.Reference `json:"providerConfigRef,omitempty"`
+
+	// Comment of the approval.
+	// +kubebuilder:validation:Optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// VulnerabilityExceptionParameters are the configurable fields of a
+// VulnerabilityException. Exactly one of deferral and falsePositive must be
+// set.
+type VulnerabilityExceptionParameters struct {
+	// CVE the exception applies to. Immutable.
+	CVE string `json:"cve"`
+
+	// Comment explaining the exception.
+	Comment string `json:"comment"`
+
+	// Image limits the exception to the images of a repository. The exception
+	// applies globally if unset. Immutable.
+	// +kubebuilder:validation:Optional
+	Image *ImageScope `json:"image,omitempty"`
+
+	// Deferral defers the vulnerability. Its expiry can be changed.
+	// +kubebuilder:validation:Optional
+	Deferral *Deferral `json:"deferral,omitempty"`
+
+	// FalsePositive marks the vulnerability as false positive. Immutable.
+	// +kubebuilder:validation:Optional
+	FalsePositive *FalsePositive `json:"falsePositive,omitempty"`
+
+	// Approval approves the exception once it is set.
+	// +kubebuilder:validation:Optional
+	Approval *Approval `json:"approval,omitempty"`
+}
+
+// VulnerabilityExceptionObservation are the observable fields of a
+// VulnerabilityException.
+type VulnerabilityExceptionObservation struct {
+	// ID of the vulnerability request.
+	ID string `json:"id,omitempty"`
+
+	// Status of the request, one of PENDING, APPROVED, DENIED and
+	// APPROVED_PENDING_UPDATE.
+	Status string `json:"status,omitempty"`
+
+	// Expired is true once the exception no longer applies.
+	Expired bool `json:"expired,omitempty"`
+
+	// Requestor of the exception.
+	Requestor string `json:"requestor,omitempty"`
+
+	// Approvers of the exception.
+	Approvers []string `json:"approvers,omitempty"`
+
+	// ExpiresOn is the time at which an approved deferral expires.
+	ExpiresOn *metav1.Time `json:"expiresOn,omitempty"`
+
+	// ExpiresWhenFixed is true if an approved deferral expires once a fix is
+	// available.
+	ExpiresWhenFixed bool `json:"expiresWhenFixed,omitempty"`
+}
+
+// A VulnerabilityExceptionSpec defines the desired state of a VulnerabilityException.
+type VulnerabilityExceptionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       VulnerabilityExceptionParameters `json:"forProvider"`
+}
+
+// A VulnerabilityExceptionStatus represents the observed state of a VulnerabilityException.
+type VulnerabilityExceptionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          VulnerabilityExceptionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A VulnerabilityException defers a vulnerability or marks it as false
+// positive. It becomes ready once the request is approved.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="CVE",type="string",JSONPath=".spec.forProvider.cve"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,stackrox}
+type VulnerabilityException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VulnerabilityExceptionSpec   `json:"spec"`
+	Status VulnerabilityExceptionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VulnerabilityExceptionList contains a list of VulnerabilityException
+type VulnerabilityExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VulnerabilityException `json:"items"`
+}
+
+// VulnerabilityException type metadata.
+var (
+	VulnerabilityExceptionKind             = reflect.TypeOf(VulnerabilityException{}).Name()
+	VulnerabilityExceptionGroupKind        = schema.GroupKind{Group: Group, Kind: VulnerabilityExceptionKind}.String()
+	VulnerabilityExceptionKindAPIVersion   = VulnerabilityExceptionKind + "." + SchemeGroupVersion.String()
+	VulnerabilityExceptionGroupVersionKind = SchemeGroupVersion.WithKind(VulnerabilityExceptionKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&VulnerabilityException{}, &VulnerabilityExceptionList{})
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package central
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/stackrox/rox/pkg/apiparams"
+	"github.com/stackrox/rox/pkg/clientconn"
+	"github.com/stackrox/rox/pkg/mtls"
+)
+
+const sensorBundlePath = "/api/extensions/clusters/zip"
+
+// A BundleClient downloads deployment bundles from Central. Bundles are only
+// served through HTTP, not through the gRPC API.
+type BundleClient interface {
+	// GetSensorBundle returns the zipped sensor deployment bundle of a
+	// cluster. Central issues new sensor certificates on every call.
+	GetSensorBundle(ctx context.Context, params apiparams.ClusterZip) ([]byte, error)
+}
+
+type bundleClient struct {
+	client *http.Client
+}
+
+// NewBundleClient creates a BundleClient for Central with the correct auth.
+func NewBundleClient(endpoint string, apiToken string) (BundleClient, error) {
+	opts, err := newOptions(endpoint, apiToken)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := clientconn.HTTPTransport(mtls.CentralSubject, endpoint, opts, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create HTTP transport")
+	}
+	return &bundleClient{client: &http.Client{Transport: transport}}, nil
+}
+
+func (c *bundleClient) GetSensorBundle(ctx context.Context, params apiparams.ClusterZip) ([]byte, error) {
+	body, err := json.Marshal(&params)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sensorBundlePath, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Nothing to do about a failed close of a read body.
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	return data, nil
+}
@@ -24,17 +24,24 @@ type grpcConfig struct {
 	endpoint string
 }
 
-// NewGRPC creates a grpc connection to Central with the correct auth.
-func NewGRPC(ctx context.Context, endpoint string, apiToken string) (*grpc.ClientConn, error) {
+func newOptions(endpoint string, apiToken string) (clientconn.Options, error) {
 	serverName, _, _, err := netutil.ParseEndpoint(endpoint)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not parse endpoint")
+		return clientconn.Options{}, errors.Wrap(err, "could not parse endpoint")
 	}
-	opts := clientconn.Options{
+	return clientconn.Options{
 		TLS: clientconn.TLSConfigOptions{
 			ServerName: serverName,
 		},
 		PerRPCCreds: tokenbased.PerRPCCredentials(apiToken),
+	}, nil
+}
+
+// NewGRPC creates a grpc connection to Central with the correct auth.
+func NewGRPC(ctx context.Context, endpoint string, apiToken string) (*grpc.ClientConn, error) {
+	opts, err := newOptions(endpoint, apiToken)
+	if err != nil {
+		return nil, err
 	}
 	return createGRPCConn(ctx, grpcConfig{
 		opts:     opts,
@@ -0,0 +1,91 @@
+// Package fake provides fake Central clients for tests.
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+)
+
+// RoleService is a fake v1.RoleServiceClient. Its methods call the Mock
+// function of the same name, and panic if that is not set.
+type RoleService struct {
+	v1.RoleServiceClient
+
+	MockGetRoles   func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.GetRolesResponse, error)
+	MockCreateRole func(ctx context.Context, in *v1.CreateRoleRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockUpdateRole func(ctx context.Context, in *storage.Role, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeleteRole func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+
+	MockListPermissionSets  func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.ListPermissionSetsResponse, error)
+	MockPostPermissionSet   func(ctx context.Context, in *storage.PermissionSet, opts ...grpc.CallOption) (*storage.PermissionSet, error)
+	MockPutPermissionSet    func(ctx context.Context, in *storage.PermissionSet, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeletePermissionSet func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+
+	MockListSimpleAccessScopes  func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.ListSimpleAccessScopesResponse, error)
+	MockPostSimpleAccessScope   func(ctx context.Context, in *storage.SimpleAccessScope, opts ...grpc.CallOption) (*storage.SimpleAccessScope, error)
+	MockPutSimpleAccessScope    func(ctx context.Context, in *storage.SimpleAccessScope, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeleteSimpleAccessScope func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+// GetRoles calls MockGetRoles.
+func (f *RoleService) GetRoles(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.GetRolesResponse, error) {
+	return f.MockGetRoles(ctx, in, opts...)
+}
+
+// CreateRole calls MockCreateRole.
+func (f *RoleService) CreateRole(ctx context.Context, in *v1.CreateRoleRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockCreateRole(ctx, in, opts...)
+}
+
+// UpdateRole calls MockUpdateRole.
+func (f *RoleService) UpdateRole(ctx context.Context, in *storage.Role, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockUpdateRole(ctx, in, opts...)
+}
+
+// DeleteRole calls MockDeleteRole.
+func (f *RoleService) DeleteRole(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteRole(ctx, in, opts...)
+}
+
+// ListPermissionSets calls MockListPermissionSets.
+func (f *RoleService) ListPermissionSets(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.ListPermissionSetsResponse, error) {
+	return f.MockListPermissionSets(ctx, in, opts...)
+}
+
+// PostPermissionSet calls MockPostPermissionSet.
+func (f *RoleService) PostPermissionSet(ctx context.Context, in *storage.PermissionSet, opts ...grpc.CallOption) (*storage.PermissionSet, error) {
+	return f.MockPostPermissionSet(ctx, in, opts...)
+}
+
+// PutPermissionSet calls MockPutPermissionSet.
+func (f *RoleService) PutPermissionSet(ctx context.Context, in *storage.PermissionSet, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockPutPermissionSet(ctx, in, opts...)
+}
+
+// DeletePermissionSet calls MockDeletePermissionSet.
+func (f *RoleService) DeletePermissionSet(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeletePermissionSet(ctx, in, opts...)
+}
+
+// ListSimpleAccessScopes calls MockListSimpleAccessScopes.
+func (f *RoleService) ListSimpleAccessScopes(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.ListSimpleAccessScopesResponse, error) {
+	return f.MockListSimpleAccessScopes(ctx, in, opts...)
+}
+
+// PostSimpleAccessScope calls MockPostSimpleAccessScope.
+func (f *RoleService) PostSimpleAccessScope(ctx context.Context, in *storage.SimpleAccessScope, opts ...grpc.CallOption) (*storage.SimpleAccessScope, error) {
+	return f.MockPostSimpleAccessScope(ctx, in, opts...)
+}
+
+// PutSimpleAccessScope calls MockPutSimpleAccessScope.
+func (f *RoleService) PutSimpleAccessScope(ctx context.Context, in *storage.SimpleAccessScope, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockPutSimpleAccessScope(ctx, in, opts...)
+}
+
+// DeleteSimpleAccessScope calls MockDeleteSimpleAccessScope.
+func (f *RoleService) DeleteSimpleAccessScope(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteSimpleAccessScope(ctx, in, opts...)
+}
@@ -0,0 +1,165 @@
+package central
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultIdleTimeout is the time after which unused connections of a Pool are
+// closed.
+const DefaultIdleTimeout = 10 * time.Minute
+
+// Connections is the connection pool that is shared by all controllers.
+var Connections = NewPool(DefaultIdleTimeout)
+
+// poolKey identifies the connection of a ProviderConfig. The token is hashed
+// so that it is not kept in memory longer than necessary.
+type poolKey struct {
+	uid       types.UID
+	endpoint  string
+	tokenHash [sha256.Size]byte
+}
+
+// A pooledConn is a connection of a Pool. conn and err are set once ready is
+// closed.
+type pooledConn struct {
+	conn  *grpc.ClientConn
+	err   error
+	ready chan struct{}
+
+	// refs counts the reconciles that lease the connection. An evicted
+	// connection is closed once the last lease ends.
+	refs     int
+	lastUsed time.Time
+	evicted  bool
+}
+
+// A Pool shares gRPC connections to Central between reconciles, so that every
+// reconcile does not dial Central and perform a TLS handshake. Connections are
+// keyed by ProviderConfig UID, endpoint and token hash. They are evicted when
+// the endpoint or token of their ProviderConfig changes, or when they have not
+// been used for the idle timeout. Evicted connections are closed once no
+// reconcile uses them anymore.
+type Pool struct {
+	mu          sync.Mutex
+	conns       map[poolKey]*pooledConn
+	idleTimeout time.Duration
+
+	dial func(ctx context.Context, endpoint string, apiToken string) (*grpc.ClientConn, error)
+	now  func() time.Time
+}
+
+// NewPool creates a connection pool that closes connections after they have
+// not been used for the idle timeout.
+func NewPool(idleTimeout time.Duration) *Pool {
+	return &Pool{
+		conns:       map[poolKey]*pooledConn{},
+		idleTimeout: idleTimeout,
+		dial:        NewGRPC,
+		now:         time.Now,
+	}
+}
+
+// Get returns the connection to Central of the ProviderConfig with the given
+// UID, and dials Central if there is none for the endpoint and token yet. The
+// connection is leased until ctx is done, which for a reconcile is when the
+// reconcile ends. It is owned by the pool and must not be closed by the
+// caller.
+func (p *Pool) Get(ctx context.Context, uid types.UID, endpoint string, apiToken string) (*grpc.ClientConn, error) {
+	conn, release, err := p.lease(ctx, uid, endpoint, apiToken)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		release()
+	}()
+	return conn, nil
+}
+
+// lease returns the connection of the ProviderConfig and a function that ends
+// the lease. Only one reconcile dials a missing connection, while the others
+// wait for it without blocking the pool.
+func (p *Pool) lease(ctx context.Context, uid types.UID, endpoint string, apiToken string) (*grpc.ClientConn, func(), error) {
+	key := poolKey{uid: uid, endpoint: endpoint, tokenHash: sha256.Sum256([]byte(apiToken))}
+
+	p.mu.Lock()
+	p.evictLocked(key)
+	c, ok := p.conns[key]
+	if !ok {
+		c = &pooledConn{ready: make(chan struct{})}
+		p.conns[key] = c
+	}
+	c.refs++
+	p.mu.Unlock()
+
+	release := func() { p.release(c) }
+	if !ok {
+		c.conn, c.err = p.dial(ctx, endpoint, apiToken)
+		close(c.ready)
+	}
+
+	select {
+	case <-c.ready:
+	case <-ctx.Done():
+		release()
+		return nil, nil, ctx.Err()
+	}
+	if c.err != nil {
+		// Forget the failed dial, so that the next lease dials again.
+		p.mu.Lock()
+		if p.conns[key] == c {
+			delete(p.conns, key)
+			c.evicted = true
+		}
+		p.mu.Unlock()
+		release()
+		return nil, nil, c.err
+	}
+	return c.conn, release, nil
+}
+
+// evictLocked evicts the connections of previous credentials of the
+// ProviderConfig of the key, and all connections that went idle. The caller
+// must hold p.mu.
+func (p *Pool) evictLocked(key poolKey) {
+	now := p.now()
+	for k, c := range p.conns {
+		stale := k.uid == key.uid && k != key
+		idle := c.refs == 0 && now.Sub(c.lastUsed) > p.idleTimeout
+		if !stale && !idle {
+			continue
+		}
+		delete(p.conns, k)
+		c.evicted = true
+		if c.refs == 0 {
+			closeConn(c)
+		}
+	}
+}
+
+// release ends a lease of the connection.
+func (p *Pool) release(c *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c.refs--
+	c.lastUsed = p.now()
+	if c.evicted && c.refs == 0 {
+		closeConn(c)
+	}
+}
+
+// closeConn closes the connection of a pooledConn without leases. Without
+// leases its dial has finished. Closing only fails if the connection is
+// already closed, so the error is ignored.
+func closeConn(c *pooledConn) {
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+}
@@ -0,0 +1,270 @@
+package central
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakePool returns a pool whose connections never connect to Central, and a
+// pointer to the number of times it dialed.
+func fakePool(now *time.Time) (*Pool, *int) {
+	var mu sync.Mutex
+	dials := 0
+	p := NewPool(time.Minute)
+	p.now = func() time.Time { return *now }
+	p.dial = func(_ context.Context, endpoint string, _ string) (*grpc.ClientConn, error) {
+		mu.Lock()
+		dials++
+		mu.Unlock()
+		return grpc.Dial("passthrough:///"+endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	return p, &dials
+}
+
+func TestPool(t *testing.T) {
+	type lease struct {
+		uid      types.UID
+		endpoint string
+		token    string
+		after    time.Duration
+		// keep the lease until all leases were taken.
+		keep bool
+	}
+
+	cases := map[string]struct {
+		reason    string
+		leases    []lease
+		wantDials int
+		// wantOpen is whether the first connection is still open after all
+		// leases were taken.
+		wantOpen bool
+	}{
+		"Reuse": {
+			reason: "Connections of the same ProviderConfig should be reused.",
+			leases: []lease{
+				{uid: "a", endpoint: "central:443", token: "t1"},
+				{uid: "a", endpoint: "central:443", token: "t1", after: 30 * time.Second},
+				{uid: "a", endpoint: "central:443", token: "t1", after: 30 * time.Second},
+			},
+			wantDials: 1,
+			wantOpen:  true,
+		},
+		"TokenChanged": {
+			reason: "Connections should be evicted when the token of the ProviderConfig changes.",
+			leases: []lease{
+				{uid: "a", endpoint: "central:443", token: "t1"},
+				{uid: "a", endpoint: "central:443", token: "t2"},
+			},
+			wantDials: 2,
+			wantOpen:  false,
+		},
+		"EndpointChanged": {
+			reason: "Connections should be evicted when the endpoint of the ProviderConfig changes.",
+			leases: []lease{
+				{uid: "a", endpoint: "central:443", token: "t1"},
+				{uid: "a", endpoint: "other:443", token: "t1"},
+			},
+			wantDials: 2,
+			wantOpen:  false,
+		},
+		"EvictedInUse": {
+			reason: "Evicted connections should not be closed while a reconcile still uses them.",
+			leases: []lease{
+				{uid: "a", endpoint: "central:443", token: "t1", keep: true},
+				{uid: "a", endpoint: "central:443", token: "t2"},
+			},
+			wantDials: 2,
+			wantOpen:  true,
+		},
+		"OtherProviderConfig": {
+			reason: "Connections of other ProviderConfigs should not be evicted.",
+			leases: []lease{
+				{uid: "a", endpoint: "central:443", token: "t1"},
+				{uid: "b", endpoint: "central:443", token: "t2"},
+			},
+			wantDials: 2,
+			wantOpen:  true,
+		},
+		"Idle": {
+			reason: "Connections should be evicted after the idle timeout.",
+			leases: []lease{
+				{uid: "a", endpoint: "central:443", token: "t1"},
+				{uid: "b", endpoint: "central:443", token: "t1", after: 2 * time.Minute},
+			},
+			wantDials: 2,
+			wantOpen:  false,
+		},
+		"IdleInUse": {
+			reason: "Connections in use should not be evicted after the idle timeout.",
+			leases: []lease{
+				{uid: "a", endpoint: "central:443", token: "t1", keep: true},
+				{uid: "b", endpoint: "central:443", token: "t1", after: 2 * time.Minute},
+			},
+			wantDials: 2,
+			wantOpen:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			p, dials := fakePool(&now)
+
+			var first *grpc.ClientConn
+			var kept []func()
+			for _, l := range tc.leases {
+				now = now.Add(l.after)
+				conn, release, err := p.lease(context.Background(), l.uid, l.endpoint, l.token)
+				if err != nil {
+					t.Fatalf("p.lease(...): %v", err)
+				}
+				if first == nil {
+					first = conn
+				}
+				if l.keep {
+					kept = append(kept, release)
+				} else {
+					release()
+				}
+			}
+
+			if *dials != tc.wantDials {
+				t.Errorf("\n%s\np.lease(...): want %d dials, got %d", tc.reason, tc.wantDials, *dials)
+			}
+			if open := first.GetState() != connectivity.Shutdown; open != tc.wantOpen {
+				t.Errorf("\n%s\np.lease(...): want first connection open %t, got %t", tc.reason, tc.wantOpen, open)
+			}
+			for _, release := range kept {
+				release()
+			}
+		})
+	}
+}
+
+func TestPoolReleaseEvicted(t *testing.T) {
+	now := time.Now()
+	p, _ := fakePool(&now)
+
+	conn, release, err := p.lease(context.Background(), "a", "central:443", "t1")
+	if err != nil {
+		t.Fatalf("p.lease(...): %v", err)
+	}
+	_, release2, err := p.lease(context.Background(), "a", "central:443", "t2")
+	if err != nil {
+		t.Fatalf("p.lease(...): %v", err)
+	}
+	defer release2()
+
+	release()
+	if conn.GetState() != connectivity.Shutdown {
+		t.Errorf("release(): want evicted connection closed after its last lease")
+	}
+}
+
+func TestPoolDialFailed(t *testing.T) {
+	now := time.Now()
+	p, _ := fakePool(&now)
+	errBoom := errors.New("boom")
+	dial := p.dial
+	p.dial = func(_ context.Context, _ string, _ string) (*grpc.ClientConn, error) {
+		return nil, errBoom
+	}
+
+	if _, _, err := p.lease(context.Background(), "a", "central:443", "t1"); !errors.Is(err, errBoom) {
+		t.Fatalf("p.lease(...): want error %v, got %v", errBoom, err)
+	}
+
+	p.dial = dial
+	_, release, err := p.lease(context.Background(), "a", "central:443", "t1")
+	if err != nil {
+		t.Fatalf("p.lease(...): want a failed dial to be retried, got %v", err)
+	}
+	release()
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	now := time.Now()
+	p, dials := fakePool(&now)
+
+	// Dials of ProviderConfig a block until b was dialed, so a slow Central
+	// must not block the connections to other Centrals.
+	dialed := make(chan struct{})
+	dial := p.dial
+	p.dial = func(ctx context.Context, endpoint string, token string) (*grpc.ClientConn, error) {
+		if endpoint == "slow:443" {
+			<-dialed
+		}
+		return dial(ctx, endpoint, token)
+	}
+
+	var wg sync.WaitGroup
+	conns := make([]*grpc.ClientConn, 10)
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn, release, err := p.lease(context.Background(), "a", "slow:443", "t1")
+			if err != nil {
+				t.Errorf("p.lease(...): %v", err)
+				return
+			}
+			defer release()
+			conns[i] = conn
+		}(i)
+	}
+
+	_, release, err := p.lease(context.Background(), "b", "central:443", "t1")
+	if err != nil {
+		t.Fatalf("p.lease(...): %v", err)
+	}
+	release()
+	close(dialed)
+	wg.Wait()
+
+	if *dials != 2 {
+		t.Errorf("p.lease(...): want 2 dials, got %d", *dials)
+	}
+	for _, conn := range conns {
+		if conn != conns[0] {
+			t.Errorf("p.lease(...): want the same connection for all reconciles")
+		}
+	}
+}
+
+func TestPoolGet(t *testing.T) {
+	now := time.Now()
+	p, _ := fakePool(&now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := p.Get(ctx, "a", "central:443", "t1")
+	if err != nil {
+		t.Fatalf("p.Get(...): %v", err)
+	}
+	// Evict the connection while it is leased by the reconcile.
+	_, release, err := p.lease(context.Background(), "a", "central:443", "t2")
+	if err != nil {
+		t.Fatalf("p.lease(...): %v", err)
+	}
+	defer release()
+	if conn.GetState() == connectivity.Shutdown {
+		t.Fatalf("p.Get(...): want connection open until the reconcile ends")
+	}
+
+	// The lease ends when the reconcile context is done.
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for conn.GetState() != connectivity.Shutdown {
+		if time.Now().After(deadline) {
+			t.Fatalf("p.Get(...): want connection closed after the reconcile ended")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package central
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ToTime converts a protobuf timestamp returned by Central. Unset timestamps
+// are converted to nil.
+func ToTime(in *types.Timestamp) *metav1.Time {
+	if in == nil {
+		return nil
+	}
+	t := metav1.NewTime(time.Unix(in.GetSeconds(), int64(in.GetNanos())))
+	return &t
+}
+
+// ToTimestamp converts a time to a protobuf timestamp sent to Central.
+func ToTimestamp(in *metav1.Time) *types.Timestamp {
+	if in == nil {
+		return nil
+	}
+	return &types.Timestamp{Seconds: in.Unix(), Nanos: int32(in.Nanosecond())}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accessscope
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/accessscope/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotAccessScope = "managed resource is not an AccessScope custom resource"
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetPC          = "cannot get ProviderConfig"
+	errGetCreds       = "cannot get credentials"
+	errGetFailed      = "cannot get access scope"
+	errObserveFailed  = "cannot observe access scope"
+	errCreateFailed   = "cannot create access scope"
+	errUpdateFailed   = "cannot update access scope"
+	errDeleteFailed   = "cannot delete access scope"
+)
+
+// Setup adds a controller that reconciles AccessScope managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.AccessScopeGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.AccessScopeGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.AccessScope{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.AccessScope)
+	if !ok {
+		return nil, errors.New(errNotAccessScope)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{svc: v1.NewRoleServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc v1.RoleServiceClient
+}
+
+func generateObservation(in *storage.SimpleAccessScope) v1alpha1.AccessScopeObservation {
+	return v1alpha1.AccessScopeObservation{
+		ID: in.GetId(),
+	}
+}
+
+func generateLabelSelectors(in []v1alpha1.LabelSelector) []*storage.SetBasedLabelSelector {
+	var out []*storage.SetBasedLabelSelector
+	for _, sel := range in {
+		s := &storage.SetBasedLabelSelector{}
+		for _, req := range sel.Requirements {
+			s.Requirements = append(s.Requirements, &storage.SetBasedLabelSelector_Requirement{
+				Key:    req.Key,
+				Op:     storage.SetBasedLabelSelector_Operator(storage.SetBasedLabelSelector_Operator_value[string(req.Op)]),
+				Values: req.Values,
+			})
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func generateAccessScope(in *v1alpha1.AccessScopeParameters, base *storage.SimpleAccessScope) *storage.SimpleAccessScope {
+	if base == nil {
+		base = &storage.SimpleAccessScope{}
+	}
+	base.Name = in.Name
+	base.Description = in.Description
+	base.Rules = &storage.SimpleAccessScope_Rules{
+		IncludedClusters:        in.Rules.IncludedClusters,
+		ClusterLabelSelectors:   generateLabelSelectors(in.Rules.ClusterLabelSelectors),
+		NamespaceLabelSelectors: generateLabelSelectors(in.Rules.NamespaceLabelSelectors),
+	}
+	for _, ns := range in.Rules.IncludedNamespaces {
+		base.Rules.IncludedNamespaces = append(base.Rules.IncludedNamespaces, &storage.SimpleAccessScope_Rules_Namespace{
+			ClusterName:   ns.ClusterName,
+			NamespaceName: ns.NamespaceName,
+		})
+	}
+	return base
+}
+
+func generateLabelSelectorParameters(in []*storage.SetBasedLabelSelector) []v1alpha1.LabelSelector {
+	var out []v1alpha1.LabelSelector
+	for _, sel := range in {
+		s := v1alpha1.LabelSelector{}
+		for _, req := range sel.GetRequirements() {
+			s.Requirements = append(s.Requirements, v1alpha1.LabelSelectorRequirement{
+				Key:    req.GetKey(),
+				Op:     v1alpha1.Operator(req.GetOp().String()),
+				Values: req.GetValues(),
+			})
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// generateAccessScopeParameters converts an observed access scope into
+// parameters. Cluster references and selectors only exist in the spec, so
+// they are taken from the supplied spec.
+func generateAccessScopeParameters(in *storage.SimpleAccessScope, spec *v1alpha1.AccessScopeParameters) v1alpha1.AccessScopeParameters {
+	rules := in.GetRules()
+	out := v1alpha1.AccessScopeParameters{
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+		Rules: v1alpha1.AccessScopeRules{
+			IncludedClusters:        rules.GetIncludedClusters(),
+			IncludedClusterRefs:     spec.Rules.IncludedClusterRefs,
+			IncludedClusterSelector: spec.Rules.IncludedClusterSelector,
+			ClusterLabelSelectors:   generateLabelSelectorParameters(rules.GetClusterLabelSelectors()),
+			NamespaceLabelSelectors: generateLabelSelectorParameters(rules.GetNamespaceLabelSelectors()),
+		},
+	}
+	for _, ns := range rules.GetIncludedNamespaces() {
+		out.Rules.IncludedNamespaces = append(out.Rules.IncludedNamespaces, v1alpha1.Namespace{
+			ClusterName:   ns.GetClusterName(),
+			NamespaceName: ns.GetNamespaceName(),
+		})
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.AccessScope, observed *storage.SimpleAccessScope) (bool, string) {
+	observedParams := generateAccessScopeParameters(observed, &in.Spec.ForProvider)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in access scope\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getAccessScope(ctx context.Context, cr *v1alpha1.AccessScope) (*storage.SimpleAccessScope, error) {
+	resp, err := c.svc.ListSimpleAccessScopes(ctx, &v1.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetAccessScopes() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			return it, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AccessScope)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAccessScope)
+	}
+
+	scope, err := c.getAccessScope(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if scope == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(scope)
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, scope.GetName())
+	upToDate, diff := isUpToDate(cr, scope)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AccessScope)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAccessScope)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	resp, err := c.svc.PostSimpleAccessScope(ctx, generateAccessScope(&cr.Spec.ForProvider, nil))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	cr.Status.AtProvider = generateObservation(resp)
+	meta.SetExternalName(cr, resp.GetName())
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AccessScope)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAccessScope)
+	}
+
+	scope, err := c.getAccessScope(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if scope == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	_, err = c.svc.PutSimpleAccessScope(ctx, generateAccessScope(&cr.Spec.ForProvider, scope))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AccessScope)
+	if !ok {
+		return errors.New(errNotAccessScope)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeleteSimpleAccessScope(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accessscope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/accessscope/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	spec := v1alpha1.AccessScopeParameters{
+		Name: "prod",
+		Rules: v1alpha1.AccessScopeRules{
+			IncludedClusters:        []string{"prod-eu"},
+			IncludedClusterSelector: &xpv1.Selector{MatchLabels: map[string]string{"env": "prod"}},
+			IncludedNamespaces:      []v1alpha1.Namespace{{ClusterName: "shared", NamespaceName: "payments"}},
+			NamespaceLabelSelectors: []v1alpha1.LabelSelector{{Requirements: []v1alpha1.LabelSelectorRequirement{{Key: "team", Op: "IN", Values: []string{"payments"}}}}},
+		},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *storage.SimpleAccessScope
+		want     bool
+	}{
+		"SelectorIgnored": {
+			reason:   "A cluster selector that only exists in the spec should not cause drift.",
+			observed: generateAccessScope(&spec, &storage.SimpleAccessScope{Id: "a"}),
+			want:     true,
+		},
+		"ClusterRemoved": {
+			reason: "A missing cluster should cause drift.",
+			observed: &storage.SimpleAccessScope{Name: "prod", Rules: &storage.SimpleAccessScope_Rules{
+				IncludedNamespaces: []*storage.SimpleAccessScope_Rules_Namespace{{ClusterName: "shared", NamespaceName: "payments"}},
+				NamespaceLabelSelectors: []*storage.SetBasedLabelSelector{{Requirements: []*storage.SetBasedLabelSelector_Requirement{
+					{Key: "team", Op: storage.SetBasedLabelSelector_IN, Values: []string{"payments"}},
+				}}},
+			}},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.AccessScope{Spec: v1alpha1.AccessScopeSpec{ForProvider: spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+func accessScope() *v1alpha1.AccessScope {
+	return &v1alpha1.AccessScope{Spec: v1alpha1.AccessScopeSpec{ForProvider: v1alpha1.AccessScopeParameters{
+		Name:  "prod",
+		Rules: v1alpha1.AccessScopeRules{IncludedClusters: []string{"prod-eu"}},
+	}}}
+}
+
+func withAccessScopes(s ...*storage.SimpleAccessScope) *fake.RoleService {
+	return &fake.RoleService{
+		MockListSimpleAccessScopes: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.ListSimpleAccessScopesResponse, error) {
+			return &v1.ListSimpleAccessScopesResponse{AccessScopes: s}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.RoleService
+		mg     resource.Managed
+		want   want
+	}{
+		"NotAccessScope": {
+			reason: "An error should be returned if the managed resource is not an AccessScope.",
+			svc:    &fake.RoleService{},
+			want:   want{err: errors.New(errNotAccessScope)},
+		},
+		"ListFailed": {
+			reason: "Errors listing access scopes should be returned.",
+			svc: &fake.RoleService{
+				MockListSimpleAccessScopes: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.ListSimpleAccessScopesResponse, error) {
+					return nil, errBoom
+				},
+			},
+			mg:   accessScope(),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "An access scope that does not exist in Central should be reported as not existing.",
+			svc:    withAccessScopes(&storage.SimpleAccessScope{Id: "other", Name: "other"}),
+			mg:     accessScope(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "An access scope matching the spec should be reported as up to date.",
+			svc:    withAccessScopes(generateAccessScope(&accessScope().Spec.ForProvider, &storage.SimpleAccessScope{Id: "id"})),
+			mg:     accessScope(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		id  string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors creating the access scope should be returned.",
+			err:    errBoom,
+			want:   want{err: errors.Wrap(errBoom, errCreateFailed)},
+		},
+		"Success": {
+			reason: "The ID of the created access scope should be recorded in the status.",
+			want:   want{id: "id"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := accessScope()
+			e := external{svc: &fake.RoleService{
+				MockPostSimpleAccessScope: func(_ context.Context, in *storage.SimpleAccessScope, _ ...grpc.CallOption) (*storage.SimpleAccessScope, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					out := *in
+					out.Id = "id"
+					return &out, nil
+				},
+			}}
+
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, cr.Status.AtProvider.ID); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		id       string
+		clusters []string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed []*storage.SimpleAccessScope
+		err      error
+		want     want
+	}{
+		"NotFound": {
+			reason: "An access scope that no longer exists should not be updated.",
+			want:   want{},
+		},
+		"UpdateFailed": {
+			reason:   "Errors updating the access scope should be returned.",
+			observed: []*storage.SimpleAccessScope{{Id: "id", Name: "prod"}},
+			err:      errBoom,
+			want:     want{id: "id", clusters: []string{"prod-eu"}, err: errors.Wrap(errBoom, errUpdateFailed)},
+		},
+		"Success": {
+			reason:   "The observed access scope should be updated by ID to the spec.",
+			observed: []*storage.SimpleAccessScope{{Id: "id", Name: "prod"}},
+			want:     want{id: "id", clusters: []string{"prod-eu"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated *storage.SimpleAccessScope
+			svc := withAccessScopes(tc.observed...)
+			svc.MockPutSimpleAccessScope = func(_ context.Context, in *storage.SimpleAccessScope, _ ...grpc.CallOption) (*v1.Empty, error) {
+				updated = in
+				return &v1.Empty{}, tc.err
+			}
+			e := external{svc: svc}
+
+			_, err := e.Update(context.Background(), accessScope())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, updated.GetId()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.clusters, updated.GetRules().GetIncludedClusters(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want clusters, +got clusters:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		deleted string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"DeleteFailed": {
+			reason: "Errors deleting the access scope should be returned.",
+			err:    errBoom,
+			want:   want{deleted: "id", err: errors.Wrap(errBoom, errDeleteFailed)},
+		},
+		"Success": {
+			reason: "The access scope should be deleted by the ID in its status.",
+			want:   want{deleted: "id"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted string
+			cr := accessScope()
+			cr.Status.AtProvider.ID = "id"
+			e := external{svc: &fake.RoleService{
+				MockDeleteSimpleAccessScope: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+					deleted = in.GetId()
+					return &v1.Empty{}, tc.err
+				},
+			}}
+
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want deleted access scope, +got deleted access scope:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apitoken
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/apitoken/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotAPIToken   = "managed resource is not an APIToken custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+	errGetCreds      = "cannot get credentials"
+	errGetFailed     = "cannot get API token"
+	errObserveFailed = "cannot observe API token"
+	errCreateFailed  = "cannot create API token"
+	errUpdateFailed  = "cannot update API token"
+	errDeleteFailed  = "cannot delete API token"
+)
+
+// Connection detail keys of an APIToken.
+const (
+	keyToken = "token"
+)
+
+// Setup adds a controller that reconciles APIToken managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.APITokenGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.APITokenGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.APIToken{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.APIToken)
+	if !ok {
+		return nil, errors.New(errNotAPIToken)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, ktypes.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{svc: v1.NewAPITokenServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc v1.APITokenServiceClient
+}
+
+func generateObservation(in *storage.TokenMetadata) v1alpha1.APITokenObservation {
+	return v1alpha1.APITokenObservation{
+		ID:         in.GetId(),
+		Name:       in.GetName(),
+		Roles:      in.GetRoles(),
+		IssuedAt:   central.ToTime(in.GetIssuedAt()),
+		Expiration: central.ToTime(in.GetExpiration()),
+		Revoked:    in.GetRevoked(),
+	}
+}
+
+func isUpToDate(in *v1alpha1.APIToken, observed *storage.TokenMetadata) (bool, string) {
+	observedParams := v1alpha1.APITokenParameters{
+		Name:  observed.GetName(),
+		Roles: observed.GetRoles(),
+	}
+	sortRoles := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty(), sortRoles); diff != "" {
+		diff = "Observed difference in API token\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+// getAPIToken returns the token whose ID is the external name. The token value
+// is only returned by Central when the token is generated, so tokens are never
+// looked up by name.
+func (c *external) getAPIToken(ctx context.Context, cr *v1alpha1.APIToken) (*storage.TokenMetadata, error) {
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return nil, nil
+	}
+	token, err := c.svc.GetAPIToken(ctx, &v1.ResourceByID{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	return token, errors.Wrap(err, errGetFailed)
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.APIToken)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAPIToken)
+	}
+
+	token, err := c.getAPIToken(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if token == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(token)
+	// A revoked token cannot be used anymore. It is reported as missing so
+	// that a new token is generated, or the deletion completes.
+	if token.GetRevoked() {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, token.GetId())
+	upToDate, diff := isUpToDate(cr, token)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.APIToken)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAPIToken)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	req := v1.GenerateTokenRequest{Name: cr.Spec.ForProvider.Name, Roles: cr.Spec.ForProvider.Roles}
+	resp, err := c.svc.GenerateToken(ctx, &req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	if m := resp.GetMetadata(); m != nil {
+		cr.Status.AtProvider = generateObservation(m)
+		meta.SetExternalName(cr, m.GetId())
+	}
+	return managed.ExternalCreation{
+		ConnectionDetails: managed.ConnectionDetails{
+			keyToken: []byte(resp.GetToken()),
+		},
+	}, nil
+}
+
+// Update revokes the token, since tokens cannot be modified. A new token is
+// generated once the revocation has been observed.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.APIToken); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAPIToken)
+	}
+
+	err := c.Delete(ctx, mg)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.APIToken)
+	if !ok {
+		return errors.New(errNotAPIToken)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.RevokeToken(ctx, &v1.ResourceByID{Id: meta.GetExternalName(cr)})
+	return errors.Wrap(err, errDeleteFailed)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apitoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/apitoken/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	spec := v1alpha1.APITokenParameters{Name: "ci", Roles: []string{"Continuous Integration", "Analyst"}}
+
+	cases := map[string]struct {
+		reason   string
+		observed *storage.TokenMetadata
+		want     bool
+	}{
+		"RolesReordered": {
+			reason:   "The order of roles should not cause drift.",
+			observed: &storage.TokenMetadata{Id: "t-1", Name: "ci", Roles: []string{"Analyst", "Continuous Integration"}},
+			want:     true,
+		},
+		"RoleRemoved": {
+			reason:   "A missing role should cause drift.",
+			observed: &storage.TokenMetadata{Id: "t-1", Name: "ci", Roles: []string{"Analyst"}},
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.APIToken{Spec: v1alpha1.APITokenSpec{ForProvider: spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+type fakeAPITokenService struct {
+	v1.APITokenServiceClient
+
+	MockGetAPIToken   func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*storage.TokenMetadata, error)
+	MockGenerateToken func(ctx context.Context, in *v1.GenerateTokenRequest, opts ...grpc.CallOption) (*v1.GenerateTokenResponse, error)
+	MockRevokeToken   func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeAPITokenService) GetAPIToken(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*storage.TokenMetadata, error) {
+	return f.MockGetAPIToken(ctx, in, opts...)
+}
+
+func (f *fakeAPITokenService) GenerateToken(ctx context.Context, in *v1.GenerateTokenRequest, opts ...grpc.CallOption) (*v1.GenerateTokenResponse, error) {
+	return f.MockGenerateToken(ctx, in, opts...)
+}
+
+func (f *fakeAPITokenService) RevokeToken(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockRevokeToken(ctx, in, opts...)
+}
+
+func apiToken(externalName string) *v1alpha1.APIToken {
+	cr := &v1alpha1.APIToken{Spec: v1alpha1.APITokenSpec{ForProvider: v1alpha1.APITokenParameters{
+		Name:  "ci",
+		Roles: []string{"Continuous Integration"},
+	}}}
+	if externalName != "" {
+		meta.SetExternalName(cr, externalName)
+	}
+	return cr
+}
+
+// withToken returns a fake APITokenService that serves the supplied tokens by
+// ID.
+func withToken(tokens ...*storage.TokenMetadata) *fakeAPITokenService {
+	return &fakeAPITokenService{
+		MockGetAPIToken: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*storage.TokenMetadata, error) {
+			for _, t := range tokens {
+				if t.GetId() == in.GetId() {
+					return t, nil
+				}
+			}
+			return nil, status.Error(codes.NotFound, "token not found")
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	ci := &storage.TokenMetadata{Id: "t-1", Name: "ci", Roles: []string{"Continuous Integration"}}
+	revoked := &storage.TokenMetadata{Id: "t-2", Name: "ci", Roles: []string{"Continuous Integration"}, Revoked: true}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeAPITokenService
+		cr     *v1alpha1.APIToken
+		want   want
+	}{
+		"NoExternalName": {
+			reason: "A token without external name has not been generated yet.",
+			svc:    &fakeAPITokenService{},
+			cr:     apiToken(""),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"GetFailed": {
+			reason: "Errors getting the token should be returned.",
+			svc: &fakeAPITokenService{
+				MockGetAPIToken: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*storage.TokenMetadata, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   apiToken("t-1"),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A token that does not exist in Central should be reported as not existing.",
+			svc:    withToken(ci),
+			cr:     apiToken("t-3"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"Revoked": {
+			reason: "A revoked token should be reported as not existing, so that a new token is generated.",
+			svc:    withToken(ci, revoked),
+			cr:     apiToken("t-2"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A token matching the spec should be reported as up to date.",
+			svc:    withToken(ci, revoked),
+			cr:     apiToken("t-1"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cr := apiToken("")
+	e := external{svc: &fakeAPITokenService{
+		MockGenerateToken: func(_ context.Context, in *v1.GenerateTokenRequest, _ ...grpc.CallOption) (*v1.GenerateTokenResponse, error) {
+			return &v1.GenerateTokenResponse{
+				Token:    "secret",
+				Metadata: &storage.TokenMetadata{Id: "t-1", Name: in.GetName(), Roles: in.GetRoles()},
+			}, nil
+		},
+	}}
+
+	got, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	want := managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{keyToken: []byte("secret")}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+	if id := meta.GetExternalName(cr); id != "t-1" {
+		t.Errorf("e.Create(...): want external name %q, got %q", "t-1", id)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var revoked string
+	e := external{svc: &fakeAPITokenService{
+		MockRevokeToken: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+			revoked = in.GetId()
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if _, err := e.Update(context.Background(), apiToken("t-1")); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if revoked != "t-1" {
+		t.Errorf("e.Update(...): want token %q revoked, got %q", "t-1", revoked)
+	}
+}
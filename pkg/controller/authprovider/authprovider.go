@@ -0,0 +1,493 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/authprovider/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotAuthProvider = "managed resource is not an AuthProvider custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetSecret       = "cannot get auth provider secret"
+	errNoConfig        = "exactly one auth provider config must be set"
+	errSAMLConfig      = "exactly one of saml.idpMetadataURL and saml.static must be set"
+	errGetFailed       = "cannot get auth provider"
+	errObserveFailed   = "cannot observe auth provider"
+	errCreateFailed    = "cannot create auth provider"
+	errUpdateFailed    = "cannot update auth provider"
+	errDeleteFailed    = "cannot delete auth provider"
+)
+
+// Auth provider types as registered in Central.
+const (
+	typeOIDC      = "oidc"
+	typeSAML      = "saml"
+	typeOpenShift = "openshift"
+)
+
+// Config keys of the auth provider types.
+const (
+	keyIssuer                    = "issuer"
+	keyClientID                  = "client_id"
+	keyClientSecret              = "client_secret"
+	keyDoNotUseClientSecret      = "do_not_use_client_secret"
+	keyMode                      = "mode"
+	keyDisableOfflineAccessScope = "disable_offline_access_scope"
+
+	keySPIssuer        = "sp_issuer"
+	keyIDPMetadataURL  = "idp_metadata_url"
+	keyIDPIssuer       = "idp_issuer"
+	keyIDPSSOURL       = "idp_sso_url"
+	keyIDPCertPEM      = "idp_cert_pem"
+	keyIDPNameIDFormat = "idp_nameid_format"
+)
+
+// Setup adds a controller that reconciles AuthProvider managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.AuthProviderGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.AuthProviderGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.AuthProvider{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.AuthProvider)
+	if !ok {
+		return nil, errors.New(errNotAuthProvider)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{kube: c.kube, svc: v1.NewAuthProviderServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube client.Client
+	svc  v1.AuthProviderServiceClient
+}
+
+// setSecrets resolves the secret references of the spec and writes their
+// values into the auth provider config.
+func (c *external) setSecrets(ctx context.Context, in *v1alpha1.AuthProviderParameters, p *storage.AuthProvider) error {
+	if in.OIDC == nil || in.OIDC.ClientSecretSecretRef == nil {
+		return nil
+	}
+	v, err := resource.ExtractSecret(ctx, c.kube, xpv1.CommonCredentialSelectors{SecretRef: in.OIDC.ClientSecretSecretRef})
+	if err != nil {
+		return errors.Wrap(err, errGetSecret)
+	}
+	p.Config[keyClientSecret] = string(v)
+	return nil
+}
+
+// secretHash returns a hash of the client secret of an auth provider config,
+// or an empty string if it has none.
+func secretHash(p *storage.AuthProvider) string {
+	v := p.GetConfig()[keyClientSecret]
+	if v == "" {
+		return ""
+	}
+	h := sha256.Sum256([]byte(v))
+	return hex.EncodeToString(h[:])
+}
+
+// lastSecretHash returns the hash of the client secret last written to
+// Central. It falls back to the annotation recorded on creation.
+func lastSecretHash(cr *v1alpha1.AuthProvider) string {
+	if h := cr.Status.AtProvider.SecretHash; h != "" {
+		return h
+	}
+	return cr.GetAnnotations()[v1alpha1.AnnotationKeySecretHash]
+}
+
+func providerType(in *v1alpha1.AuthProviderParameters) string {
+	configs := map[string]bool{
+		typeOIDC:      in.OIDC != nil,
+		typeSAML:      in.SAML != nil,
+		typeOpenShift: in.OpenShift != nil,
+	}
+	t := ""
+	for k, set := range configs {
+		if !set {
+			continue
+		}
+		if t != "" {
+			return ""
+		}
+		t = k
+	}
+	return t
+}
+
+// validate rejects parameters Central cannot represent. A SAML provider is
+// configured either from the metadata of the identity provider or statically.
+func validate(in *v1alpha1.AuthProviderParameters) error {
+	if providerType(in) == "" {
+		return errors.New(errNoConfig)
+	}
+	if in.SAML != nil && (in.SAML.IDPMetadataURL != "") == (in.SAML.Static != nil) {
+		return errors.New(errSAMLConfig)
+	}
+	return nil
+}
+
+func generateObservation(in *storage.AuthProvider) v1alpha1.AuthProviderObservation {
+	return v1alpha1.AuthProviderObservation{
+		ID:        in.GetId(),
+		Type:      in.GetType(),
+		LoginURL:  in.GetLoginUrl(),
+		Validated: in.GetValidated(), //nolint:staticcheck // still reported by Central
+		Active:    in.GetActive(),
+	}
+}
+
+func generateOIDCConfig(in *v1alpha1.OIDCConfig) map[string]string {
+	mode := in.CallbackMode
+	if mode == "" {
+		mode = v1alpha1.CallbackModeAuto
+	}
+	out := map[string]string{
+		keyIssuer:               in.Issuer,
+		keyClientID:             in.ClientID,
+		keyMode:                 string(mode),
+		keyDoNotUseClientSecret: strconv.FormatBool(in.ClientSecretSecretRef == nil),
+	}
+	if in.DisableOfflineAccessScope {
+		out[keyDisableOfflineAccessScope] = "true"
+	}
+	return out
+}
+
+func generateSAMLConfig(in *v1alpha1.SAMLConfig) map[string]string {
+	out := map[string]string{keySPIssuer: in.SPIssuer}
+	if in.IDPMetadataURL != "" {
+		out[keyIDPMetadataURL] = in.IDPMetadataURL
+	}
+	if in.Static != nil {
+		out[keyIDPIssuer] = in.Static.IDPIssuer
+		out[keyIDPSSOURL] = in.Static.IDPSSOURL
+		out[keyIDPCertPEM] = in.Static.IDPCertPEM
+		out[keyIDPNameIDFormat] = in.Static.IDPNameIDFormat
+	}
+	return out
+}
+
+func generateAuthProvider(in *v1alpha1.AuthProviderParameters, base *storage.AuthProvider) *storage.AuthProvider {
+	if base == nil {
+		base = &storage.AuthProvider{}
+	}
+	base.Name = in.Name
+	base.Type = providerType(in)
+	base.UiEndpoint = in.UIEndpoint
+	base.ExtraUiEndpoints = in.ExtraUIEndpoints
+	base.Enabled = in.Enabled
+	base.ClaimMappings = in.ClaimMappings
+	base.RequiredAttributes = nil
+	for _, it := range in.RequiredAttributes {
+		base.RequiredAttributes = append(base.RequiredAttributes, &storage.AuthProvider_RequiredAttribute{
+			AttributeKey:   it.Key,
+			AttributeValue: it.Value,
+		})
+	}
+
+	switch {
+	case in.OIDC != nil:
+		base.Config = generateOIDCConfig(in.OIDC)
+	case in.SAML != nil:
+		base.Config = generateSAMLConfig(in.SAML)
+	default:
+		base.Config = map[string]string{}
+	}
+	return base
+}
+
+// generateOIDCParameters converts an observed OIDC config into parameters.
+// Central stores the effective config: the client secret is redacted, the
+// issuer is canonicalized and an automatic callback mode is resolved. These
+// values are reconciled against the supplied spec instead.
+func generateOIDCParameters(in map[string]string, spec *v1alpha1.OIDCConfig) *v1alpha1.OIDCConfig {
+	out := &v1alpha1.OIDCConfig{
+		Issuer:                    in[keyIssuer],
+		ClientID:                  in[keyClientID],
+		CallbackMode:              v1alpha1.CallbackMode(in[keyMode]),
+		DisableOfflineAccessScope: in[keyDisableOfflineAccessScope] == "true",
+	}
+	if spec == nil {
+		return out
+	}
+	out.ClientSecretSecretRef = spec.ClientSecretSecretRef
+	if !strings.Contains(spec.Issuer, "://") && out.Issuer == "https://"+spec.Issuer {
+		out.Issuer = spec.Issuer
+	}
+	if spec.CallbackMode == "" || spec.CallbackMode == v1alpha1.CallbackModeAuto {
+		out.CallbackMode = spec.CallbackMode
+	}
+	return out
+}
+
+func generateSAMLParameters(in map[string]string) *v1alpha1.SAMLConfig {
+	out := &v1alpha1.SAMLConfig{
+		SPIssuer:       in[keySPIssuer],
+		IDPMetadataURL: in[keyIDPMetadataURL],
+	}
+	if in[keyIDPIssuer] != "" {
+		out.Static = &v1alpha1.SAMLStaticConfig{
+			IDPIssuer:       in[keyIDPIssuer],
+			IDPSSOURL:       in[keyIDPSSOURL],
+			IDPCertPEM:      in[keyIDPCertPEM],
+			IDPNameIDFormat: in[keyIDPNameIDFormat],
+		}
+	}
+	return out
+}
+
+func generateAuthProviderParameters(in *storage.AuthProvider, spec *v1alpha1.AuthProviderParameters) v1alpha1.AuthProviderParameters {
+	out := v1alpha1.AuthProviderParameters{
+		Name:             in.GetName(),
+		UIEndpoint:       in.GetUiEndpoint(),
+		ExtraUIEndpoints: in.GetExtraUiEndpoints(),
+		Enabled:          in.GetEnabled(),
+		ClaimMappings:    in.GetClaimMappings(),
+	}
+	for _, it := range in.GetRequiredAttributes() {
+		out.RequiredAttributes = append(out.RequiredAttributes, v1alpha1.RequiredAttribute{
+			Key:   it.GetAttributeKey(),
+			Value: it.GetAttributeValue(),
+		})
+	}
+
+	switch in.GetType() {
+	case typeOIDC:
+		out.OIDC = generateOIDCParameters(in.GetConfig(), spec.OIDC)
+	case typeSAML:
+		out.SAML = generateSAMLParameters(in.GetConfig())
+	case typeOpenShift:
+		out.OpenShift = &v1alpha1.OpenShiftConfig{}
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.AuthProvider, observed *storage.AuthProvider) (bool, string) {
+	observedParams := generateAuthProviderParameters(observed, &in.Spec.ForProvider)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in auth provider\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getAuthProvider(ctx context.Context, cr *v1alpha1.AuthProvider) (*storage.AuthProvider, error) {
+	resp, err := c.svc.GetAuthProviders(ctx, &v1.GetAuthProvidersRequest{Name: cr.Spec.ForProvider.Name})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetAuthProviders() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			return it, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AuthProvider)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAuthProvider)
+	}
+
+	provider, err := c.getAuthProvider(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if provider == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	last := lastSecretHash(cr)
+	cr.Status.AtProvider = generateObservation(provider)
+	cr.Status.AtProvider.SecretHash = last
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, provider.GetName())
+	upToDate, diff := isUpToDate(cr, provider)
+
+	// Central masks the client secret in its responses, so it is compared
+	// against the hash of the value last written to it.
+	desired := generateAuthProvider(&cr.Spec.ForProvider, nil)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, desired); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if upToDate && secretHash(desired) != last {
+		upToDate, diff = false, "Observed change of auth provider client secret"
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AuthProvider)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAuthProvider)
+	}
+	if err := validate(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	req := generateAuthProvider(&cr.Spec.ForProvider, nil)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, req); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	resp, err := c.svc.PostAuthProvider(ctx, &v1.PostAuthProviderRequest{Provider: req})
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	cr.Status.AtProvider = generateObservation(resp)
+	cr.Status.AtProvider.SecretHash = secretHash(req)
+	meta.SetExternalName(cr, resp.GetName())
+	if h := cr.Status.AtProvider.SecretHash; h != "" {
+		meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeySecretHash: h})
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AuthProvider)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAuthProvider)
+	}
+	if err := validate(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	provider, err := c.getAuthProvider(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if provider == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	req := generateAuthProvider(&cr.Spec.ForProvider, provider)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, req); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+
+	if _, err := c.svc.PutAuthProvider(ctx, req); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	cr.Status.AtProvider.SecretHash = secretHash(req)
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AuthProvider)
+	if !ok {
+		return errors.New(errNotAuthProvider)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeleteAuthProvider(ctx, &v1.DeleteByIDWithForce{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
@@ -0,0 +1,459 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/authprovider/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	oidc := v1alpha1.AuthProviderParameters{
+		Name:       "sso",
+		UIEndpoint: "central.example.com",
+		Enabled:    true,
+		OIDC: &v1alpha1.OIDCConfig{
+			Issuer:                "sso.example.com",
+			ClientID:              "central",
+			ClientSecretSecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "sso", Namespace: "crossplane-system"}, Key: "secret"},
+			CallbackMode:          v1alpha1.CallbackModeAuto,
+		},
+		RequiredAttributes: []v1alpha1.RequiredAttribute{{Key: "groups", Value: "stackrox"}},
+		ClaimMappings:      map[string]string{"realm_access.roles": "roles"},
+	}
+	saml := v1alpha1.AuthProviderParameters{
+		Name:       "saml",
+		UIEndpoint: "central.example.com",
+		Enabled:    true,
+		SAML:       &v1alpha1.SAMLConfig{SPIssuer: "https://central.example.com", IDPMetadataURL: "https://idp.example.com/metadata"},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		spec     v1alpha1.AuthProviderParameters
+		observed *storage.AuthProvider
+		want     bool
+	}{
+		"EffectiveOIDCConfig": {
+			reason: "A redacted secret, canonical issuer and resolved callback mode should not cause drift.",
+			spec:   oidc,
+			observed: &storage.AuthProvider{Name: "sso", Type: "oidc", UiEndpoint: "central.example.com", Enabled: true,
+				Config:             map[string]string{"issuer": "https://sso.example.com", "client_id": "central", "client_secret": "*****", "mode": "post"},
+				RequiredAttributes: []*storage.AuthProvider_RequiredAttribute{{AttributeKey: "groups", AttributeValue: "stackrox"}},
+				ClaimMappings:      map[string]string{"realm_access.roles": "roles"}},
+			want: true,
+		},
+		"ClientIDChanged": {
+			reason: "A different client ID should cause drift.",
+			spec:   oidc,
+			observed: &storage.AuthProvider{Name: "sso", Type: "oidc", UiEndpoint: "central.example.com", Enabled: true,
+				Config:             map[string]string{"issuer": "https://sso.example.com", "client_id": "other", "mode": "post"},
+				RequiredAttributes: []*storage.AuthProvider_RequiredAttribute{{AttributeKey: "groups", AttributeValue: "stackrox"}},
+				ClaimMappings:      map[string]string{"realm_access.roles": "roles"}},
+			want: false,
+		},
+		"SAMLMetadata": {
+			reason:   "A SAML provider generated from the spec should be up to date.",
+			spec:     saml,
+			observed: generateAuthProvider(&saml, nil),
+			want:     true,
+		},
+		"Disabled": {
+			reason: "A disabled provider should cause drift.",
+			spec:   saml,
+			observed: &storage.AuthProvider{Name: "saml", Type: "saml", UiEndpoint: "central.example.com",
+				Config: map[string]string{"sp_issuer": "https://central.example.com", "idp_metadata_url": "https://idp.example.com/metadata"}},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.AuthProvider{Spec: v1alpha1.AuthProviderSpec{ForProvider: tc.spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+func TestProviderType(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.AuthProviderParameters
+		want   string
+	}{
+		"None": {
+			reason: "No config should yield no type.",
+			want:   "",
+		},
+		"OpenShift": {
+			reason: "An OpenShift config should yield the openshift type.",
+			spec:   v1alpha1.AuthProviderParameters{OpenShift: &v1alpha1.OpenShiftConfig{}},
+			want:   "openshift",
+		},
+		"Multiple": {
+			reason: "Multiple configs should yield no type.",
+			spec:   v1alpha1.AuthProviderParameters{OIDC: &v1alpha1.OIDCConfig{}, SAML: &v1alpha1.SAMLConfig{}},
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := providerType(&tc.spec)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nproviderType(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type fakeAuthProviderService struct {
+	v1.AuthProviderServiceClient
+
+	MockGetAuthProviders   func(ctx context.Context, in *v1.GetAuthProvidersRequest, opts ...grpc.CallOption) (*v1.GetAuthProvidersResponse, error)
+	MockPostAuthProvider   func(ctx context.Context, in *v1.PostAuthProviderRequest, opts ...grpc.CallOption) (*storage.AuthProvider, error)
+	MockPutAuthProvider    func(ctx context.Context, in *storage.AuthProvider, opts ...grpc.CallOption) (*storage.AuthProvider, error)
+	MockDeleteAuthProvider func(ctx context.Context, in *v1.DeleteByIDWithForce, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeAuthProviderService) GetAuthProviders(ctx context.Context, in *v1.GetAuthProvidersRequest, opts ...grpc.CallOption) (*v1.GetAuthProvidersResponse, error) {
+	return f.MockGetAuthProviders(ctx, in, opts...)
+}
+
+func (f *fakeAuthProviderService) PostAuthProvider(ctx context.Context, in *v1.PostAuthProviderRequest, opts ...grpc.CallOption) (*storage.AuthProvider, error) {
+	return f.MockPostAuthProvider(ctx, in, opts...)
+}
+
+func (f *fakeAuthProviderService) PutAuthProvider(ctx context.Context, in *storage.AuthProvider, opts ...grpc.CallOption) (*storage.AuthProvider, error) {
+	return f.MockPutAuthProvider(ctx, in, opts...)
+}
+
+func (f *fakeAuthProviderService) DeleteAuthProvider(ctx context.Context, in *v1.DeleteByIDWithForce, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteAuthProvider(ctx, in, opts...)
+}
+
+func samlProvider(m ...func(*v1alpha1.SAMLConfig)) *v1alpha1.AuthProvider {
+	cr := &v1alpha1.AuthProvider{Spec: v1alpha1.AuthProviderSpec{ForProvider: v1alpha1.AuthProviderParameters{
+		Name:       "saml",
+		UIEndpoint: "central.example.com",
+		Enabled:    true,
+		SAML:       &v1alpha1.SAMLConfig{SPIssuer: "https://central.example.com", IDPMetadataURL: "https://idp.example.com/metadata"},
+	}}}
+	for _, f := range m {
+		f(cr.Spec.ForProvider.SAML)
+	}
+	return cr
+}
+
+func oidcProvider(m ...func(*v1alpha1.AuthProvider)) *v1alpha1.AuthProvider {
+	cr := &v1alpha1.AuthProvider{Spec: v1alpha1.AuthProviderSpec{ForProvider: v1alpha1.AuthProviderParameters{
+		Name:       "sso",
+		UIEndpoint: "central.example.com",
+		Enabled:    true,
+		OIDC: &v1alpha1.OIDCConfig{
+			Issuer:                "https://sso.example.com",
+			ClientID:              "central",
+			ClientSecretSecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "sso", Namespace: "crossplane-system"}, Key: "secret"},
+		},
+	}}}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// withSecret returns a kube client that serves the supplied value from any
+// Secret under the key "secret".
+func withSecret(v string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			if s, ok := obj.(*corev1.Secret); ok {
+				s.Data = map[string][]byte{"secret": []byte(v)}
+			}
+			return nil
+		}),
+	}
+}
+
+// hashOf returns the secret hash of an auth provider with the supplied
+// client secret.
+func hashOf(secret string) string {
+	return secretHash(&storage.AuthProvider{Config: map[string]string{keyClientSecret: secret}})
+}
+
+func withAuthProviders(p ...*storage.AuthProvider) *fakeAuthProviderService {
+	return &fakeAuthProviderService{
+		MockGetAuthProviders: func(_ context.Context, _ *v1.GetAuthProvidersRequest, _ ...grpc.CallOption) (*v1.GetAuthProvidersResponse, error) {
+			return &v1.GetAuthProvidersResponse{AuthProviders: p}, nil
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	static := &v1alpha1.SAMLStaticConfig{IDPIssuer: "https://idp.example.com", IDPSSOURL: "https://idp.example.com/sso", IDPCertPEM: "cert"}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.AuthProviderParameters
+		want   error
+	}{
+		"NoConfig": {
+			reason: "A provider without config should be rejected.",
+			want:   errors.New(errNoConfig),
+		},
+		"SAMLMetadata": {
+			reason: "A SAML provider configured from metadata should be accepted.",
+			spec:   samlProvider().Spec.ForProvider,
+		},
+		"SAMLStatic": {
+			reason: "A statically configured SAML provider should be accepted.",
+			spec: samlProvider(func(c *v1alpha1.SAMLConfig) {
+				c.IDPMetadataURL = ""
+				c.Static = static
+			}).Spec.ForProvider,
+		},
+		"SAMLBoth": {
+			reason: "A SAML provider configured both from metadata and statically should be rejected.",
+			spec:   samlProvider(func(c *v1alpha1.SAMLConfig) { c.Static = static }).Spec.ForProvider,
+			want:   errors.New(errSAMLConfig),
+		},
+		"SAMLNeither": {
+			reason: "A SAML provider without identity provider config should be rejected.",
+			spec:   samlProvider(func(c *v1alpha1.SAMLConfig) { c.IDPMetadataURL = "" }).Spec.ForProvider,
+			want:   errors.New(errSAMLConfig),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validate(&tc.spec)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nvalidate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	oidc := generateAuthProvider(&oidcProvider().Spec.ForProvider, &storage.AuthProvider{Id: "id"})
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		svc    *fakeAuthProviderService
+		mg     resource.Managed
+		want   want
+	}{
+		"NotAuthProvider": {
+			reason: "An error should be returned if the managed resource is not an AuthProvider.",
+			svc:    &fakeAuthProviderService{},
+			want:   want{err: errors.New(errNotAuthProvider)},
+		},
+		"GetFailed": {
+			reason: "Errors listing auth providers should be returned.",
+			svc: &fakeAuthProviderService{
+				MockGetAuthProviders: func(_ context.Context, _ *v1.GetAuthProvidersRequest, _ ...grpc.CallOption) (*v1.GetAuthProvidersResponse, error) {
+					return nil, errBoom
+				},
+			},
+			mg:   samlProvider(),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "An auth provider that does not exist in Central should be reported as not existing.",
+			svc:    withAuthProviders(),
+			mg:     samlProvider(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "An auth provider matching the spec should be reported as up to date.",
+			svc:    withAuthProviders(generateAuthProvider(&samlProvider().Spec.ForProvider, &storage.AuthProvider{Id: "id"})),
+			mg:     samlProvider(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SecretUnchanged": {
+			reason: "An auth provider whose client secret has the recorded hash should be reported as up to date.",
+			kube:   withSecret("s1"),
+			svc:    withAuthProviders(oidc),
+			mg:     oidcProvider(func(cr *v1alpha1.AuthProvider) { cr.Status.AtProvider.SecretHash = hashOf("s1") }),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SecretUnchangedSinceCreate": {
+			reason: "The hash recorded on creation should be used if the status has none.",
+			kube:   withSecret("s1"),
+			svc:    withAuthProviders(oidc),
+			mg: oidcProvider(func(cr *v1alpha1.AuthProvider) {
+				meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeySecretHash: hashOf("s1")})
+			}),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SecretChanged": {
+			reason: "An auth provider whose client secret changed should be reported as not up to date.",
+			kube:   withSecret("s2"),
+			svc:    withAuthProviders(oidc),
+			mg:     oidcProvider(func(cr *v1alpha1.AuthProvider) { cr.Status.AtProvider.SecretHash = hashOf("s1") }),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "Observed change of auth provider client secret"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{kube: tc.kube, svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		id   string
+		hash string
+		err  error
+	}
+
+	post := &fakeAuthProviderService{
+		MockPostAuthProvider: func(_ context.Context, in *v1.PostAuthProviderRequest, _ ...grpc.CallOption) (*storage.AuthProvider, error) {
+			out := *in.GetProvider()
+			out.Id = "id"
+			return &out, nil
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.AuthProvider
+		want   want
+	}{
+		"SAMLBoth": {
+			reason: "A SAML provider configured both from metadata and statically should not be created.",
+			cr: samlProvider(func(c *v1alpha1.SAMLConfig) {
+				c.Static = &v1alpha1.SAMLStaticConfig{IDPIssuer: "https://idp.example.com"}
+			}),
+			want: want{err: errors.New(errSAMLConfig)},
+		},
+		"Success": {
+			reason: "The ID of the created auth provider should be recorded in the status.",
+			cr:     samlProvider(),
+			want:   want{id: "id"},
+		},
+		"ClientSecret": {
+			reason: "The hash of the client secret of the created auth provider should be recorded as annotation, which is persisted after creation.",
+			cr:     oidcProvider(),
+			want:   want{id: "id", hash: hashOf("s1")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{kube: withSecret("s1"), svc: post}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, tc.cr.Status.AtProvider.ID); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.hash, tc.cr.GetAnnotations()[v1alpha1.AnnotationKeySecretHash]); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want secret hash, +got secret hash:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var updated *storage.AuthProvider
+	svc := withAuthProviders(&storage.AuthProvider{Id: "id", Name: "saml", Type: "saml"})
+	svc.MockPutAuthProvider = func(_ context.Context, in *storage.AuthProvider, _ ...grpc.CallOption) (*storage.AuthProvider, error) {
+		updated = in
+		return in, nil
+	}
+	e := external{svc: svc}
+
+	_, err := e.Update(context.Background(), samlProvider(func(c *v1alpha1.SAMLConfig) { c.IDPMetadataURL = "" }))
+	if diff := cmp.Diff(errors.New(errSAMLConfig), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	if updated != nil {
+		t.Fatalf("e.Update(...): want no update of a rejected auth provider, got %v", updated)
+	}
+
+	if _, err := e.Update(context.Background(), samlProvider()); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if updated.GetId() != "id" || !updated.GetEnabled() {
+		t.Errorf("e.Update(...): want enabled auth provider %q, got %v", "id", updated)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var deleted string
+	cr := samlProvider()
+	cr.Status.AtProvider.ID = "id"
+	e := external{svc: &fakeAuthProviderService{
+		MockDeleteAuthProvider: func(_ context.Context, in *v1.DeleteByIDWithForce, _ ...grpc.CallOption) (*v1.Empty, error) {
+			deleted = in.GetId()
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if deleted != "id" {
+		t.Errorf("e.Delete(...): want auth provider %q deleted, got %q", "id", deleted)
+	}
+}
@@ -0,0 +1,509 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package centralconfig
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/centralconfig/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotCentralConfig    = "managed resource is not a CentralConfig custom resource"
+	errTrackPCUsage        = "cannot track ProviderConfig usage"
+	errGetPC               = "cannot get ProviderConfig"
+	errGetCreds            = "cannot get credentials"
+	errGetFailed           = "cannot get central config"
+	errGetTelemetryFailed  = "cannot get telemetry config"
+	errPutFailed           = "cannot put central config"
+	errPutTelemetryFailed  = "cannot put telemetry config"
+	errPreviousConfig      = "cannot parse previous central config"
+	errObserveFailed       = "cannot observe central config"
+	errCreateFailed        = "cannot create central config"
+	errUpdateFailed        = "cannot update central config"
+	errDeleteFailed        = "cannot delete central config"
+	errRecordPreviousValue = "cannot record previous central config"
+)
+
+// Setup adds a controller that reconciles CentralConfig managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.CentralConfigGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CentralConfigGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		// The configuration is a singleton without a name of its own.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.CentralConfig{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.CentralConfig)
+	if !ok {
+		return nil, errors.New(errNotCentralConfig)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{
+		svc:       v1.NewConfigServiceClient(client),
+		telemetry: v1.NewTelemetryServiceClient(client),
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc       v1.ConfigServiceClient
+	telemetry v1.TelemetryServiceClient
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+// size returns the name of a banner size, with the unset size as empty name.
+func size(in storage.BannerConfig_Size) string {
+	if in == storage.BannerConfig_UNSET {
+		return ""
+	}
+	return in.String()
+}
+
+func generateBannerParameters(in *storage.BannerConfig) *v1alpha1.BannerConfig {
+	return &v1alpha1.BannerConfig{
+		Enabled:         in.GetEnabled(),
+		Text:            in.GetText(),
+		Size:            size(in.GetSize()),
+		Color:           in.GetColor(),
+		BackgroundColor: in.GetBackgroundColor(),
+	}
+}
+
+func generateBanner(in *v1alpha1.BannerConfig) *storage.BannerConfig {
+	return &storage.BannerConfig{
+		Enabled:         in.Enabled,
+		Text:            in.Text,
+		Size:            storage.BannerConfig_Size(storage.BannerConfig_Size_value[in.Size]),
+		Color:           in.Color,
+		BackgroundColor: in.BackgroundColor,
+	}
+}
+
+// generateCentralConfigParameters converts the observed configuration into
+// parameters with every field set. Telemetry is only set if tel is not nil.
+func generateCentralConfigParameters(in *storage.Config, tel *storage.TelemetryConfiguration) v1alpha1.CentralConfigParameters {
+	pub, priv := in.GetPublicConfig(), in.GetPrivateConfig()
+	out := v1alpha1.CentralConfigParameters{
+		PublicConfig: &v1alpha1.PublicConfig{
+			LoginNotice: &v1alpha1.LoginNotice{
+				Enabled: pub.GetLoginNotice().GetEnabled(),
+				Text:    pub.GetLoginNotice().GetText(),
+			},
+			Header: generateBannerParameters(pub.GetHeader()),
+			Footer: generateBannerParameters(pub.GetFooter()),
+		},
+		PrivateConfig: &v1alpha1.PrivateConfig{
+			AlertRetention: &v1alpha1.AlertRetentionConfig{
+				ResolvedDeployRetentionDurationDays:   priv.GetAlertConfig().GetResolvedDeployRetentionDurationDays(),
+				DeletedRuntimeRetentionDurationDays:   priv.GetAlertConfig().GetDeletedRuntimeRetentionDurationDays(),
+				AllRuntimeRetentionDurationDays:       priv.GetAlertConfig().GetAllRuntimeRetentionDurationDays(),
+				AttemptedDeployRetentionDurationDays:  priv.GetAlertConfig().GetAttemptedDeployRetentionDurationDays(),
+				AttemptedRuntimeRetentionDurationDays: priv.GetAlertConfig().GetAttemptedRuntimeRetentionDurationDays(),
+			},
+			ImageRetentionDurationDays:              int32Ptr(priv.GetImageRetentionDurationDays()),
+			ExpiredVulnRequestRetentionDurationDays: int32Ptr(priv.GetExpiredVulnReqRetentionDurationDays()),
+			DecommissionedClusterRetention: &v1alpha1.DecommissionedClusterRetentionConfig{
+				RetentionDurationDays: priv.GetDecommissionedClusterRetention().GetRetentionDurationDays(),
+				IgnoreClusterLabels:   priv.GetDecommissionedClusterRetention().GetIgnoreClusterLabels(),
+			},
+		},
+	}
+	if tel != nil {
+		out.PublicConfig.Telemetry = &v1alpha1.TelemetryConfig{Enabled: tel.GetEnabled()}
+	}
+	return out
+}
+
+// managedFields returns the fields of in that are set in spec.
+func managedFields(in, spec *v1alpha1.CentralConfigParameters) v1alpha1.CentralConfigParameters {
+	out := v1alpha1.CentralConfigParameters{}
+	if s := spec.PublicConfig; s != nil {
+		from := in.PublicConfig
+		if from == nil {
+			from = &v1alpha1.PublicConfig{}
+		}
+		out.PublicConfig = &v1alpha1.PublicConfig{}
+		if s.LoginNotice != nil {
+			out.PublicConfig.LoginNotice = from.LoginNotice
+		}
+		if s.Header != nil {
+			out.PublicConfig.Header = from.Header
+		}
+		if s.Footer != nil {
+			out.PublicConfig.Footer = from.Footer
+		}
+		if s.Telemetry != nil {
+			out.PublicConfig.Telemetry = from.Telemetry
+		}
+	}
+	if s := spec.PrivateConfig; s != nil {
+		from := in.PrivateConfig
+		if from == nil {
+			from = &v1alpha1.PrivateConfig{}
+		}
+		out.PrivateConfig = &v1alpha1.PrivateConfig{}
+		if s.AlertRetention != nil {
+			out.PrivateConfig.AlertRetention = from.AlertRetention
+		}
+		if s.ImageRetentionDurationDays != nil {
+			out.PrivateConfig.ImageRetentionDurationDays = from.ImageRetentionDurationDays
+		}
+		if s.ExpiredVulnRequestRetentionDurationDays != nil {
+			out.PrivateConfig.ExpiredVulnRequestRetentionDurationDays = from.ExpiredVulnRequestRetentionDurationDays
+		}
+		if s.DecommissionedClusterRetention != nil {
+			out.PrivateConfig.DecommissionedClusterRetention = from.DecommissionedClusterRetention
+		}
+	}
+	return out
+}
+
+// recordPrevious adds the observed values of fields that are managed by spec
+// but not recorded in prev yet. It returns whether prev changed.
+func recordPrevious(prev, observed, spec *v1alpha1.CentralConfigParameters) bool {
+	changed := false
+	set := managedFields(observed, spec)
+	if s := set.PublicConfig; s != nil {
+		if prev.PublicConfig == nil {
+			prev.PublicConfig = &v1alpha1.PublicConfig{}
+			changed = true
+		}
+		p := prev.PublicConfig
+		for _, f := range []struct{ dst, src **v1alpha1.BannerConfig }{{&p.Header, &s.Header}, {&p.Footer, &s.Footer}} {
+			if *f.dst == nil && *f.src != nil {
+				*f.dst, changed = *f.src, true
+			}
+		}
+		if p.LoginNotice == nil && s.LoginNotice != nil {
+			p.LoginNotice, changed = s.LoginNotice, true
+		}
+		if p.Telemetry == nil && s.Telemetry != nil {
+			p.Telemetry, changed = s.Telemetry, true
+		}
+	}
+	if s := set.PrivateConfig; s != nil {
+		if prev.PrivateConfig == nil {
+			prev.PrivateConfig = &v1alpha1.PrivateConfig{}
+			changed = true
+		}
+		p := prev.PrivateConfig
+		for _, f := range []struct{ dst, src **int32 }{
+			{&p.ImageRetentionDurationDays, &s.ImageRetentionDurationDays},
+			{&p.ExpiredVulnRequestRetentionDurationDays, &s.ExpiredVulnRequestRetentionDurationDays},
+		} {
+			if *f.dst == nil && *f.src != nil {
+				*f.dst, changed = *f.src, true
+			}
+		}
+		if p.AlertRetention == nil && s.AlertRetention != nil {
+			p.AlertRetention, changed = s.AlertRetention, true
+		}
+		if p.DecommissionedClusterRetention == nil && s.DecommissionedClusterRetention != nil {
+			p.DecommissionedClusterRetention, changed = s.DecommissionedClusterRetention, true
+		}
+	}
+	return changed
+}
+
+// mergeConfig writes the fields that are set in in into the configuration.
+func mergeConfig(cfg *storage.Config, in *v1alpha1.CentralConfigParameters) {
+	if p := in.PublicConfig; p != nil {
+		if cfg.PublicConfig == nil {
+			cfg.PublicConfig = &storage.PublicConfig{}
+		}
+		if p.LoginNotice != nil {
+			cfg.PublicConfig.LoginNotice = &storage.LoginNotice{Enabled: p.LoginNotice.Enabled, Text: p.LoginNotice.Text}
+		}
+		if p.Header != nil {
+			cfg.PublicConfig.Header = generateBanner(p.Header)
+		}
+		if p.Footer != nil {
+			cfg.PublicConfig.Footer = generateBanner(p.Footer)
+		}
+	}
+	if p := in.PrivateConfig; p != nil {
+		if cfg.PrivateConfig == nil {
+			cfg.PrivateConfig = &storage.PrivateConfig{}
+		}
+		if r := p.AlertRetention; r != nil {
+			cfg.PrivateConfig.AlertRetention = &storage.PrivateConfig_AlertConfig{AlertConfig: &storage.AlertRetentionConfig{
+				ResolvedDeployRetentionDurationDays:   r.ResolvedDeployRetentionDurationDays,
+				DeletedRuntimeRetentionDurationDays:   r.DeletedRuntimeRetentionDurationDays,
+				AllRuntimeRetentionDurationDays:       r.AllRuntimeRetentionDurationDays,
+				AttemptedDeployRetentionDurationDays:  r.AttemptedDeployRetentionDurationDays,
+				AttemptedRuntimeRetentionDurationDays: r.AttemptedRuntimeRetentionDurationDays,
+			}}
+		}
+		if p.ImageRetentionDurationDays != nil {
+			cfg.PrivateConfig.ImageRetentionDurationDays = *p.ImageRetentionDurationDays
+		}
+		if p.ExpiredVulnRequestRetentionDurationDays != nil {
+			cfg.PrivateConfig.ExpiredVulnReqRetentionDurationDays = *p.ExpiredVulnRequestRetentionDurationDays
+		}
+		if r := p.DecommissionedClusterRetention; r != nil {
+			if cfg.PrivateConfig.DecommissionedClusterRetention == nil {
+				cfg.PrivateConfig.DecommissionedClusterRetention = &storage.DecommissionedClusterRetentionConfig{}
+			}
+			cfg.PrivateConfig.DecommissionedClusterRetention.RetentionDurationDays = r.RetentionDurationDays
+			cfg.PrivateConfig.DecommissionedClusterRetention.IgnoreClusterLabels = r.IgnoreClusterLabels
+		}
+	}
+}
+
+func isUpToDate(spec, observed *v1alpha1.CentralConfigParameters) (bool, string) {
+	if diff := cmp.Diff(*spec, managedFields(observed, spec), cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in central config\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+// previousConfig returns the recorded previous values and whether any were
+// recorded.
+func previousConfig(cr *v1alpha1.CentralConfig) (v1alpha1.CentralConfigParameters, bool, error) {
+	prev := v1alpha1.CentralConfigParameters{}
+	v, ok := cr.GetAnnotations()[v1alpha1.AnnotationKeyPreviousConfig]
+	if !ok {
+		return prev, false, nil
+	}
+	err := json.Unmarshal([]byte(v), &prev)
+	return prev, true, errors.Wrap(err, errPreviousConfig)
+}
+
+// getConfig returns the current configuration, along with its parameters.
+// Telemetry is only fetched if in manages it.
+func (c *external) getConfig(ctx context.Context, in *v1alpha1.CentralConfigParameters) (*storage.Config, v1alpha1.CentralConfigParameters, error) {
+	cfg, err := c.svc.GetConfig(ctx, &v1.Empty{})
+	if err != nil {
+		return nil, v1alpha1.CentralConfigParameters{}, errors.Wrap(err, errGetFailed)
+	}
+	var tel *storage.TelemetryConfiguration
+	if in.PublicConfig != nil && in.PublicConfig.Telemetry != nil {
+		if tel, err = c.telemetry.GetTelemetryConfiguration(ctx, &v1.Empty{}); err != nil {
+			return nil, v1alpha1.CentralConfigParameters{}, errors.Wrap(err, errGetTelemetryFailed)
+		}
+	}
+	return cfg, generateCentralConfigParameters(cfg, tel), nil
+}
+
+// putConfig writes the fields that are set in in to Central.
+func (c *external) putConfig(ctx context.Context, cfg *storage.Config, in *v1alpha1.CentralConfigParameters) error {
+	mergeConfig(cfg, in)
+	if _, err := c.svc.PutConfig(ctx, &v1.PutConfigRequest{Config: cfg}); err != nil {
+		return errors.Wrap(err, errPutFailed)
+	}
+	if in.PublicConfig != nil && in.PublicConfig.Telemetry != nil {
+		req := &v1.ConfigureTelemetryRequest{Enabled: in.PublicConfig.Telemetry.Enabled}
+		if _, err := c.telemetry.ConfigureTelemetry(ctx, req); err != nil {
+			return errors.Wrap(err, errPutTelemetryFailed)
+		}
+	}
+	return nil
+}
+
+// Observe reports the configuration as not existing until the previous values
+// of all managed fields are recorded, so that Create records them before it
+// changes anything.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CentralConfig)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCentralConfig)
+	}
+
+	_, observed, err := c.getConfig(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	cr.Status.AtProvider = v1alpha1.CentralConfigObservation{
+		PublicConfig:  observed.PublicConfig,
+		PrivateConfig: observed.PrivateConfig,
+	}
+
+	prev, recorded, err := previousConfig(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if !recorded || recordPrevious(&prev, &observed, &cr.Spec.ForProvider) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if meta.WasDeleted(cr) {
+		// Once Delete restored the previous values there is nothing left to
+		// delete.
+		restore := managedFields(&prev, &cr.Spec.ForProvider)
+		if restored, _ := isUpToDate(&restore, &observed); restored {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
+
+	cr.SetConditions(xpv1.Available())
+	upToDate, diff := isUpToDate(&cr.Spec.ForProvider, &observed)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+// Create records the previous values of the managed fields and then applies
+// the spec. The record is kept in an annotation, because the managed
+// reconciler persists annotations, but not the status, set by Create.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CentralConfig)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCentralConfig)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	cfg, observed, err := c.getConfig(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	prev, _, err := previousConfig(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	recordPrevious(&prev, &observed, &cr.Spec.ForProvider)
+	b, err := json.Marshal(prev)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRecordPreviousValue)
+	}
+
+	if err := c.putConfig(ctx, cfg, &cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyPreviousConfig: string(b)})
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CentralConfig)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCentralConfig)
+	}
+
+	cfg, _, err := c.getConfig(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	err = c.putConfig(ctx, cfg, &cr.Spec.ForProvider)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+// Delete restores the recorded previous values of the fields that are still
+// managed. It is not called for the Orphan deletion policy, which leaves the
+// current values in place.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CentralConfig)
+	if !ok {
+		return errors.New(errNotCentralConfig)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	prev, recorded, err := previousConfig(cr)
+	if err != nil || !recorded {
+		return errors.Wrap(err, errDeleteFailed)
+	}
+	restore := managedFields(&prev, &cr.Spec.ForProvider)
+
+	cfg, _, err := c.getConfig(ctx, &restore)
+	if err != nil {
+		return errors.Wrap(err, errDeleteFailed)
+	}
+	return errors.Wrap(c.putConfig(ctx, cfg, &restore), errDeleteFailed)
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package centralconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/centralconfig/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type fakeConfigService struct {
+	v1.ConfigServiceClient
+
+	MockGetConfig func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*storage.Config, error)
+	MockPutConfig func(ctx context.Context, in *v1.PutConfigRequest, opts ...grpc.CallOption) (*storage.Config, error)
+}
+
+func (f *fakeConfigService) GetConfig(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*storage.Config, error) {
+	return f.MockGetConfig(ctx, in, opts...)
+}
+
+func (f *fakeConfigService) PutConfig(ctx context.Context, in *v1.PutConfigRequest, opts ...grpc.CallOption) (*storage.Config, error) {
+	return f.MockPutConfig(ctx, in, opts...)
+}
+
+type fakeTelemetryService struct {
+	v1.TelemetryServiceClient
+
+	MockGetTelemetryConfiguration func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*storage.TelemetryConfiguration, error)
+	MockConfigureTelemetry        func(ctx context.Context, in *v1.ConfigureTelemetryRequest, opts ...grpc.CallOption) (*storage.TelemetryConfiguration, error)
+}
+
+func (f *fakeTelemetryService) GetTelemetryConfiguration(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*storage.TelemetryConfiguration, error) {
+	return f.MockGetTelemetryConfiguration(ctx, in, opts...)
+}
+
+func (f *fakeTelemetryService) ConfigureTelemetry(ctx context.Context, in *v1.ConfigureTelemetryRequest, opts ...grpc.CallOption) (*storage.TelemetryConfiguration, error) {
+	return f.MockConfigureTelemetry(ctx, in, opts...)
+}
+
+// withConfig returns a config service that stores the last put config.
+func withConfig(cfg *storage.Config) *fakeConfigService {
+	return &fakeConfigService{
+		MockGetConfig: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*storage.Config, error) {
+			return cfg, nil
+		},
+		MockPutConfig: func(_ context.Context, in *v1.PutConfigRequest, _ ...grpc.CallOption) (*storage.Config, error) {
+			cfg = in.GetConfig()
+			return cfg, nil
+		},
+	}
+}
+
+// withTelemetry returns a telemetry service that stores the last configured
+// setting.
+func withTelemetry(enabled bool) *fakeTelemetryService {
+	return &fakeTelemetryService{
+		MockGetTelemetryConfiguration: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*storage.TelemetryConfiguration, error) {
+			return &storage.TelemetryConfiguration{Enabled: enabled}, nil
+		},
+		MockConfigureTelemetry: func(_ context.Context, in *v1.ConfigureTelemetryRequest, _ ...grpc.CallOption) (*storage.TelemetryConfiguration, error) {
+			enabled = in.GetEnabled()
+			return &storage.TelemetryConfiguration{Enabled: enabled}, nil
+		},
+	}
+}
+
+func centralConfig(previous string) *v1alpha1.CentralConfig {
+	days := int32(30)
+	cr := &v1alpha1.CentralConfig{Spec: v1alpha1.CentralConfigSpec{ForProvider: v1alpha1.CentralConfigParameters{
+		PublicConfig: &v1alpha1.PublicConfig{
+			LoginNotice: &v1alpha1.LoginNotice{Enabled: true, Text: "Authorized use only"},
+			Telemetry:   &v1alpha1.TelemetryConfig{Enabled: false},
+		},
+		PrivateConfig: &v1alpha1.PrivateConfig{
+			ImageRetentionDurationDays: &days,
+		},
+	}}}
+	if previous != "" {
+		cr.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyPreviousConfig: previous})
+	}
+	return cr
+}
+
+// original is the configuration before the CentralConfig took over.
+func original() *storage.Config {
+	return &storage.Config{
+		PublicConfig: &storage.PublicConfig{
+			Header: &storage.BannerConfig{Enabled: true, Text: "Production", Size: storage.BannerConfig_LARGE},
+		},
+		PrivateConfig: &storage.PrivateConfig{
+			ImageRetentionDurationDays:          7,
+			ExpiredVulnReqRetentionDurationDays: 90,
+		},
+	}
+}
+
+const originalPrevious = `{"publicConfig":{"loginNotice":{"enabled":false},"telemetry":{"enabled":true}},"privateConfig":{"imageRetentionDurationDays":7}}`
+
+func TestIsUpToDate(t *testing.T) {
+	observed := generateCentralConfigParameters(original(), &storage.TelemetryConfiguration{Enabled: true})
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.CentralConfigParameters
+		want   bool
+	}{
+		"UnmanagedFieldsIgnored": {
+			reason: "Fields that are not set in the spec should not cause drift.",
+			spec: v1alpha1.CentralConfigParameters{PublicConfig: &v1alpha1.PublicConfig{
+				Header: &v1alpha1.BannerConfig{Enabled: true, Text: "Production", Size: "LARGE"},
+			}},
+			want: true,
+		},
+		"UnsetSize": {
+			reason: "An unset banner size should match the unset size of Central.",
+			spec: v1alpha1.CentralConfigParameters{PublicConfig: &v1alpha1.PublicConfig{
+				Footer: &v1alpha1.BannerConfig{},
+			}},
+			want: true,
+		},
+		"RetentionChanged": {
+			reason: "A different retention should cause drift.",
+			spec:   centralConfig("").Spec.ForProvider,
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, diff := isUpToDate(&tc.spec, &observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeConfigService
+		cr     *v1alpha1.CentralConfig
+		want   want
+	}{
+		"GetFailed": {
+			reason: "Errors getting the config should be returned.",
+			svc: &fakeConfigService{
+				MockGetConfig: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*storage.Config, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   centralConfig(""),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotRecorded": {
+			reason: "A config without recorded previous values should be reported as not existing.",
+			svc:    withConfig(original()),
+			cr:     centralConfig(""),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NewFieldNotRecorded": {
+			reason: "A config that manages a field without recorded previous value should be reported as not existing.",
+			svc:    withConfig(original()),
+			cr:     centralConfig(`{"publicConfig":{"loginNotice":{"enabled":false}},"privateConfig":{"imageRetentionDurationDays":7}}`),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"Drifted": {
+			reason: "A recorded config that differs from the spec should be reported as not up to date.",
+			svc:    withConfig(original()),
+			cr:     centralConfig(originalPrevious),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"Restored": {
+			reason: "A deleted config whose previous values are restored should be reported as not existing.",
+			svc:    withConfig(original()),
+			cr: func() *v1alpha1.CentralConfig {
+				cr := centralConfig(originalPrevious)
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc, telemetry: withTelemetry(true)}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	svc := withConfig(original())
+	tel := withTelemetry(true)
+	e := external{svc: svc, telemetry: tel}
+	cr := centralConfig("")
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	if diff := cmp.Diff(originalPrevious, cr.GetAnnotations()[v1alpha1.AnnotationKeyPreviousConfig]); diff != "" {
+		t.Errorf("e.Create(...): -want previous config, +got previous config:\n%s\n", diff)
+	}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s\n", diff)
+	}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	restored, _ := svc.GetConfig(context.Background(), &v1.Empty{})
+	want := original()
+	want.PublicConfig.LoginNotice = &storage.LoginNotice{}
+	if diff := cmp.Diff(want, restored); diff != "" {
+		t.Errorf("e.Delete(...): -want restored config, +got restored config:\n%s\n", diff)
+	}
+	if got, _ := tel.GetTelemetryConfiguration(context.Background(), &v1.Empty{}); !got.GetEnabled() {
+		t.Errorf("e.Delete(...): want telemetry enabled again")
+	}
+}
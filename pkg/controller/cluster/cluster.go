@@ -17,14 +17,24 @@ limitations under the License.
 package cluster
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	v1 "github.com/stackrox/rox/generated/api/v1"
 	"github.com/stackrox/rox/generated/storage"
-	"google.golang.org/grpc"
+	"github.com/stackrox/rox/pkg/apiparams"
+	"github.com/stackrox/rox/pkg/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -42,10 +52,12 @@ import (
 	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
 	"github.com/stehessel/provider-stackrox/pkg/clients/central"
 	"github.com/stehessel/provider-stackrox/pkg/features"
+	"github.com/stehessel/provider-stackrox/pkg/management"
 )
 
 const (
 	errNotCluster    = "managed resource is not a Cluster custom resource"
+	errNoName        = "name is required to create a cluster"
 	errTrackPCUsage  = "cannot track ProviderConfig usage"
 	errGetPC         = "cannot get ProviderConfig"
 	errGetCreds      = "cannot get credentials"
@@ -54,6 +66,9 @@ const (
 	errCreateFailed  = "cannot create cluster"
 	errUpdateFailed  = "cannot update cluster"
 	errDeleteFailed  = "cannot delete cluster"
+	errUpgradeFailed = "cannot trigger sensor upgrade"
+	errRotateFailed  = "cannot trigger sensor certificate rotation"
+	errBundleFailed  = "cannot generate sensor bundle"
 )
 
 // Setup adds a controller that reconciles Cluster managed resources.
@@ -65,12 +80,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	policies := o.Features.Enabled(features.EnableAlphaManagementPolicies)
+	conn := managed.NewNopDisconnecter(&connector{
+		kube:               mgr.GetClient(),
+		usage:              resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+		pool:               central.Connections,
+		managementPolicies: policies,
+	})
+	if policies {
+		conn = management.NewConnectDisconnecter(conn)
+	}
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.ClusterGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube:  mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-		}),
+		managed.WithExternalConnectDisconnecter(conn),
+		managed.WithInitializers(),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...))
@@ -85,9 +109,13 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube     client.Client
-	usage    resource.Tracker
-	external *external
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+
+	// managementPolicies is true if the management policies of clusters
+	// are honored.
+	managementPolicies bool
 }
 
 // Connect typically produces an ExternalClient by:
@@ -117,32 +145,303 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 	stringToken := string(token)
 
-	client, err := central.NewGRPC(ctx, pc.Spec.Endpoint, stringToken)
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
 	if err != nil {
 		return nil, errors.Wrap(err, central.ErrNewClient)
 	}
-	c.external = &external{client: client}
-	return c.external, nil
-}
-
-// Disconnect closes the connection of the external client.
-func (c *connector) Disconnect(ctx context.Context) error {
-	err := c.external.close()
-	return errors.Wrap(err, central.ErrCloseClient)
+	bundles, err := central.NewBundleClient(pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{
+		svc:                v1.NewClustersServiceClient(client),
+		upgrades:           v1.NewSensorUpgradeServiceClient(client),
+		bundles:            bundles,
+		managementPolicies: c.managementPolicies,
+	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	client *grpc.ClientConn
+	svc      v1.ClustersServiceClient
+	upgrades v1.SensorUpgradeServiceClient
+	bundles  central.BundleClient
+
+	managementPolicies bool
+}
+
+// updateAllowed returns true if Update may act on the cluster, which is
+// where pending triggers and bundles are acted on.
+func (c *external) updateAllowed(cr *v1alpha1.Cluster) bool {
+	return !c.managementPolicies || cr.GetManagementPolicies().Allows(apisv1alpha1.ManagementActionUpdate)
+}
+
+// pendingUpgrade returns the value of the upgrade annotation if it has not been
+// acted on yet.
+func pendingUpgrade(cr *v1alpha1.Cluster) string {
+	v := cr.GetAnnotations()[v1alpha1.AnnotationKeyUpgrade]
+	if v == cr.Status.AtProvider.LastUpgrade {
+		return ""
+	}
+	return v
+}
+
+// pendingCertRotation returns the value of the rotate-certs annotation if it
+// has not been acted on yet.
+func pendingCertRotation(cr *v1alpha1.Cluster) string {
+	v := cr.GetAnnotations()[v1alpha1.AnnotationKeyRotateCerts]
+	if v == cr.Status.AtProvider.LastCertRotation {
+		return ""
+	}
+	return v
+}
+
+// pendingBundle returns true if a sensor bundle should be published, either
+// because none was published yet or because the generate-bundle annotation
+// changed. Bundles are only generated if there is somewhere to publish them.
+func pendingBundle(cr *v1alpha1.Cluster) bool {
+	if cr.GetWriteConnectionSecretToReference() == nil && cr.GetPublishConnectionDetailsTo() == nil {
+		return false
+	}
+	if cr.Status.AtProvider.BundleGeneratedAt == nil {
+		return true
+	}
+	return cr.GetAnnotations()[v1alpha1.AnnotationKeyGenerateBundle] != cr.Status.AtProvider.LastBundle
 }
 
-func (c *external) close() error {
-	if c != nil && c.client != nil {
-		err := c.client.Close()
-		return errors.Wrap(err, central.ErrCloseClient)
+// bundleConnectionDetails returns the files of a zipped sensor bundle keyed by
+// their path. Slashes are replaced by underscores to form valid secret keys.
+func bundleConnectionDetails(data []byte) (managed.ConnectionDetails, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.Wrap(err, errBundleFailed)
+	}
+	cd := managed.ConnectionDetails{}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		content, err := readZipFile(f)
+		if err != nil {
+			return nil, errors.Wrap(err, errBundleFailed)
+		}
+		cd[strings.ReplaceAll(f.Name, "/", "_")] = content
 	}
-	return nil
+	return cd, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open %s", f.Name)
+	}
+	defer rc.Close() //nolint:errcheck // Nothing to do about a failed close of a read file.
+	content, err := io.ReadAll(rc)
+	return content, errors.Wrapf(err, "cannot read %s", f.Name)
+}
+
+func (c *external) sensorBundle(ctx context.Context, cr *v1alpha1.Cluster, id string) (managed.ConnectionDetails, error) {
+	params := apiparams.ClusterZip{ID: id}
+	if b := cr.Spec.ForProvider.SensorBundle; b != nil {
+		params.CreateUpgraderSA = b.CreateUpgraderSA
+		params.IstioVersion = b.IstioVersion
+		params.DisablePodSecurityPolicies = b.DisablePodSecurityPolicies
+	}
+	data, err := c.bundles.GetSensorBundle(ctx, params)
+	if err != nil {
+		return nil, errors.Wrap(err, errBundleFailed)
+	}
+	return bundleConnectionDetails(data)
+}
+
+func generateUpgradeStatus(in *storage.ClusterUpgradeStatus) *v1alpha1.UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := &v1alpha1.UpgradeStatus{
+		Upgradability:             in.GetUpgradability().String(),
+		UpgradabilityStatusReason: in.GetUpgradabilityStatusReason(),
+	}
+	if p := in.GetMostRecentProcess(); p != nil {
+		out.MostRecentProcess = &v1alpha1.UpgradeProcess{
+			ID:            p.GetId(),
+			Type:          p.GetType().String(),
+			Active:        p.GetActive(),
+			TargetVersion: p.GetTargetVersion(),
+			InitiatedAt:   central.ToTime(p.GetInitiatedAt()),
+			State:         p.GetProgress().GetUpgradeState().String(),
+			Detail:        p.GetProgress().GetUpgradeStatusDetail(),
+			Since:         central.ToTime(p.GetProgress().GetSince()),
+		}
+	}
+	return out
+}
+
+// upgradeCondition returns the condition of the most recent upgrade or
+// certificate rotation, if there is one.
+func upgradeCondition(in *storage.ClusterUpgradeStatus) (xpv1.Condition, bool) {
+	p := in.GetMostRecentProcess()
+	if p == nil {
+		return xpv1.Condition{}, false
+	}
+	msg := p.GetType().String() + " " + p.GetId() + ": " + p.GetProgress().GetUpgradeState().String()
+	if d := p.GetProgress().GetUpgradeStatusDetail(); d != "" {
+		msg += ": " + d
+	}
+	switch s := p.GetProgress().GetUpgradeState(); {
+	case s == storage.UpgradeProgress_UPGRADE_COMPLETE:
+		return apisv1alpha1.UpgradeComplete(msg), true
+	case s >= storage.UpgradeProgress_UPGRADE_INITIALIZATION_ERROR:
+		return apisv1alpha1.UpgradeFailed(msg), true
+	}
+	return apisv1alpha1.UpgradeInProgress(msg), true
+}
+
+func generateHealthStatus(in *storage.ClusterHealthStatus) *v1alpha1.ClusterHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := &v1alpha1.ClusterHealthStatus{
+		OverallHealthStatus:          in.GetOverallHealthStatus().String(),
+		SensorHealthStatus:           in.GetSensorHealthStatus().String(),
+		CollectorHealthStatus:        in.GetCollectorHealthStatus().String(),
+		AdmissionControlHealthStatus: in.GetAdmissionControlHealthStatus().String(),
+		LastContact:                  central.ToTime(in.GetLastContact()),
+	}
+	if c := in.GetCollectorHealthInfo(); c != nil {
+		out.CollectorHealthInfo = &v1alpha1.CollectorHealthInfo{
+			Version:      c.GetVersion(),
+			StatusErrors: c.GetStatusErrors(),
+		}
+		// The pod counts are optional, an unset count is unknown rather than zero.
+		if c.GetTotalDesiredPodsOpt() != nil {
+			v := c.GetTotalDesiredPods()
+			out.CollectorHealthInfo.TotalDesiredPods = &v
+		}
+		if c.GetTotalReadyPodsOpt() != nil {
+			v := c.GetTotalReadyPods()
+			out.CollectorHealthInfo.TotalReadyPods = &v
+		}
+		if c.GetTotalRegisteredNodesOpt() != nil {
+			v := c.GetTotalRegisteredNodes()
+			out.CollectorHealthInfo.TotalRegisteredNodes = &v
+		}
+	}
+	return out
+}
+
+// healthCondition returns the Ready condition of a cluster. A cluster is
+// ready once its sensor is connected and healthy and the remaining secured
+// cluster services are at least degraded.
+func healthCondition(in *storage.ClusterHealthStatus) xpv1.Condition {
+	sensor := in.GetSensorHealthStatus()
+	switch overall := in.GetOverallHealthStatus(); {
+	case overall == storage.ClusterHealthStatus_UNINITIALIZED:
+		return apisv1alpha1.Uninitialized()
+	case sensor != storage.ClusterHealthStatus_HEALTHY:
+		return apisv1alpha1.SensorUnhealthy("sensor is " + sensor.String())
+	case overall < storage.ClusterHealthStatus_DEGRADED:
+		return apisv1alpha1.Unhealthy("collector is " + in.GetCollectorHealthStatus().String() +
+			", admission control is " + in.GetAdmissionControlHealthStatus().String())
+	}
+	return xpv1.Available()
+}
+
+// externallyManaged returns true if the static configuration of the cluster
+// is owned by a Helm chart or the operator. Central refuses to change it, but
+// still accepts changes to the labels and the dynamic configuration.
+func externallyManaged(in *storage.Cluster) bool {
+	switch in.GetManagedBy() {
+	case storage.ManagerType_MANAGER_TYPE_HELM_CHART, storage.ManagerType_MANAGER_TYPE_KUBERNETES_OPERATOR:
+		return true
+	}
+	return false
+}
+
+func managedByCondition(in *storage.Cluster) xpv1.Condition {
+	switch in.GetManagedBy() {
+	case storage.ManagerType_MANAGER_TYPE_HELM_CHART:
+		return apisv1alpha1.ManagedByHelm()
+	case storage.ManagerType_MANAGER_TYPE_KUBERNETES_OPERATOR:
+		return apisv1alpha1.ManagedByOperator()
+	}
+	return apisv1alpha1.ManagedByCentral()
+}
+
+func generateHelmConfig(in *storage.CompleteClusterConfig) *v1alpha1.HelmConfig {
+	if in == nil {
+		return nil
+	}
+	out := &v1alpha1.HelmConfig{
+		ConfigFingerprint: in.GetConfigFingerprint(),
+		ClusterLabels:     in.GetClusterLabels(),
+		DynamicConfig:     generateDynamicConfig(in.GetDynamicConfig()),
+	}
+	if s := in.GetStaticConfig(); s != nil {
+		out.StaticConfig = &v1alpha1.StaticConfig{
+			Type:                       s.GetType().String(),
+			MainImage:                  s.GetMainImage(),
+			CentralAPIEndpoint:         s.GetCentralApiEndpoint(),
+			CollectionMethod:           s.GetCollectionMethod().String(),
+			CollectorImage:             s.GetCollectorImage(),
+			AdmissionController:        s.GetAdmissionController(),
+			AdmissionControllerUpdates: s.GetAdmissionControllerUpdates(),
+			AdmissionControllerEvents:  s.GetAdmissionControllerEvents(),
+			Tolerations:                !s.GetTolerationsConfig().GetDisabled(),
+			SlimCollector:              s.GetSlimCollector(),
+		}
+	}
+	return out
+}
+
+func generateDynamicConfig(in *storage.DynamicClusterConfig) *v1alpha1.DynamicConfig {
+	if in == nil {
+		return nil
+	}
+	a := in.GetAdmissionControllerConfig()
+	return &v1alpha1.DynamicConfig{
+		AdmissionControllerConfig: v1alpha1.AdmissionControllerConfig{
+			Enabled:          a.GetEnabled(),
+			TimeoutSeconds:   a.GetTimeoutSeconds(),
+			ScanInline:       a.GetScanInline(),
+			DisableBypass:    a.GetDisableBypass(),
+			EnforceOnUpdates: a.GetEnforceOnUpdates(),
+		},
+		RegistryOverride: in.GetRegistryOverride(),
+		DisableAuditLogs: in.GetDisableAuditLogs(),
+	}
+}
+
+// keepTriggers copies the state of the annotation triggers, which Central
+// does not know about, from the last observation. Triggers that were consumed
+// on creation are only recorded in annotations.
+func keepTriggers(cr *v1alpha1.Cluster, last v1alpha1.ClusterObservation) {
+	obs := &cr.Status.AtProvider
+	obs.LastUpgrade = last.LastUpgrade
+	if obs.LastUpgrade == "" {
+		obs.LastUpgrade = cr.GetAnnotations()[v1alpha1.AnnotationKeyConsumedUpgrade]
+	}
+	obs.LastCertRotation = last.LastCertRotation
+	if obs.LastCertRotation == "" {
+		obs.LastCertRotation = cr.GetAnnotations()[v1alpha1.AnnotationKeyConsumedRotateCerts]
+	}
+	obs.LastBundle = last.LastBundle
+	obs.BundleGeneratedAt = last.BundleGeneratedAt
+}
+
+// consumeTriggers records the values of the upgrade and rotate-certs
+// annotations of a new cluster, so that they do not fire once its sensor
+// connects.
+func consumeTriggers(cr *v1alpha1.Cluster) {
+	consumed := map[string]string{}
+	if v := cr.GetAnnotations()[v1alpha1.AnnotationKeyUpgrade]; v != "" {
+		consumed[v1alpha1.AnnotationKeyConsumedUpgrade] = v
+	}
+	if v := cr.GetAnnotations()[v1alpha1.AnnotationKeyRotateCerts]; v != "" {
+		consumed[v1alpha1.AnnotationKeyConsumedRotateCerts] = v
+	}
+	meta.AddAnnotations(cr, consumed)
 }
 
 func generateObservation(in *storage.Cluster) v1alpha1.ClusterObservation {
@@ -162,62 +461,254 @@ func generateObservation(in *storage.Cluster) v1alpha1.ClusterObservation {
 		CentralAPIEndpoint:         in.GetCentralApiEndpoint(),
 		CollectionMethod:           storage.CollectionMethod_name[int32(in.GetCollectionMethod())],
 		CollectorImage:             in.GetCollectorImage(),
+		DynamicConfig:              generateDynamicConfig(in.GetDynamicConfig()),
+		HelmConfig:                 generateHelmConfig(in.GetHelmConfig()),
 		ID:                         in.GetId(),
 		Labels:                     in.GetLabels(),
 		MainImage:                  in.GetMainImage(),
 		ManagedBy:                  storage.ManagerType_name[int32(in.GetManagedBy())],
 		MostRecentSensor:           mostRecentSensor,
 		Name:                       in.GetName(),
+		Priority:                   in.GetPriority(),
 		SlimCollector:              in.GetSlimCollector(),
 		Tolerations:                !in.GetTolerationsConfig().GetDisabled(),
 		Type:                       storage.ClusterType_name[int32(in.GetType())],
+		HealthStatus:               generateHealthStatus(in.GetHealthStatus()),
+		UpgradeStatus:              generateUpgradeStatus(in.GetStatus().GetUpgradeStatus()),
 	}
 }
 
-func generateCluster(in *v1alpha1.ClusterParameters, base *storage.Cluster) *storage.Cluster {
+// Defaults of the images of new clusters.
+const (
+	defaultCollectorImage = "registry.redhat.io/advanced-cluster-security/rhacs-collector-rhel8"
+	defaultMainImage      = "registry.redhat.io/advanced-cluster-security/rhacs-main-rhel8"
+)
+
+// newCluster returns a cluster with the defaults of unset parameters.
+func newCluster() *storage.Cluster {
+	return &storage.Cluster{
+		AdmissionController: true,
+		CollectionMethod:    storage.CollectionMethod_EBPF,
+		CollectorImage:      defaultCollectorImage,
+		MainImage:           defaultMainImage,
+		SlimCollector:       true,
+		TolerationsConfig:   &storage.TolerationsConfig{},
+		Type:                storage.ClusterType_GENERIC_CLUSTER,
+	}
+}
+
+func generateCluster(in *v1alpha1.ClusterParameters, base *storage.Cluster) *storage.Cluster { //nolint:gocyclo // one check per optional parameter
 	if base == nil {
-		base = &storage.Cluster{}
-	}
-	base.AdmissionController = in.AdmissionController
-	base.AdmissionControllerEvents = in.AdmissionControllerEvents
-	base.AdmissionControllerUpdates = in.AdmissionControllerUpdates
-	base.CentralApiEndpoint = in.CentralAPIEndpoint
-	base.CollectionMethod = storage.CollectionMethod(storage.CollectionMethod_value[in.CollectionMethod])
-	base.CollectorImage = in.CollectorImage
-	base.Labels = in.Labels
-	base.MainImage = in.MainImage
+		base = newCluster()
+	}
+	if d := in.DynamicConfig; d != nil {
+		base.DynamicConfig = &storage.DynamicClusterConfig{
+			AdmissionControllerConfig: &storage.AdmissionControllerConfig{
+				Enabled:          d.AdmissionControllerConfig.Enabled,
+				TimeoutSeconds:   d.AdmissionControllerConfig.TimeoutSeconds,
+				ScanInline:       d.AdmissionControllerConfig.ScanInline,
+				DisableBypass:    d.AdmissionControllerConfig.DisableBypass,
+				EnforceOnUpdates: d.AdmissionControllerConfig.EnforceOnUpdates,
+			},
+			RegistryOverride: d.RegistryOverride,
+			DisableAuditLogs: d.DisableAuditLogs,
+		}
+	}
+	if in.Labels != nil {
+		base.Labels = in.Labels
+	}
+	// Central refuses changes to the static configuration of Helm and
+	// operator managed clusters.
+	if externallyManaged(base) {
+		return base
+	}
+	if in.AdmissionController != nil {
+		base.AdmissionController = *in.AdmissionController
+	}
+	if in.AdmissionControllerEvents != nil {
+		base.AdmissionControllerEvents = *in.AdmissionControllerEvents
+	}
+	if in.AdmissionControllerUpdates != nil {
+		base.AdmissionControllerUpdates = *in.AdmissionControllerUpdates
+	}
+	if in.CentralAPIEndpoint != "" {
+		base.CentralApiEndpoint = in.CentralAPIEndpoint
+	}
+	if in.CollectionMethod != "" {
+		base.CollectionMethod = storage.CollectionMethod(storage.CollectionMethod_value[in.CollectionMethod])
+	}
+	if in.CollectorImage != "" {
+		base.CollectorImage = in.CollectorImage
+	}
+	if in.MainImage != "" {
+		base.MainImage = in.MainImage
+	}
 	base.Name = in.Name
-	base.SlimCollector = in.SlimCollector
-	base.TolerationsConfig = &storage.TolerationsConfig{Disabled: !in.Tolerations}
-	base.Type = storage.ClusterType(storage.ClusterType_value[in.Type])
+	if in.SlimCollector != nil {
+		base.SlimCollector = *in.SlimCollector
+	}
+	if in.Tolerations != nil {
+		base.TolerationsConfig = &storage.TolerationsConfig{Disabled: !*in.Tolerations}
+	}
+	if in.Type != "" {
+		base.Type = storage.ClusterType(storage.ClusterType_value[in.Type])
+	}
 	return base
 }
 
-func isUpToDate(in *v1alpha1.Cluster, observed *storage.Cluster) (bool, string) {
-	observedParams := v1alpha1.ClusterParameters{
-		AdmissionController:        observed.GetAdmissionController(),
-		AdmissionControllerEvents:  observed.GetAdmissionControllerEvents(),
-		AdmissionControllerUpdates: observed.GetAdmissionControllerUpdates(),
+func generateClusterParameters(observed *storage.Cluster, spec *v1alpha1.ClusterParameters) v1alpha1.ClusterParameters {
+	return v1alpha1.ClusterParameters{
+		AdmissionController:        boolPtr(observed.GetAdmissionController()),
+		AdmissionControllerEvents:  boolPtr(observed.GetAdmissionControllerEvents()),
+		AdmissionControllerUpdates: boolPtr(observed.GetAdmissionControllerUpdates()),
 		CentralAPIEndpoint:         observed.GetCentralApiEndpoint(),
 		CollectionMethod:           storage.CollectionMethod_name[int32(observed.GetCollectionMethod())],
 		CollectorImage:             observed.GetCollectorImage(),
+		DynamicConfig:              generateDynamicConfig(observed.GetDynamicConfig()),
 		Labels:                     observed.GetLabels(),
 		MainImage:                  observed.GetMainImage(),
 		Name:                       observed.GetName(),
-		SlimCollector:              observed.GetSlimCollector(),
-		Tolerations:                !observed.GetTolerationsConfig().GetDisabled(),
-		Type:                       storage.ClusterType_name[int32(observed.GetType())],
+		// The bundle options are not stored by Central.
+		SensorBundle:  spec.SensorBundle,
+		SlimCollector: boolPtr(observed.GetSlimCollector()),
+		Tolerations:   boolPtr(!observed.GetTolerationsConfig().GetDisabled()),
+		Type:          storage.ClusterType_name[int32(observed.GetType())],
+	}
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+// lateInitialize fills the unset parameters from the observed cluster, so
+// that imported clusters keep their configuration.
+func lateInitialize(in *v1alpha1.ClusterParameters, observed *storage.Cluster) bool {
+	o := generateClusterParameters(observed, in)
+	li := false
+	for _, f := range []struct{ in, o **bool }{
+		{&in.AdmissionController, &o.AdmissionController},
+		{&in.AdmissionControllerEvents, &o.AdmissionControllerEvents},
+		{&in.AdmissionControllerUpdates, &o.AdmissionControllerUpdates},
+		{&in.SlimCollector, &o.SlimCollector},
+		{&in.Tolerations, &o.Tolerations},
+	} {
+		if *f.in == nil {
+			*f.in = *f.o
+			li = true
+		}
+	}
+	for _, f := range []struct{ in, o *string }{
+		{&in.CentralAPIEndpoint, &o.CentralAPIEndpoint},
+		{&in.CollectionMethod, &o.CollectionMethod},
+		{&in.CollectorImage, &o.CollectorImage},
+		{&in.MainImage, &o.MainImage},
+		{&in.Name, &o.Name},
+		{&in.Type, &o.Type},
+	} {
+		if *f.in == "" && *f.o != "" {
+			*f.in = *f.o
+			li = true
+		}
 	}
-	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+	if in.DynamicConfig == nil && o.DynamicConfig != nil {
+		in.DynamicConfig = o.DynamicConfig
+		li = true
+	}
+	if in.Labels == nil && len(o.Labels) > 0 {
+		in.Labels = o.Labels
+		li = true
+	}
+	return li
+}
+
+// serverOwnedTag marks parameters that Central owns while they are unset,
+// e.g. because it fills in a default that late initialization cannot record.
+const serverOwnedTag = "serverOwned"
+
+// withObservedServerOwned sets the unset server-owned fields of the struct
+// that in points to, and of the structs nested in it, to their values in
+// observed, which is a struct of the same type.
+func withObservedServerOwned(in, observed reflect.Value) {
+	in, observed = reflect.Indirect(in), reflect.Indirect(observed)
+	if in.Kind() != reflect.Struct || !observed.IsValid() {
+		return
+	}
+	for i := 0; i < in.NumField(); i++ {
+		f := in.Field(i)
+		switch {
+		case in.Type().Field(i).Tag.Get("stackrox") == serverOwnedTag:
+			if f.IsZero() {
+				f.Set(observed.Field(i))
+			}
+		case f.Kind() == reflect.Struct, f.Kind() == reflect.Ptr && !f.IsNil():
+			withObservedServerOwned(f, observed.Field(i))
+		}
+	}
+}
+
+// withServerOwned returns the parameters as Central stores them. Central
+// normalizes some parameters and owns others, e.g. depending on the cluster
+// type, so their configured values would never match the observed ones.
+func withServerOwned(in *v1alpha1.ClusterParameters, observed *storage.Cluster) v1alpha1.ClusterParameters {
+	out := *in.DeepCopy()
+	o := generateClusterParameters(observed, in)
+	withObservedServerOwned(reflect.ValueOf(&out), reflect.ValueOf(o))
+	if externallyManaged(observed) {
+		// The Helm chart or the operator owns the static configuration.
+		out.AdmissionController = o.AdmissionController
+		out.AdmissionControllerEvents = o.AdmissionControllerEvents
+		out.AdmissionControllerUpdates = o.AdmissionControllerUpdates
+		out.CentralAPIEndpoint = o.CentralAPIEndpoint
+		out.CollectionMethod = o.CollectionMethod
+		out.CollectorImage = o.CollectorImage
+		out.MainImage = o.MainImage
+		out.Name = o.Name
+		out.SlimCollector = o.SlimCollector
+		out.Tolerations = o.Tolerations
+		out.Type = o.Type
+	}
+	out.CentralAPIEndpoint = strings.TrimPrefix(out.CentralAPIEndpoint, "https://")
+	out.CentralAPIEndpoint = strings.TrimPrefix(out.CentralAPIEndpoint, "http://")
+	if out.CollectionMethod == storage.CollectionMethod_UNSET_COLLECTION.String() {
+		out.CollectionMethod = storage.CollectionMethod_KERNEL_MODULE.String()
+	}
+	// Central owns the audit logs of all but OpenShift 4 clusters, which a
+	// field tag cannot express.
+	if d := out.DynamicConfig; d != nil && out.Type != storage.ClusterType_OPENSHIFT4_CLUSTER.String() {
+		d.DisableAuditLogs = observed.GetDynamicConfig().GetDisableAuditLogs()
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.Cluster, observed *storage.Cluster) (bool, string) {
+	desired := withServerOwned(&in.Spec.ForProvider, observed)
+	observedParams := generateClusterParameters(observed, &in.Spec.ForProvider)
+	if diff := cmp.Diff(desired, observedParams, cmpopts.EquateEmpty()); diff != "" {
 		diff = "Observed difference in cluster\n" + diff
 		return false, diff
 	}
 	return true, ""
 }
 
+// getCluster returns the cluster with the ID in the external name. Without
+// ID, e.g. before the cluster was created or when the external name still
+// holds the cluster name, it searches the cluster by name.
 func (c *external) getCluster(ctx context.Context, cr *v1alpha1.Cluster) (*storage.Cluster, error) {
-	svc := v1.NewClustersServiceClient(c.client)
-	resp, err := svc.GetClusters(ctx, &v1.GetClustersRequest{})
+	id := meta.GetExternalName(cr)
+	if _, err := uuid.FromString(id); err == nil {
+		resp, err := c.svc.GetCluster(ctx, &v1.ResourceByID{Id: id})
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return resp.GetCluster(), errors.Wrap(err, errGetFailed)
+	}
+
+	if cr.Spec.ForProvider.Name == "" {
+		return nil, nil
+	}
+	resp, err := c.svc.GetClusters(ctx, &v1.GetClustersRequest{
+		Query: fmt.Sprintf("Cluster:%q", cr.Spec.ForProvider.Name),
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, errGetFailed)
 	}
@@ -243,15 +734,38 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
+	last := cr.Status.AtProvider
 	cr.Status.AtProvider = generateObservation(cluster)
-	cr.SetConditions(xpv1.Available())
-	meta.SetExternalName(cr, cluster.GetName())
+	keepTriggers(cr, last)
+	cr.SetConditions(healthCondition(cluster.GetHealthStatus()), managedByCondition(cluster))
+	if cond, ok := upgradeCondition(cluster.GetStatus().GetUpgradeStatus()); ok {
+		cr.SetConditions(cond)
+	}
+	meta.SetExternalName(cr, cluster.GetId())
+	lateInitialized := lateInitialize(&cr.Spec.ForProvider, cluster)
 	upToDate, diff := isUpToDate(cr, cluster)
+	// Pending triggers and bundles are not drift, so they must not fail
+	// clusters whose management policies do not allow to update them.
+	if c.updateAllowed(cr) {
+		if t := pendingUpgrade(cr); t != "" {
+			upToDate = false
+			diff += "Pending sensor upgrade " + t + "\n"
+		}
+		if t := pendingCertRotation(cr); t != "" {
+			upToDate = false
+			diff += "Pending sensor certificate rotation " + t + "\n"
+		}
+		if pendingBundle(cr) && !externallyManaged(cluster) {
+			upToDate = false
+			diff += "Pending sensor bundle\n"
+		}
+	}
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: upToDate,
-		Diff:             diff,
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: lateInitialized,
+		Diff:                    diff,
 	}, nil
 }
 
@@ -260,19 +774,22 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotCluster)
 	}
+	if cr.Spec.ForProvider.Name == "" {
+		return managed.ExternalCreation{}, errors.New(errNoName)
+	}
 	cr.SetConditions(xpv1.Creating())
 
-	svc := v1.NewClustersServiceClient(c.client)
 	req := generateCluster(&cr.Spec.ForProvider, nil)
-	resp, err := svc.PostCluster(ctx, req)
+	resp, err := c.svc.PostCluster(ctx, req)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 	}
 
 	if c := resp.GetCluster(); c != nil {
 		cr.Status.AtProvider = generateObservation(c)
-		meta.SetExternalName(cr, c.GetName())
+		meta.SetExternalName(cr, c.GetId())
 	}
+	consumeTriggers(cr)
 	return managed.ExternalCreation{}, nil
 }
 
@@ -290,18 +807,44 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, nil
 	}
 
-	svc := v1.NewClustersServiceClient(c.client)
-	req := generateCluster(&cr.Spec.ForProvider, cluster)
-	resp, err := svc.PutCluster(ctx, req)
-	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errCreateFailed)
+	// Update is also called for pending triggers, which must not put the
+	// cluster again.
+	if upToDate, _ := isUpToDate(cr, cluster); !upToDate {
+		resp, err := c.svc.PutCluster(ctx, generateCluster(&cr.Spec.ForProvider, cluster))
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+		}
+		if c := resp.GetCluster(); c != nil {
+			last := cr.Status.AtProvider
+			cr.Status.AtProvider = generateObservation(c)
+			keepTriggers(cr, last)
+			meta.SetExternalName(cr, c.GetId())
+		}
 	}
 
-	if c := resp.GetCluster(); c != nil {
-		cr.Status.AtProvider = generateObservation(c)
-		meta.SetExternalName(cr, c.GetName())
+	if t := pendingUpgrade(cr); t != "" {
+		if _, err := c.upgrades.TriggerSensorUpgrade(ctx, &v1.ResourceByID{Id: cluster.GetId()}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpgradeFailed)
+		}
+		cr.Status.AtProvider.LastUpgrade = t
+	}
+	if t := pendingCertRotation(cr); t != "" {
+		if _, err := c.upgrades.TriggerSensorCertRotation(ctx, &v1.ResourceByID{Id: cluster.GetId()}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRotateFailed)
+		}
+		cr.Status.AtProvider.LastCertRotation = t
+	}
+	if !pendingBundle(cr) || externallyManaged(cluster) {
+		return managed.ExternalUpdate{}, nil
+	}
+	cd, err := c.sensorBundle(ctx, cr, cluster.GetId())
+	if err != nil {
+		return managed.ExternalUpdate{}, err
 	}
-	return managed.ExternalUpdate{}, nil
+	now := metav1.Now()
+	cr.Status.AtProvider.LastBundle = cr.GetAnnotations()[v1alpha1.AnnotationKeyGenerateBundle]
+	cr.Status.AtProvider.BundleGeneratedAt = &now
+	return managed.ExternalUpdate{ConnectionDetails: cd}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -311,7 +854,6 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	}
 	mg.SetConditions(xpv1.Deleting())
 
-	svc := v1.NewClustersServiceClient(c.client)
-	_, err := svc.DeleteCluster(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.ID})
+	_, err := c.svc.DeleteCluster(ctx, &v1.ResourceByID{Id: meta.GetExternalName(cr)})
 	return errors.Wrap(err, errDeleteFailed)
 }
@@ -1,31 +1,30 @@
-/*
-Copyright 2022 The Crossplane Authors.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
 package cluster
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"github.com/stackrox/rox/pkg/apiparams"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
-	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/cluster/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,11 +35,164 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+type fakeClustersService struct {
+	v1.ClustersServiceClient
+
+	MockGetCluster  func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.ClusterResponse, error)
+	MockGetClusters func(ctx context.Context, in *v1.GetClustersRequest, opts ...grpc.CallOption) (*v1.ClustersList, error)
+	MockPostCluster func(ctx context.Context, in *storage.Cluster, opts ...grpc.CallOption) (*v1.ClusterResponse, error)
+	MockPutCluster  func(ctx context.Context, in *storage.Cluster, opts ...grpc.CallOption) (*v1.ClusterResponse, error)
+}
+
+func (f *fakeClustersService) PostCluster(ctx context.Context, in *storage.Cluster, opts ...grpc.CallOption) (*v1.ClusterResponse, error) {
+	return f.MockPostCluster(ctx, in, opts...)
+}
+
+func (f *fakeClustersService) GetCluster(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.ClusterResponse, error) {
+	return f.MockGetCluster(ctx, in, opts...)
+}
+
+func (f *fakeClustersService) GetClusters(ctx context.Context, in *v1.GetClustersRequest, opts ...grpc.CallOption) (*v1.ClustersList, error) {
+	return f.MockGetClusters(ctx, in, opts...)
+}
+
+func (f *fakeClustersService) PutCluster(ctx context.Context, in *storage.Cluster, opts ...grpc.CallOption) (*v1.ClusterResponse, error) {
+	return f.MockPutCluster(ctx, in, opts...)
+}
+
+type fakeSensorUpgradeService struct {
+	v1.SensorUpgradeServiceClient
+
+	MockTriggerSensorUpgrade      func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockTriggerSensorCertRotation func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeSensorUpgradeService) TriggerSensorUpgrade(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockTriggerSensorUpgrade(ctx, in, opts...)
+}
+
+func (f *fakeSensorUpgradeService) TriggerSensorCertRotation(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockTriggerSensorCertRotation(ctx, in, opts...)
+}
+
+type fakeBundleClient struct {
+	MockGetSensorBundle func(ctx context.Context, params apiparams.ClusterZip) ([]byte, error)
+}
+
+func (f *fakeBundleClient) GetSensorBundle(ctx context.Context, params apiparams.ClusterZip) ([]byte, error) {
+	return f.MockGetSensorBundle(ctx, params)
+}
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func withClusters(clusters ...*storage.Cluster) *fakeClustersService {
+	return &fakeClustersService{
+		MockGetCluster: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.ClusterResponse, error) {
+			for _, c := range clusters {
+				if c.GetId() == in.GetId() {
+					return &v1.ClusterResponse{Cluster: c}, nil
+				}
+			}
+			return nil, status.Error(codes.NotFound, "not found")
+		},
+		MockGetClusters: func(_ context.Context, _ *v1.GetClustersRequest, _ ...grpc.CallOption) (*v1.ClustersList, error) {
+			return &v1.ClustersList{Clusters: clusters}, nil
+		},
+		MockPutCluster: func(_ context.Context, in *storage.Cluster, _ ...grpc.CallOption) (*v1.ClusterResponse, error) {
+			return &v1.ClusterResponse{Cluster: in}, nil
+		},
 	}
+}
+
+func cluster(annotations map[string]string) *v1alpha1.Cluster {
+	cr := &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{ForProvider: v1alpha1.ClusterParameters{
+		AdmissionController:        boolPtr(true),
+		AdmissionControllerEvents:  boolPtr(false),
+		AdmissionControllerUpdates: boolPtr(false),
+		CentralAPIEndpoint:         "central.stackrox:443",
+		CollectionMethod:           "EBPF",
+		CollectorImage:             "registry.redhat.io/advanced-cluster-security/rhacs-collector-rhel8",
+		MainImage:                  "registry.redhat.io/advanced-cluster-security/rhacs-main-rhel8",
+		Name:                       "remote",
+		SlimCollector:              boolPtr(true),
+		Tolerations:                boolPtr(true),
+		Type:                       "OPENSHIFT4_CLUSTER",
+	}}}
+	cr.SetAnnotations(annotations)
+	return cr
+}
+
+func withDynamicConfig(cr *v1alpha1.Cluster) *v1alpha1.Cluster {
+	cr.Spec.ForProvider.DynamicConfig = &v1alpha1.DynamicConfig{
+		AdmissionControllerConfig: v1alpha1.AdmissionControllerConfig{
+			Enabled:          true,
+			TimeoutSeconds:   10,
+			DisableBypass:    true,
+			EnforceOnUpdates: true,
+		},
+		DisableAuditLogs: true,
+	}
+	return cr
+}
+
+const clusterID = "9b9e1d2a-64a5-4f4e-b0c5-6f1f0c6b6d0e"
+
+func withExternalName(cr *v1alpha1.Cluster, name string) *v1alpha1.Cluster {
+	meta.SetExternalName(cr, name)
+	return cr
+}
+
+func withConnectionSecret(cr *v1alpha1.Cluster) *v1alpha1.Cluster {
+	cr.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Name: "remote-sensor", Namespace: "stackrox"})
+	return cr
+}
+
+func observedCluster(upgrade *storage.ClusterUpgradeStatus) *storage.Cluster {
+	out := generateCluster(&cluster(nil).Spec.ForProvider, nil)
+	out.Id = "remote-id"
+	out.Status = &storage.ClusterStatus{UpgradeStatus: upgrade}
+	return out
+}
+
+// helmCluster returns a cluster whose static configuration is owned by a Helm
+// chart and differs from the spec of cluster.
+func helmCluster() *storage.Cluster {
+	out := observedCluster(nil)
+	out.ManagedBy = storage.ManagerType_MANAGER_TYPE_HELM_CHART
+	out.MainImage = "quay.io/stackrox-io/main"
+	return out
+}
+
+func upgradeStatus(state storage.UpgradeProgress_UpgradeState) *storage.ClusterUpgradeStatus {
+	return &storage.ClusterUpgradeStatus{
+		Upgradability: storage.ClusterUpgradeStatus_UP_TO_DATE,
+		MostRecentProcess: &storage.ClusterUpgradeStatus_UpgradeProcessStatus{
+			Id:       "process-id",
+			Active:   state < storage.UpgradeProgress_UPGRADE_COMPLETE,
+			Progress: &storage.UpgradeProgress{UpgradeState: state},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
 
 	type want struct {
 		o   managed.ExternalObservation
@@ -49,23 +201,772 @@ func TestObserve(t *testing.T) {
 
 	cases := map[string]struct {
 		reason string
-		client *grpc.ClientConn
-		args   args
+		svc    *fakeClustersService
+		cr     *v1alpha1.Cluster
 		want   want
 	}{
-		// TODO: Add test cases.
+		"GetFailed": {
+			reason: "Errors getting the clusters should be returned.",
+			svc: &fakeClustersService{
+				MockGetClusters: func(_ context.Context, _ *v1.GetClustersRequest, _ ...grpc.CallOption) (*v1.ClustersList, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   cluster(nil),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A cluster that does not exist in Central should be reported as not existing.",
+			svc:    withClusters(),
+			cr:     cluster(nil),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"GetByIDFailed": {
+			reason: "Errors getting the cluster by ID should be returned.",
+			svc: &fakeClustersService{
+				MockGetCluster: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*v1.ClusterResponse, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   withExternalName(cluster(nil), clusterID),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFoundByID": {
+			reason: "A cluster whose ID is not known to Central should be reported as not existing.",
+			svc:    withClusters(observedCluster(nil)),
+			cr:     withExternalName(cluster(nil), clusterID),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A cluster that matches the spec should be up to date.",
+			svc:    withClusters(observedCluster(nil)),
+			cr:     cluster(nil),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"DynamicConfigLateInitialized": {
+			reason: "The dynamic config in Central should be late-initialized if the spec omits it.",
+			svc: withClusters(func() *storage.Cluster {
+				c := observedCluster(nil)
+				c.DynamicConfig = &storage.DynamicClusterConfig{RegistryOverride: "quay.io"}
+				return c
+			}()),
+			cr:   cluster(nil),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true}},
+		},
+		"DynamicConfigUpToDate": {
+			reason: "A cluster whose dynamic config matches the spec should be up to date.",
+			svc: withClusters(func() *storage.Cluster {
+				cr := withDynamicConfig(cluster(nil))
+				return generateCluster(&cr.Spec.ForProvider, observedCluster(nil))
+			}()),
+			cr:   withDynamicConfig(cluster(nil)),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"DynamicConfigDrift": {
+			reason: "A cluster whose dynamic config differs from the spec should not be up to date.",
+			svc:    withClusters(observedCluster(nil)),
+			cr:     withDynamicConfig(cluster(nil)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"LateInitializedServerDefaults": {
+			reason: "Parameters that Central fills in should be late-initialized.",
+			svc:    withClusters(observedCluster(nil)),
+			cr: func() *v1alpha1.Cluster {
+				cr := cluster(nil)
+				cr.Spec.ForProvider.CentralAPIEndpoint = ""
+				cr.Spec.ForProvider.MainImage = ""
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true}},
+		},
+		"NormalizedEndpoint": {
+			reason: "The scheme of the endpoint, which Central strips, should not be a difference.",
+			svc:    withClusters(observedCluster(nil)),
+			cr: func() *v1alpha1.Cluster {
+				cr := cluster(nil)
+				cr.Spec.ForProvider.CentralAPIEndpoint = "https://central.stackrox:443"
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"ServerOwnedDynamicConfig": {
+			reason: "The audit logs of non OpenShift 4 clusters and the default timeout are owned by Central.",
+			svc: withClusters(func() *storage.Cluster {
+				c := observedCluster(nil)
+				c.Type = storage.ClusterType_KUBERNETES_CLUSTER
+				c.DynamicConfig = &storage.DynamicClusterConfig{
+					AdmissionControllerConfig: &storage.AdmissionControllerConfig{Enabled: true, TimeoutSeconds: 3},
+					DisableAuditLogs:          true,
+				}
+				return c
+			}()),
+			cr: func() *v1alpha1.Cluster {
+				cr := cluster(nil)
+				cr.Spec.ForProvider.Type = "KUBERNETES_CLUSTER"
+				cr.Spec.ForProvider.DynamicConfig = &v1alpha1.DynamicConfig{
+					AdmissionControllerConfig: v1alpha1.AdmissionControllerConfig{Enabled: true},
+				}
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"HelmManaged": {
+			reason: "The static configuration of a Helm managed cluster is read-only and should not be reconciled.",
+			svc:    withClusters(helmCluster()),
+			cr:     cluster(nil),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"HelmManagedLabels": {
+			reason: "The labels of a Helm managed cluster should be reconciled.",
+			svc:    withClusters(helmCluster()),
+			cr: func() *v1alpha1.Cluster {
+				cr := cluster(nil)
+				cr.Spec.ForProvider.Labels = map[string]string{"env": "prod"}
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"PendingBundle": {
+			reason: "A cluster with a connection secret but without published bundle should not be up to date.",
+			svc:    withClusters(observedCluster(nil)),
+			cr:     withConnectionSecret(cluster(nil)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"PendingUpgrade": {
+			reason: "A cluster with a new value of the upgrade annotation should not be up to date.",
+			svc:    withClusters(observedCluster(nil)),
+			cr:     cluster(map[string]string{v1alpha1.AnnotationKeyUpgrade: "2023-01-30"}),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"PendingCertRotation": {
+			reason: "A cluster with a new value of the rotate-certs annotation should not be up to date.",
+			svc:    withClusters(observedCluster(nil)),
+			cr:     cluster(map[string]string{v1alpha1.AnnotationKeyRotateCerts: "2023-01-30"}),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"TriggersDone": {
+			reason: "A cluster whose triggers were acted on should be up to date.",
+			svc:    withClusters(observedCluster(nil)),
+			cr: func() *v1alpha1.Cluster {
+				cr := cluster(map[string]string{v1alpha1.AnnotationKeyUpgrade: "1", v1alpha1.AnnotationKeyRotateCerts: "2"})
+				cr.Status.AtProvider.LastUpgrade = "1"
+				cr.Status.AtProvider.LastCertRotation = "2"
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.client}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.cr)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
+
+func TestObserveImport(t *testing.T) {
+	imported := &storage.Cluster{
+		Id:                  clusterID,
+		Name:                "legacy",
+		Type:                storage.ClusterType_KUBERNETES_CLUSTER,
+		MainImage:           "quay.io/stackrox-io/main",
+		CollectorImage:      "quay.io/stackrox-io/collector",
+		CentralApiEndpoint:  "central.example.com:443",
+		CollectionMethod:    storage.CollectionMethod_KERNEL_MODULE,
+		AdmissionController: false,
+		Labels:              map[string]string{"env": "prod"},
+		TolerationsConfig:   &storage.TolerationsConfig{Disabled: true},
+	}
+	cr := withExternalName(&v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{ForProvider: v1alpha1.ClusterParameters{
+		SlimCollector: boolPtr(false),
+	}}}, clusterID)
+
+	e := external{svc: withClusters(imported)}
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	want := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s\n", diff)
+	}
+	wantParams := v1alpha1.ClusterParameters{
+		AdmissionController:        boolPtr(false),
+		AdmissionControllerEvents:  boolPtr(false),
+		AdmissionControllerUpdates: boolPtr(false),
+		CentralAPIEndpoint:         "central.example.com:443",
+		CollectionMethod:           "KERNEL_MODULE",
+		CollectorImage:             "quay.io/stackrox-io/collector",
+		Labels:                     map[string]string{"env": "prod"},
+		MainImage:                  "quay.io/stackrox-io/main",
+		Name:                       "legacy",
+		SlimCollector:              boolPtr(false),
+		Tolerations:                boolPtr(false),
+		Type:                       "KUBERNETES_CLUSTER",
+	}
+	if diff := cmp.Diff(wantParams, cr.Spec.ForProvider); diff != "" {
+		t.Errorf("e.Observe(...): -want parameters, +got parameters:\n%s\n", diff)
+	}
+}
+
+func TestCreateDefaults(t *testing.T) {
+	var got *storage.Cluster
+	e := external{svc: &fakeClustersService{
+		MockPostCluster: func(_ context.Context, in *storage.Cluster, _ ...grpc.CallOption) (*v1.ClusterResponse, error) {
+			got = in
+			return &v1.ClusterResponse{Cluster: in}, nil
+		},
+	}}
+	cr := &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{ForProvider: v1alpha1.ClusterParameters{
+		Name:          "remote",
+		SlimCollector: boolPtr(false),
+	}}}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	want := newCluster()
+	want.Name = "remote"
+	want.SlimCollector = false
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Create(...): -want cluster, +got cluster:\n%s\n", diff)
+	}
+}
+
+func TestObserveObserveOnly(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		policies bool
+		want     bool
+	}{
+		"PoliciesHonored": {
+			reason:   "Pending triggers and bundles of an observe-only cluster should not be reported, since they cannot be acted on.",
+			policies: true,
+			want:     true,
+		},
+		"PoliciesIgnored": {
+			reason: "Pending triggers and bundles should be reported if the management policies are not honored.",
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: withClusters(observedCluster(nil)), managementPolicies: tc.policies}
+			cr := withConnectionSecret(cluster(map[string]string{v1alpha1.AnnotationKeyUpgrade: "1"}))
+			cr.SetManagementPolicies(apisv1alpha1.ManagementPolicies{apisv1alpha1.ManagementActionObserve})
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if got.ResourceUpToDate != tc.want {
+				t.Errorf("\n%s\ne.Observe(...): want up to date %t, got %t", tc.reason, tc.want, got.ResourceUpToDate)
+			}
+		})
+	}
+}
+
+func TestCreateNoName(t *testing.T) {
+	e := external{svc: &fakeClustersService{}}
+	_, err := e.Create(context.Background(), &v1alpha1.Cluster{})
+	if diff := cmp.Diff(errors.New(errNoName), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestObserveAfterCreate(t *testing.T) {
+	created := observedCluster(nil)
+	svc := withClusters(created)
+	svc.MockPostCluster = func(_ context.Context, _ *storage.Cluster, _ ...grpc.CallOption) (*v1.ClusterResponse, error) {
+		return &v1.ClusterResponse{Cluster: created}, nil
+	}
+	e := external{svc: svc}
+	cr := cluster(map[string]string{v1alpha1.AnnotationKeyUpgrade: "1", v1alpha1.AnnotationKeyRotateCerts: "2"})
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+
+	// The reconciler only persists the metadata of the managed resource after
+	// Create, so the next reconcile observes it without the status.
+	reread := &v1alpha1.Cluster{ObjectMeta: *cr.ObjectMeta.DeepCopy(), Spec: *cr.Spec.DeepCopy()}
+	got, err := e.Observe(context.Background(), reread)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if !got.ResourceUpToDate {
+		t.Errorf("e.Observe(...): want triggers set before creation to be consumed, got diff:\n%s", got.Diff)
+	}
+}
+
+func TestObserveExternalName(t *testing.T) {
+	byID := observedCluster(nil)
+	byID.Id = clusterID
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeClustersService
+		cr     *v1alpha1.Cluster
+		want   string
+	}{
+		"ByName": {
+			reason: "A cluster without external name should be found by name and get its ID as external name.",
+			svc:    withClusters(byID),
+			cr:     cluster(nil),
+			want:   clusterID,
+		},
+		"ByClusterName": {
+			reason: "A cluster whose external name is its name should be found by name and get its ID as external name.",
+			svc:    withClusters(byID),
+			cr:     withExternalName(cluster(nil), "remote"),
+			want:   clusterID,
+		},
+		"ByID": {
+			reason: "A cluster with an ID as external name should be looked up by ID without listing clusters.",
+			svc: func() *fakeClustersService {
+				svc := withClusters(byID)
+				svc.MockGetClusters = nil
+				return svc
+			}(),
+			cr:   withExternalName(cluster(nil), clusterID),
+			want: clusterID,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			if _, err := e.Observe(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, meta.GetExternalName(tc.cr)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveUpgradeCondition(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status *storage.ClusterUpgradeStatus
+		want   xpv1.Condition
+	}{
+		"NoProcess": {
+			reason: "A cluster without upgrade process should not have an upgrade condition.",
+			status: &storage.ClusterUpgradeStatus{Upgradability: storage.ClusterUpgradeStatus_UP_TO_DATE},
+			want:   xpv1.Condition{Type: apisv1alpha1.TypeSensorUpgrade, Status: corev1.ConditionUnknown},
+		},
+		"InProgress": {
+			reason: "A running upgrade should be reported as in progress.",
+			status: upgradeStatus(storage.UpgradeProgress_UPGRADER_LAUNCHED),
+			want:   apisv1alpha1.UpgradeInProgress("UPGRADE process-id: UPGRADER_LAUNCHED"),
+		},
+		"Complete": {
+			reason: "A completed upgrade should be reported as complete.",
+			status: upgradeStatus(storage.UpgradeProgress_UPGRADE_COMPLETE),
+			want:   apisv1alpha1.UpgradeComplete("UPGRADE process-id: UPGRADE_COMPLETE"),
+		},
+		"Failed": {
+			reason: "A failed upgrade should be reported as failed.",
+			status: upgradeStatus(storage.UpgradeProgress_UPGRADE_ERROR_ROLLED_BACK),
+			want:   apisv1alpha1.UpgradeFailed("UPGRADE process-id: UPGRADE_ERROR_ROLLED_BACK"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := cluster(nil)
+			e := external{svc: withClusters(observedCluster(tc.status))}
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := cr.GetCondition(apisv1alpha1.TypeSensorUpgrade)
+			if diff := cmp.Diff(tc.want, got, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveManagedBy(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		managedBy storage.ManagerType
+		want      xpv1.Condition
+	}{
+		"Manual": {
+			reason:    "A manually managed cluster should be managed through Central.",
+			managedBy: storage.ManagerType_MANAGER_TYPE_MANUAL,
+			want:      apisv1alpha1.ManagedByCentral(),
+		},
+		"Helm": {
+			reason:    "A Helm managed cluster should be reported as such.",
+			managedBy: storage.ManagerType_MANAGER_TYPE_HELM_CHART,
+			want:      apisv1alpha1.ManagedByHelm(),
+		},
+		"Operator": {
+			reason:    "An operator managed cluster should be reported as such.",
+			managedBy: storage.ManagerType_MANAGER_TYPE_KUBERNETES_OPERATOR,
+			want:      apisv1alpha1.ManagedByOperator(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := cluster(nil)
+			observed := observedCluster(nil)
+			observed.ManagedBy = tc.managedBy
+			e := external{svc: withClusters(observed)}
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, cr.GetCondition(apisv1alpha1.TypeExternallyManaged), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func healthStatus(overall, sensor storage.ClusterHealthStatus_HealthStatusLabel) *storage.ClusterHealthStatus {
+	return &storage.ClusterHealthStatus{
+		OverallHealthStatus:          overall,
+		SensorHealthStatus:           sensor,
+		CollectorHealthStatus:        overall,
+		AdmissionControlHealthStatus: overall,
+	}
+}
+
+func TestObserveHealth(t *testing.T) {
+	type want struct {
+		cond   xpv1.Condition
+		health *v1alpha1.ClusterHealthStatus
+	}
+
+	cases := map[string]struct {
+		reason string
+		status *storage.ClusterHealthStatus
+		want   want
+	}{
+		"NoHealthStatus": {
+			reason: "A cluster without health status should not be ready.",
+			want:   want{cond: apisv1alpha1.Uninitialized()},
+		},
+		"Uninitialized": {
+			reason: "A cluster whose sensor never connected should not be ready.",
+			status: healthStatus(storage.ClusterHealthStatus_UNINITIALIZED, storage.ClusterHealthStatus_UNINITIALIZED),
+			want: want{
+				cond: apisv1alpha1.Uninitialized(),
+				health: &v1alpha1.ClusterHealthStatus{
+					OverallHealthStatus:          "UNINITIALIZED",
+					SensorHealthStatus:           "UNINITIALIZED",
+					CollectorHealthStatus:        "UNINITIALIZED",
+					AdmissionControlHealthStatus: "UNINITIALIZED",
+				},
+			},
+		},
+		"SensorUnhealthy": {
+			reason: "A cluster with an unhealthy sensor should not be ready.",
+			status: healthStatus(storage.ClusterHealthStatus_DEGRADED, storage.ClusterHealthStatus_UNAVAILABLE),
+			want: want{
+				cond: apisv1alpha1.SensorUnhealthy("sensor is UNAVAILABLE"),
+				health: &v1alpha1.ClusterHealthStatus{
+					OverallHealthStatus:          "DEGRADED",
+					SensorHealthStatus:           "UNAVAILABLE",
+					CollectorHealthStatus:        "DEGRADED",
+					AdmissionControlHealthStatus: "DEGRADED",
+				},
+			},
+		},
+		"Unhealthy": {
+			reason: "A cluster with unhealthy secured cluster services should not be ready.",
+			status: healthStatus(storage.ClusterHealthStatus_UNHEALTHY, storage.ClusterHealthStatus_HEALTHY),
+			want: want{
+				cond: apisv1alpha1.Unhealthy("collector is UNHEALTHY, admission control is UNHEALTHY"),
+				health: &v1alpha1.ClusterHealthStatus{
+					OverallHealthStatus:          "UNHEALTHY",
+					SensorHealthStatus:           "HEALTHY",
+					CollectorHealthStatus:        "UNHEALTHY",
+					AdmissionControlHealthStatus: "UNHEALTHY",
+				},
+			},
+		},
+		"Healthy": {
+			reason: "A healthy cluster should be ready and report its collector pods.",
+			status: func() *storage.ClusterHealthStatus {
+				s := healthStatus(storage.ClusterHealthStatus_HEALTHY, storage.ClusterHealthStatus_HEALTHY)
+				s.CollectorHealthInfo = &storage.CollectorHealthInfo{
+					Version:             "3.73.0",
+					TotalDesiredPodsOpt: &storage.CollectorHealthInfo_TotalDesiredPods{TotalDesiredPods: 3},
+					TotalReadyPodsOpt:   &storage.CollectorHealthInfo_TotalReadyPods{TotalReadyPods: 3},
+				}
+				return s
+			}(),
+			want: want{
+				cond: xpv1.Available(),
+				health: &v1alpha1.ClusterHealthStatus{
+					OverallHealthStatus:          "HEALTHY",
+					SensorHealthStatus:           "HEALTHY",
+					CollectorHealthStatus:        "HEALTHY",
+					AdmissionControlHealthStatus: "HEALTHY",
+					CollectorHealthInfo: &v1alpha1.CollectorHealthInfo{
+						Version:          "3.73.0",
+						TotalDesiredPods: int32Ptr(3),
+						TotalReadyPods:   int32Ptr(3),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := cluster(nil)
+			observed := observedCluster(nil)
+			observed.HealthStatus = tc.status
+			e := external{svc: withClusters(observed)}
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.cond, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.health, cr.Status.AtProvider.HealthStatus); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want health status, +got health status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		puts      int
+		upgrades  []string
+		rotations []string
+		obs       v1alpha1.ClusterObservation
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Cluster
+		want   want
+	}{
+		"Upgrade": {
+			reason: "A new value of the upgrade annotation should trigger a sensor upgrade.",
+			cr:     cluster(map[string]string{v1alpha1.AnnotationKeyUpgrade: "1"}),
+			want: want{
+				upgrades: []string{"remote-id"},
+				obs:      v1alpha1.ClusterObservation{LastUpgrade: "1"},
+			},
+		},
+		"RotateCerts": {
+			reason: "A new value of the rotate-certs annotation should trigger a certificate rotation.",
+			cr:     cluster(map[string]string{v1alpha1.AnnotationKeyRotateCerts: "1"}),
+			want: want{
+				rotations: []string{"remote-id"},
+				obs:       v1alpha1.ClusterObservation{LastCertRotation: "1"},
+			},
+		},
+		"NothingPending": {
+			reason: "Triggers that were acted on should not fire again.",
+			cr: func() *v1alpha1.Cluster {
+				cr := cluster(map[string]string{v1alpha1.AnnotationKeyUpgrade: "1"})
+				cr.Status.AtProvider.LastUpgrade = "1"
+				return cr
+			}(),
+			want: want{obs: v1alpha1.ClusterObservation{LastUpgrade: "1"}},
+		},
+		"Drifted": {
+			reason: "A drifted cluster should be put.",
+			cr: func() *v1alpha1.Cluster {
+				cr := cluster(nil)
+				cr.Spec.ForProvider.Labels = map[string]string{"env": "prod"}
+				return cr
+			}(),
+			want: want{puts: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var upgrades, rotations []string
+			puts := 0
+			svc := withClusters(observedCluster(nil))
+			put := svc.MockPutCluster
+			svc.MockPutCluster = func(ctx context.Context, in *storage.Cluster, opts ...grpc.CallOption) (*v1.ClusterResponse, error) {
+				puts++
+				return put(ctx, in, opts...)
+			}
+			e := external{
+				svc: svc,
+				upgrades: &fakeSensorUpgradeService{
+					MockTriggerSensorUpgrade: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+						upgrades = append(upgrades, in.GetId())
+						return &v1.Empty{}, nil
+					},
+					MockTriggerSensorCertRotation: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+						rotations = append(rotations, in.GetId())
+						return &v1.Empty{}, nil
+					},
+				},
+			}
+
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			if puts != tc.want.puts {
+				t.Errorf("\n%s\ne.Update(...): want %d puts, got %d", tc.reason, tc.want.puts, puts)
+			}
+			if diff := cmp.Diff(tc.want.upgrades, upgrades); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want upgrades, +got upgrades:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rotations, rotations); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want rotations, +got rotations:\n%s\n", tc.reason, diff)
+			}
+			got := v1alpha1.ClusterObservation{
+				LastUpgrade:      tc.cr.Status.AtProvider.LastUpgrade,
+				LastCertRotation: tc.cr.Status.AtProvider.LastCertRotation,
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want observation, +got observation:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateHelmManaged(t *testing.T) {
+	var got *storage.Cluster
+	svc := withClusters(helmCluster())
+	svc.MockPutCluster = func(_ context.Context, in *storage.Cluster, _ ...grpc.CallOption) (*v1.ClusterResponse, error) {
+		got = in
+		return &v1.ClusterResponse{Cluster: in}, nil
+	}
+	e := external{svc: svc}
+	cr := cluster(nil)
+	cr.Spec.ForProvider.Labels = map[string]string{"env": "prod"}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	// Only the labels change, the static configuration is kept as rendered
+	// by the Helm chart.
+	want := helmCluster()
+	want.Labels = map[string]string{"env": "prod"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want cluster, +got cluster:\n%s\n", diff)
+	}
+}
+
+func TestUpdateBundle(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.Now()
+
+	type want struct {
+		requests []apiparams.ClusterZip
+		cd       managed.ConnectionDetails
+		last     string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Cluster
+		bundle func(ctx context.Context, params apiparams.ClusterZip) ([]byte, error)
+		want   want
+	}{
+		"NoConnectionSecret": {
+			reason: "A bundle should not be generated if there is nowhere to publish it.",
+			cr:     cluster(nil),
+		},
+		"FirstBundle": {
+			reason: "The bundle of a new cluster should be published as connection details.",
+			cr: func() *v1alpha1.Cluster {
+				cr := withConnectionSecret(cluster(nil))
+				cr.Spec.ForProvider.SensorBundle = &v1alpha1.SensorBundle{DisablePodSecurityPolicies: true}
+				return cr
+			}(),
+			want: want{
+				requests: []apiparams.ClusterZip{{ID: "remote-id", DisablePodSecurityPolicies: true}},
+				cd: managed.ConnectionDetails{
+					"sensor.yaml":           []byte("kind: Deployment"),
+					"additional-cas_ca.pem": []byte("ca"),
+				},
+			},
+		},
+		"Regenerate": {
+			reason: "A new value of the generate-bundle annotation should publish a new bundle.",
+			cr: func() *v1alpha1.Cluster {
+				cr := withConnectionSecret(cluster(map[string]string{v1alpha1.AnnotationKeyGenerateBundle: "2"}))
+				cr.Status.AtProvider.LastBundle = "1"
+				cr.Status.AtProvider.BundleGeneratedAt = &now
+				return cr
+			}(),
+			want: want{
+				requests: []apiparams.ClusterZip{{ID: "remote-id"}},
+				cd: managed.ConnectionDetails{
+					"sensor.yaml":           []byte("kind: Deployment"),
+					"additional-cas_ca.pem": []byte("ca"),
+				},
+				last: "2",
+			},
+		},
+		"Published": {
+			reason: "A bundle should not be generated again without a new annotation value.",
+			cr: func() *v1alpha1.Cluster {
+				cr := withConnectionSecret(cluster(map[string]string{v1alpha1.AnnotationKeyGenerateBundle: "1"}))
+				cr.Status.AtProvider.LastBundle = "1"
+				cr.Status.AtProvider.BundleGeneratedAt = &now
+				return cr
+			}(),
+			want: want{last: "1"},
+		},
+		"BundleFailed": {
+			reason: "Errors generating the bundle should be returned.",
+			cr:     withConnectionSecret(cluster(nil)),
+			bundle: func(_ context.Context, _ apiparams.ClusterZip) ([]byte, error) {
+				return nil, errBoom
+			},
+			want: want{err: errors.Wrap(errBoom, errBundleFailed)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []apiparams.ClusterZip
+			bundle := tc.bundle
+			if bundle == nil {
+				bundle = func(_ context.Context, params apiparams.ClusterZip) ([]byte, error) {
+					requests = append(requests, params)
+					return zipFiles(t, map[string]string{"sensor.yaml": "kind: Deployment", "additional-cas/ca.pem": "ca"}), nil
+				}
+			}
+			e := external{
+				svc:     withClusters(observedCluster(nil)),
+				bundles: &fakeBundleClient{MockGetSensorBundle: bundle},
+			}
+
+			got, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cd, got.ConnectionDetails); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want connection details, +got connection details:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.last, tc.cr.Status.AtProvider.LastBundle); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want last bundle, +got last bundle:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
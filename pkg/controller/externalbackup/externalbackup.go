@@ -0,0 +1,433 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalbackup
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/externalbackup/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotExternalBackup = "managed resource is not an ExternalBackup custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errGetCreds          = "cannot get credentials"
+	errGetSecret         = "cannot get external backup secret"
+	errNoConfig          = "exactly one external backup config must be set"
+	errTestFailed        = "cannot test external backup"
+	errTriggerFailed     = "cannot trigger external backup"
+	errGetFailed         = "cannot get external backup"
+	errObserveFailed     = "cannot observe external backup"
+	errCreateFailed      = "cannot create external backup"
+	errUpdateFailed      = "cannot update external backup"
+	errDeleteFailed      = "cannot delete external backup"
+)
+
+// External backup types as registered in Central.
+const (
+	typeS3  = "s3"
+	typeGCS = "gcs"
+)
+
+// Setup adds a controller that reconciles ExternalBackup managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ExternalBackupGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ExternalBackupGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ExternalBackup{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ExternalBackup)
+	if !ok {
+		return nil, errors.New(errNotExternalBackup)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{kube: c.kube, svc: v1.NewExternalBackupServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube client.Client
+	svc  v1.ExternalBackupServiceClient
+}
+
+func (c *external) getSecret(ctx context.Context, ref *xpv1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	v, err := resource.ExtractSecret(ctx, c.kube, xpv1.CommonCredentialSelectors{SecretRef: ref})
+	return string(v), errors.Wrap(err, errGetSecret)
+}
+
+// setSecrets resolves the secret references of the spec and writes their
+// values into the external backup config.
+func (c *external) setSecrets(ctx context.Context, in *v1alpha1.ExternalBackupParameters, eb *storage.ExternalBackup) error {
+	var err error
+	switch {
+	case in.S3 != nil:
+		if eb.GetS3().AccessKeyId, err = c.getSecret(ctx, in.S3.AccessKeyIDSecretRef); err != nil {
+			return err
+		}
+		eb.GetS3().SecretAccessKey, err = c.getSecret(ctx, in.S3.SecretAccessKeySecretRef)
+	case in.GCS != nil:
+		eb.GetGcs().ServiceAccount, err = c.getSecret(ctx, in.GCS.ServiceAccountSecretRef)
+	}
+	return err
+}
+
+func backupType(in *v1alpha1.ExternalBackupParameters) string {
+	switch {
+	case in.S3 != nil && in.GCS != nil:
+		return ""
+	case in.S3 != nil:
+		return typeS3
+	case in.GCS != nil:
+		return typeGCS
+	}
+	return ""
+}
+
+// pendingTrigger returns the value of the trigger annotation if it has not
+// been acted on yet.
+func pendingTrigger(cr *v1alpha1.ExternalBackup) string {
+	v := cr.GetAnnotations()[v1alpha1.AnnotationKeyTrigger]
+	if v == cr.Status.AtProvider.LastTrigger {
+		return ""
+	}
+	return v
+}
+
+func generateObservation(in *storage.ExternalBackup, lastTrigger string) v1alpha1.ExternalBackupObservation {
+	return v1alpha1.ExternalBackupObservation{
+		ID:          in.GetId(),
+		Name:        in.GetName(),
+		Type:        in.GetType(),
+		LastTrigger: lastTrigger,
+	}
+}
+
+func generateSchedule(in v1alpha1.Schedule) *storage.Schedule {
+	out := &storage.Schedule{
+		IntervalType: storage.Schedule_IntervalType(storage.Schedule_IntervalType_value[in.Interval]),
+		Hour:         in.Hour,
+		Minute:       in.Minute,
+	}
+	if out.IntervalType == storage.Schedule_WEEKLY {
+		out.Interval = &storage.Schedule_Weekly{Weekly: &storage.Schedule_WeeklyInterval{Day: in.Weekday}}
+	}
+	return out
+}
+
+func generateExternalBackup(in *v1alpha1.ExternalBackupParameters, base *storage.ExternalBackup) *storage.ExternalBackup {
+	if base == nil {
+		base = &storage.ExternalBackup{}
+	}
+	base.Name = in.Name
+	base.Type = backupType(in)
+	base.Schedule = generateSchedule(in.Schedule)
+	base.BackupsToKeep = in.BackupsToKeep
+	switch {
+	case in.S3 != nil:
+		base.Config = &storage.ExternalBackup_S3{S3: &storage.S3Config{
+			Bucket:       in.S3.Bucket,
+			ObjectPrefix: in.S3.ObjectPrefix,
+			Region:       in.S3.Region,
+			Endpoint:     in.S3.Endpoint,
+			UseIam:       in.S3.UseIAM,
+		}}
+	case in.GCS != nil:
+		base.Config = &storage.ExternalBackup_Gcs{Gcs: &storage.GCSConfig{
+			Bucket:        in.GCS.Bucket,
+			ObjectPrefix:  in.GCS.ObjectPrefix,
+			UseWorkloadId: in.GCS.UseWorkloadIdentity,
+		}}
+	}
+	return base
+}
+
+func generateScheduleParameters(in *storage.Schedule) v1alpha1.Schedule {
+	return v1alpha1.Schedule{
+		Interval: storage.Schedule_IntervalType_name[int32(in.GetIntervalType())],
+		Hour:     in.GetHour(),
+		Minute:   in.GetMinute(),
+		Weekday:  in.GetWeekly().GetDay(),
+	}
+}
+
+// generateExternalBackupParameters converts an observed external backup into
+// parameters. Central masks credentials in its responses, so the secret
+// references are taken from the supplied spec instead.
+func generateExternalBackupParameters(in *storage.ExternalBackup, spec *v1alpha1.ExternalBackupParameters) v1alpha1.ExternalBackupParameters {
+	out := v1alpha1.ExternalBackupParameters{
+		Name:          in.GetName(),
+		Schedule:      generateScheduleParameters(in.GetSchedule()),
+		BackupsToKeep: in.GetBackupsToKeep(),
+	}
+	// The weekday only applies to weekly backups.
+	if in.GetSchedule().GetIntervalType() != storage.Schedule_WEEKLY {
+		out.Schedule.Weekday = spec.Schedule.Weekday
+	}
+	switch in.GetType() {
+	case typeS3:
+		s3 := in.GetS3()
+		out.S3 = &v1alpha1.S3Config{
+			Bucket:       s3.GetBucket(),
+			ObjectPrefix: s3.GetObjectPrefix(),
+			Region:       s3.GetRegion(),
+			Endpoint:     s3.GetEndpoint(),
+			UseIAM:       s3.GetUseIam(),
+		}
+		if spec.S3 != nil {
+			out.S3.AccessKeyIDSecretRef = spec.S3.AccessKeyIDSecretRef
+			out.S3.SecretAccessKeySecretRef = spec.S3.SecretAccessKeySecretRef
+		}
+	case typeGCS:
+		gcs := in.GetGcs()
+		out.GCS = &v1alpha1.GCSConfig{
+			Bucket:              gcs.GetBucket(),
+			ObjectPrefix:        gcs.GetObjectPrefix(),
+			UseWorkloadIdentity: gcs.GetUseWorkloadId(),
+		}
+		if spec.GCS != nil {
+			out.GCS.ServiceAccountSecretRef = spec.GCS.ServiceAccountSecretRef
+		}
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.ExternalBackup, observed *storage.ExternalBackup) (bool, string) {
+	observedParams := generateExternalBackupParameters(observed, &in.Spec.ForProvider)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in external backup\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getExternalBackup(ctx context.Context, cr *v1alpha1.ExternalBackup) (*storage.ExternalBackup, error) {
+	resp, err := c.svc.GetExternalBackups(ctx, &v1.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetExternalBackups() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			return it, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ExternalBackup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotExternalBackup)
+	}
+
+	backup, err := c.getExternalBackup(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if backup == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	lastTrigger := cr.Status.AtProvider.LastTrigger
+	if lastTrigger == "" {
+		// A trigger consumed on creation is only recorded in an annotation,
+		// because the status written by Create is not persisted.
+		lastTrigger = cr.GetAnnotations()[v1alpha1.AnnotationKeyConsumedTrigger]
+	}
+	cr.Status.AtProvider = generateObservation(backup, lastTrigger)
+	cr.SetConditions(xpv1.Available())
+	// Create only creates backups that passed the test of Central, but the
+	// condition it sets is lost. A backup that was created by the provider
+	// therefore passed its last test, which also supersedes a failed test
+	// of an earlier Create.
+	if !meta.GetExternalCreateSucceeded(cr).IsZero() {
+		cr.SetConditions(apisv1alpha1.TestSucceeded())
+	}
+	meta.SetExternalName(cr, backup.GetName())
+	upToDate, diff := isUpToDate(cr, backup)
+	if t := pendingTrigger(cr); t != "" {
+		upToDate = false
+		diff += "Pending backup trigger " + t + "\n"
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ExternalBackup)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotExternalBackup)
+	}
+	if backupType(&cr.Spec.ForProvider) == "" {
+		return managed.ExternalCreation{}, errors.New(errNoConfig)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	req := generateExternalBackup(&cr.Spec.ForProvider, nil)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, req); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	if _, err := c.svc.TestExternalBackup(ctx, req); err != nil {
+		cr.SetConditions(apisv1alpha1.TestFailed(err))
+		return managed.ExternalCreation{}, errors.Wrap(err, errTestFailed)
+	}
+	cr.SetConditions(apisv1alpha1.TestSucceeded())
+
+	resp, err := c.svc.PostExternalBackup(ctx, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	// A freshly created backup has nothing to catch up on, so a trigger that
+	// is already set must not fire right away.
+	if t := cr.GetAnnotations()[v1alpha1.AnnotationKeyTrigger]; t != "" {
+		meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyConsumedTrigger: t})
+	}
+	meta.SetExternalName(cr, resp.GetName())
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ExternalBackup)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotExternalBackup)
+	}
+	if backupType(&cr.Spec.ForProvider) == "" {
+		return managed.ExternalUpdate{}, errors.New(errNoConfig)
+	}
+
+	backup, err := c.getExternalBackup(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if backup == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	if upToDate, _ := isUpToDate(cr, backup); !upToDate {
+		req := generateExternalBackup(&cr.Spec.ForProvider, backup)
+		if err := c.setSecrets(ctx, &cr.Spec.ForProvider, req); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+		}
+		if _, err := c.svc.UpdateExternalBackup(ctx, &v1.UpdateExternalBackupRequest{ExternalBackup: req, UpdatePassword: true}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+		}
+	}
+
+	if t := pendingTrigger(cr); t != "" {
+		if _, err := c.svc.TriggerExternalBackup(ctx, &v1.ResourceByID{Id: backup.GetId()}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errTriggerFailed)
+		}
+		cr.Status.AtProvider.LastTrigger = t
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ExternalBackup)
+	if !ok {
+		return errors.New(errNotExternalBackup)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeleteExternalBackup(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
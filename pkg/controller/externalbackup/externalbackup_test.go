@@ -0,0 +1,417 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalbackup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/externalbackup/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	gcs := v1alpha1.ExternalBackupParameters{
+		Name:          "gcs",
+		Schedule:      v1alpha1.Schedule{Interval: "DAILY", Hour: 2, Weekday: 3},
+		BackupsToKeep: 7,
+		GCS: &v1alpha1.GCSConfig{
+			Bucket:                  "backups",
+			ServiceAccountSecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "gcs", Namespace: "crossplane-system"}, Key: "key.json"},
+		},
+	}
+	s3 := v1alpha1.ExternalBackupParameters{
+		Name:          "s3",
+		Schedule:      v1alpha1.Schedule{Interval: "WEEKLY", Hour: 2, Minute: 30, Weekday: 1},
+		BackupsToKeep: 4,
+		S3:            &v1alpha1.S3Config{Bucket: "backups", Region: "us-east-1", UseIAM: true},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		spec     v1alpha1.ExternalBackupParameters
+		observed *storage.ExternalBackup
+		want     bool
+	}{
+		"MaskedServiceAccountIgnored": {
+			reason: "A masked service account returned by Central should not cause drift.",
+			spec:   gcs,
+			observed: &storage.ExternalBackup{Name: "gcs", Type: "gcs", BackupsToKeep: 7,
+				Schedule: &storage.Schedule{IntervalType: storage.Schedule_DAILY, Hour: 2},
+				Config:   &storage.ExternalBackup_Gcs{Gcs: &storage.GCSConfig{Bucket: "backups", ServiceAccount: "******"}}},
+			want: true,
+		},
+		"WeeklySchedule": {
+			reason:   "A weekly schedule should round trip.",
+			spec:     s3,
+			observed: generateExternalBackup(&s3, nil),
+			want:     true,
+		},
+		"WeekdayChanged": {
+			reason: "A different weekday of a weekly schedule should cause drift.",
+			spec:   s3,
+			observed: &storage.ExternalBackup{Name: "s3", Type: "s3", BackupsToKeep: 4,
+				Schedule: &storage.Schedule{IntervalType: storage.Schedule_WEEKLY, Hour: 2, Minute: 30,
+					Interval: &storage.Schedule_Weekly{Weekly: &storage.Schedule_WeeklyInterval{Day: 5}}},
+				Config: &storage.ExternalBackup_S3{S3: &storage.S3Config{Bucket: "backups", Region: "us-east-1", UseIam: true}}},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.ExternalBackup{Spec: v1alpha1.ExternalBackupSpec{ForProvider: tc.spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+func TestPendingTrigger(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		annotation  string
+		lastTrigger string
+		want        string
+	}{
+		"NoAnnotation": {
+			reason: "No trigger should be pending without annotation.",
+			want:   "",
+		},
+		"NewTrigger": {
+			reason:      "A changed annotation should trigger a backup.",
+			annotation:  "2022-11-02T10:00:00Z",
+			lastTrigger: "2022-11-01T10:00:00Z",
+			want:        "2022-11-02T10:00:00Z",
+		},
+		"AlreadyTriggered": {
+			reason:      "An annotation that was acted on should not trigger again.",
+			annotation:  "2022-11-01T10:00:00Z",
+			lastTrigger: "2022-11-01T10:00:00Z",
+			want:        "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.ExternalBackup{}
+			if tc.annotation != "" {
+				cr.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{v1alpha1.AnnotationKeyTrigger: tc.annotation}}
+			}
+			cr.Status.AtProvider.LastTrigger = tc.lastTrigger
+			got := pendingTrigger(cr)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\npendingTrigger(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type fakeExternalBackupService struct {
+	v1.ExternalBackupServiceClient
+
+	MockGetExternalBackups    func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.GetExternalBackupsResponse, error)
+	MockPostExternalBackup    func(ctx context.Context, in *storage.ExternalBackup, opts ...grpc.CallOption) (*storage.ExternalBackup, error)
+	MockTestExternalBackup    func(ctx context.Context, in *storage.ExternalBackup, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockTriggerExternalBackup func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockUpdateExternalBackup  func(ctx context.Context, in *v1.UpdateExternalBackupRequest, opts ...grpc.CallOption) (*storage.ExternalBackup, error)
+	MockDeleteExternalBackup  func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeExternalBackupService) GetExternalBackups(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.GetExternalBackupsResponse, error) {
+	return f.MockGetExternalBackups(ctx, in, opts...)
+}
+
+func (f *fakeExternalBackupService) PostExternalBackup(ctx context.Context, in *storage.ExternalBackup, opts ...grpc.CallOption) (*storage.ExternalBackup, error) {
+	return f.MockPostExternalBackup(ctx, in, opts...)
+}
+
+func (f *fakeExternalBackupService) TestExternalBackup(ctx context.Context, in *storage.ExternalBackup, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockTestExternalBackup(ctx, in, opts...)
+}
+
+func (f *fakeExternalBackupService) TriggerExternalBackup(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockTriggerExternalBackup(ctx, in, opts...)
+}
+
+func (f *fakeExternalBackupService) UpdateExternalBackup(ctx context.Context, in *v1.UpdateExternalBackupRequest, opts ...grpc.CallOption) (*storage.ExternalBackup, error) {
+	return f.MockUpdateExternalBackup(ctx, in, opts...)
+}
+
+func (f *fakeExternalBackupService) DeleteExternalBackup(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteExternalBackup(ctx, in, opts...)
+}
+
+func s3Backup(trigger, lastTrigger string) *v1alpha1.ExternalBackup {
+	cr := &v1alpha1.ExternalBackup{Spec: v1alpha1.ExternalBackupSpec{ForProvider: v1alpha1.ExternalBackupParameters{
+		Name:          "s3",
+		Schedule:      v1alpha1.Schedule{Interval: "DAILY", Hour: 2},
+		BackupsToKeep: 4,
+		S3:            &v1alpha1.S3Config{Bucket: "backups", Region: "us-east-1", UseIAM: true},
+	}}}
+	if trigger != "" {
+		cr.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyTrigger: trigger})
+	}
+	cr.Status.AtProvider.LastTrigger = lastTrigger
+	return cr
+}
+
+func withExternalBackups(b ...*storage.ExternalBackup) *fakeExternalBackupService {
+	return &fakeExternalBackupService{
+		MockGetExternalBackups: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.GetExternalBackupsResponse, error) {
+			return &v1.GetExternalBackupsResponse{ExternalBackups: b}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	observed := generateExternalBackup(&s3Backup("", "").Spec.ForProvider, &storage.ExternalBackup{Id: "id"})
+
+	type want struct {
+		o      managed.ExternalObservation
+		tested xpv1.Condition
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeExternalBackupService
+		cr     *v1alpha1.ExternalBackup
+		want   want
+	}{
+		"NotFound": {
+			reason: "An external backup that does not exist in Central should be reported as not existing.",
+			svc:    withExternalBackups(),
+			cr:     s3Backup("", ""),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "An external backup matching the spec without pending trigger should be reported as up to date.",
+			svc:    withExternalBackups(observed),
+			cr:     s3Backup("2022-11-01T10:00:00Z", "2022-11-01T10:00:00Z"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"PendingTrigger": {
+			reason: "A pending trigger should be reported as not up to date, so that Update runs the backup.",
+			svc:    withExternalBackups(observed),
+			cr:     s3Backup("2022-11-02T10:00:00Z", "2022-11-01T10:00:00Z"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"ConsumedTrigger": {
+			reason: "A trigger consumed on creation should not fire.",
+			svc:    withExternalBackups(observed),
+			cr: func() *v1alpha1.ExternalBackup {
+				cr := s3Backup("2022-11-01T10:00:00Z", "")
+				meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyConsumedTrigger: "2022-11-01T10:00:00Z"})
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"Created": {
+			reason: "An external backup created by the provider should have passed its test, superseding an earlier failed test.",
+			svc:    withExternalBackups(observed),
+			cr: func() *v1alpha1.ExternalBackup {
+				cr := s3Backup("", "")
+				cr.SetConditions(apisv1alpha1.TestFailed(errors.New("boom")))
+				meta.SetExternalCreateSucceeded(cr, time.Now())
+				return cr
+			}(),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				tested: apisv1alpha1.TestSucceeded(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.tested.Type == "" {
+				return
+			}
+			if diff := cmp.Diff(tc.want.tested, tc.cr.GetCondition(apisv1alpha1.TypeTested), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want tested condition, +got tested condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		consumed string
+		tested   xpv1.Condition
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		cr      *v1alpha1.ExternalBackup
+		testErr error
+		want    want
+	}{
+		"TestFailed": {
+			reason:  "An external backup that fails the test should not be created.",
+			cr:      s3Backup("", ""),
+			testErr: errBoom,
+			want: want{
+				tested: apisv1alpha1.TestFailed(errBoom),
+				err:    errors.Wrap(errBoom, errTestFailed),
+			},
+		},
+		"Success": {
+			reason: "A trigger set at creation should be recorded as consumed, so that it does not fire right away.",
+			cr:     s3Backup("2022-11-01T10:00:00Z", ""),
+			want: want{
+				consumed: "2022-11-01T10:00:00Z",
+				tested:   apisv1alpha1.TestSucceeded(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: &fakeExternalBackupService{
+				MockTestExternalBackup: func(_ context.Context, _ *storage.ExternalBackup, _ ...grpc.CallOption) (*v1.Empty, error) {
+					return &v1.Empty{}, tc.testErr
+				},
+				MockPostExternalBackup: func(_ context.Context, in *storage.ExternalBackup, _ ...grpc.CallOption) (*storage.ExternalBackup, error) {
+					out := *in
+					out.Id = "id"
+					return &out, nil
+				},
+			}}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.consumed, tc.cr.GetAnnotations()[v1alpha1.AnnotationKeyConsumedTrigger]); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want consumed trigger, +got consumed trigger:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.tested, tc.cr.GetCondition(apisv1alpha1.TypeTested), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want tested condition, +got tested condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	inSync := generateExternalBackup(&s3Backup("", "").Spec.ForProvider, &storage.ExternalBackup{Id: "id"})
+	drifted := generateExternalBackup(&s3Backup("", "").Spec.ForProvider, &storage.ExternalBackup{Id: "id"})
+	drifted.BackupsToKeep = 1
+
+	type want struct {
+		updates     int
+		triggers    int
+		lastTrigger string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *storage.ExternalBackup
+		cr       *v1alpha1.ExternalBackup
+		want     want
+	}{
+		"TriggerOnly": {
+			reason:   "A pending trigger of an external backup without drift should only trigger a backup.",
+			observed: inSync,
+			cr:       s3Backup("2022-11-02T10:00:00Z", "2022-11-01T10:00:00Z"),
+			want:     want{triggers: 1, lastTrigger: "2022-11-02T10:00:00Z"},
+		},
+		"DriftOnly": {
+			reason:   "Drift without pending trigger should only update the external backup.",
+			observed: drifted,
+			cr:       s3Backup("2022-11-01T10:00:00Z", "2022-11-01T10:00:00Z"),
+			want:     want{updates: 1, lastTrigger: "2022-11-01T10:00:00Z"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updates, triggers := 0, 0
+			svc := withExternalBackups(tc.observed)
+			svc.MockUpdateExternalBackup = func(_ context.Context, in *v1.UpdateExternalBackupRequest, _ ...grpc.CallOption) (*storage.ExternalBackup, error) {
+				updates++
+				return in.GetExternalBackup(), nil
+			}
+			svc.MockTriggerExternalBackup = func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+				triggers++
+				return &v1.Empty{}, nil
+			}
+			e := external{svc: svc}
+
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			got := want{updates: updates, triggers: triggers, lastTrigger: tc.cr.Status.AtProvider.LastTrigger}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var deleted string
+	cr := s3Backup("", "")
+	cr.Status.AtProvider.ID = "id"
+	e := external{svc: &fakeExternalBackupService{
+		MockDeleteExternalBackup: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+			deleted = in.GetId()
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if deleted != "id" {
+		t.Errorf("e.Delete(...): want external backup %q deleted, got %q", "id", deleted)
+	}
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package group
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/group/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotGroup       = "managed resource is not a Group custom resource"
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetPC          = "cannot get ProviderConfig"
+	errGetCreds       = "cannot get credentials"
+	errDefaultWithKey = "key and value must be empty for the default group of an auth provider"
+	errMissingKey     = "key must be set unless the group is the default group of an auth provider"
+	errGetFailed      = "cannot get group"
+	errObserveFailed  = "cannot observe group"
+	errCreateFailed   = "cannot create group"
+	errUpdateFailed   = "cannot update group"
+	errDeleteFailed   = "cannot delete group"
+)
+
+// Setup adds a controller that reconciles Group managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.GroupGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.GroupGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Group{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Group)
+	if !ok {
+		return nil, errors.New(errNotGroup)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{svc: v1.NewGroupServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc v1.GroupServiceClient
+}
+
+func validate(in *v1alpha1.GroupParameters) error {
+	if in.DefaultForProvider {
+		if in.Key != "" || in.Value != "" {
+			return errors.New(errDefaultWithKey)
+		}
+		return nil
+	}
+	if in.Key == "" {
+		return errors.New(errMissingKey)
+	}
+	return nil
+}
+
+func generateObservation(in *storage.Group) v1alpha1.GroupObservation {
+	return v1alpha1.GroupObservation{
+		ID: in.GetProps().GetId(),
+	}
+}
+
+func generateGroup(in *v1alpha1.GroupParameters, base *storage.Group) *storage.Group {
+	if base == nil {
+		base = &storage.Group{}
+	}
+	if base.Props == nil {
+		base.Props = &storage.GroupProperties{}
+	}
+	base.Props.AuthProviderId = in.AuthProviderID
+	base.Props.Key = in.Key
+	base.Props.Value = in.Value
+	base.RoleName = in.RoleName
+	return base
+}
+
+// generateGroupParameters converts an observed group into parameters.
+// References and selectors only exist in the spec, so they are taken from the
+// supplied spec.
+func generateGroupParameters(in *storage.Group, spec *v1alpha1.GroupParameters) v1alpha1.GroupParameters {
+	return v1alpha1.GroupParameters{
+		AuthProviderID:         in.GetProps().GetAuthProviderId(),
+		AuthProviderIDRef:      spec.AuthProviderIDRef,
+		AuthProviderIDSelector: spec.AuthProviderIDSelector,
+		DefaultForProvider:     in.GetProps().GetKey() == "" && in.GetProps().GetValue() == "",
+		Key:                    in.GetProps().GetKey(),
+		Value:                  in.GetProps().GetValue(),
+		RoleName:               in.GetRoleName(),
+		RoleNameRef:            spec.RoleNameRef,
+		RoleNameSelector:       spec.RoleNameSelector,
+	}
+}
+
+func isUpToDate(in *v1alpha1.Group, observed *storage.Group) (bool, string) {
+	observedParams := generateGroupParameters(observed, &in.Spec.ForProvider)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in group\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+// matches returns true if the group has the auth provider, key, value and
+// role described by the parameters.
+func matches(in *v1alpha1.GroupParameters, g *storage.Group) bool {
+	props := g.GetProps()
+	return props.GetAuthProviderId() == in.AuthProviderID && props.GetKey() == in.Key && props.GetValue() == in.Value &&
+		g.GetRoleName() == in.RoleName
+}
+
+// listGroups returns all groups keyed by their ID.
+func (c *external) listGroups(ctx context.Context) (map[string]*storage.Group, error) {
+	resp, err := c.svc.GetGroups(ctx, &v1.GetGroupsRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	groups := make(map[string]*storage.Group, len(resp.GetGroups()))
+	for _, it := range resp.GetGroups() {
+		groups[it.GetProps().GetId()] = it
+	}
+	return groups, nil
+}
+
+// getGroup returns the group with the supplied ID. Groups are not looked up
+// by their properties, so that mappings created by hand are only taken over
+// once they are imported by setting their ID as external name.
+func (c *external) getGroup(ctx context.Context, id string) (*storage.Group, error) {
+	if id == "" {
+		return nil, nil
+	}
+	groups, err := c.listGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return groups[id], nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Group)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotGroup)
+	}
+
+	group, err := c.getGroup(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if group == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(group)
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, group.GetProps().GetId())
+	upToDate, diff := isUpToDate(cr, group)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Group)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotGroup)
+	}
+	if err := validate(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	// Central does not return the created group, so it is looked up to
+	// record its ID. Groups that existed before may have the same
+	// properties and are not the created group.
+	existing, err := c.listGroups(ctx)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	if _, err := c.svc.CreateGroup(ctx, generateGroup(&cr.Spec.ForProvider, nil)); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	groups, err := c.listGroups(ctx)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	for id, it := range groups {
+		if _, ok := existing[id]; ok || !matches(&cr.Spec.ForProvider, it) {
+			continue
+		}
+		cr.Status.AtProvider = generateObservation(it)
+		meta.SetExternalName(cr, id)
+		break
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Group)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotGroup)
+	}
+	if err := validate(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	group, err := c.getGroup(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if group == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	_, err = c.svc.UpdateGroup(ctx, &v1.UpdateGroupRequest{Group: generateGroup(&cr.Spec.ForProvider, group)})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Group)
+	if !ok {
+		return errors.New(errNotGroup)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	group, err := c.getGroup(ctx, meta.GetExternalName(cr))
+	if err != nil || group == nil {
+		return errors.Wrap(err, errDeleteFailed)
+	}
+
+	props := group.GetProps()
+	_, err = c.svc.DeleteGroup(ctx, &v1.DeleteGroupRequest{
+		Id:             props.GetId(),
+		AuthProviderId: props.GetAuthProviderId(),
+		Key:            props.GetKey(),
+		Value:          props.GetValue(),
+	})
+	return errors.Wrap(err, errDeleteFailed)
+}
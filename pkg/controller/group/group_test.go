@@ -0,0 +1,316 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package group
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/group/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	admins := v1alpha1.GroupParameters{
+		AuthProviderID:    "ap-1",
+		AuthProviderIDRef: &xpv1.Reference{Name: "sso"},
+		Key:               "groups",
+		Value:             "admins",
+		RoleName:          "Admin",
+	}
+	def := v1alpha1.GroupParameters{
+		AuthProviderID:     "ap-1",
+		DefaultForProvider: true,
+		RoleName:           "None",
+	}
+
+	cases := map[string]struct {
+		reason   string
+		spec     v1alpha1.GroupParameters
+		observed *storage.Group
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "References that only exist in the spec should not cause drift.",
+			spec:     admins,
+			observed: &storage.Group{Props: &storage.GroupProperties{Id: "g-1", AuthProviderId: "ap-1", Key: "groups", Value: "admins"}, RoleName: "Admin"},
+			want:     true,
+		},
+		"DefaultGroup": {
+			reason:   "A group with empty key and value should be the default group.",
+			spec:     def,
+			observed: &storage.Group{Props: &storage.GroupProperties{Id: "g-2", AuthProviderId: "ap-1"}, RoleName: "None"},
+			want:     true,
+		},
+		"DefaultRoleChanged": {
+			reason:   "A different role of the default group should cause drift.",
+			spec:     def,
+			observed: &storage.Group{Props: &storage.GroupProperties{Id: "g-2", AuthProviderId: "ap-1"}, RoleName: "Analyst"},
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Group{Spec: v1alpha1.GroupSpec{ForProvider: tc.spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.GroupParameters
+		group  *storage.Group
+		want   bool
+	}{
+		"Default": {
+			reason: "The default group with the same role should match.",
+			spec:   v1alpha1.GroupParameters{AuthProviderID: "ap-1", DefaultForProvider: true, RoleName: "None"},
+			group:  &storage.Group{Props: &storage.GroupProperties{AuthProviderId: "ap-1"}, RoleName: "None"},
+			want:   true,
+		},
+		"OtherRole": {
+			reason: "A group with the same properties but another role should not match.",
+			spec:   v1alpha1.GroupParameters{AuthProviderID: "ap-1", Key: "groups", Value: "admins", RoleName: "Admin"},
+			group:  &storage.Group{Props: &storage.GroupProperties{AuthProviderId: "ap-1", Key: "groups", Value: "admins"}, RoleName: "Analyst"},
+			want:   false,
+		},
+		"OtherValue": {
+			reason: "A group with another attribute value should not match.",
+			spec:   v1alpha1.GroupParameters{AuthProviderID: "ap-1", Key: "groups", Value: "admins", RoleName: "Admin"},
+			group:  &storage.Group{Props: &storage.GroupProperties{AuthProviderId: "ap-1", Key: "groups", Value: "analysts"}, RoleName: "Admin"},
+			want:   false,
+		},
+		"OtherProvider": {
+			reason: "A group of another auth provider should not match.",
+			spec:   v1alpha1.GroupParameters{AuthProviderID: "ap-1", DefaultForProvider: true},
+			group:  &storage.Group{Props: &storage.GroupProperties{AuthProviderId: "ap-2"}},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := matches(&tc.spec, tc.group)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nmatches(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type fakeGroupService struct {
+	v1.GroupServiceClient
+
+	MockGetGroups   func(ctx context.Context, in *v1.GetGroupsRequest, opts ...grpc.CallOption) (*v1.GetGroupsResponse, error)
+	MockCreateGroup func(ctx context.Context, in *storage.Group, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockUpdateGroup func(ctx context.Context, in *v1.UpdateGroupRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeleteGroup func(ctx context.Context, in *v1.DeleteGroupRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeGroupService) GetGroups(ctx context.Context, in *v1.GetGroupsRequest, opts ...grpc.CallOption) (*v1.GetGroupsResponse, error) {
+	return f.MockGetGroups(ctx, in, opts...)
+}
+
+func (f *fakeGroupService) CreateGroup(ctx context.Context, in *storage.Group, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockCreateGroup(ctx, in, opts...)
+}
+
+func (f *fakeGroupService) UpdateGroup(ctx context.Context, in *v1.UpdateGroupRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockUpdateGroup(ctx, in, opts...)
+}
+
+func (f *fakeGroupService) DeleteGroup(ctx context.Context, in *v1.DeleteGroupRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteGroup(ctx, in, opts...)
+}
+
+func group(m ...func(*v1alpha1.Group)) *v1alpha1.Group {
+	cr := &v1alpha1.Group{Spec: v1alpha1.GroupSpec{ForProvider: v1alpha1.GroupParameters{
+		AuthProviderID: "ap-1",
+		Key:            "groups",
+		Value:          "admins",
+		RoleName:       "Admin",
+	}}}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func withExternalName(id string) func(*v1alpha1.Group) {
+	return func(cr *v1alpha1.Group) { meta.SetExternalName(cr, id) }
+}
+
+func withGroups(g ...*storage.Group) *fakeGroupService {
+	return &fakeGroupService{
+		MockGetGroups: func(_ context.Context, _ *v1.GetGroupsRequest, _ ...grpc.CallOption) (*v1.GetGroupsResponse, error) {
+			return &v1.GetGroupsResponse{Groups: g}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	admins := &storage.Group{Props: &storage.GroupProperties{Id: "g-1", AuthProviderId: "ap-1", Key: "groups", Value: "admins"}, RoleName: "Admin"}
+	analysts := &storage.Group{Props: &storage.GroupProperties{Id: "g-2", AuthProviderId: "ap-1", Key: "groups", Value: "admins"}, RoleName: "Analyst"}
+
+	type want struct {
+		o   managed.ExternalObservation
+		id  string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeGroupService
+		cr     *v1alpha1.Group
+		want   want
+	}{
+		"GetFailed": {
+			reason: "Errors listing groups should be returned.",
+			svc: &fakeGroupService{
+				MockGetGroups: func(_ context.Context, _ *v1.GetGroupsRequest, _ ...grpc.CallOption) (*v1.GetGroupsResponse, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   group(),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"ByExternalName": {
+			reason: "A group should be looked up by the ID in its external name, so that a role change is detected as drift.",
+			svc:    withGroups(admins, analysts),
+			cr:     group(withExternalName("g-2")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, id: "g-2"},
+		},
+		"ExternalNameGone": {
+			reason: "A group whose ID no longer exists should be reported as not existing.",
+			svc:    withGroups(admins),
+			cr:     group(withExternalName("g-3")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NoExternalName": {
+			reason: "Without external name, a group with matching properties should not be adopted.",
+			svc:    withGroups(analysts, admins),
+			cr:     group(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, tc.cr.Status.AtProvider.ID); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	// A group with the same properties that was created by hand must not be
+	// recorded as the created group.
+	groups := []*storage.Group{{Props: &storage.GroupProperties{Id: "g-9", AuthProviderId: "ap-1", Key: "groups", Value: "admins"}, RoleName: "Admin"}}
+	svc := &fakeGroupService{
+		MockGetGroups: func(_ context.Context, _ *v1.GetGroupsRequest, _ ...grpc.CallOption) (*v1.GetGroupsResponse, error) {
+			return &v1.GetGroupsResponse{Groups: groups}, nil
+		},
+		MockCreateGroup: func(_ context.Context, in *storage.Group, _ ...grpc.CallOption) (*v1.Empty, error) {
+			in.Props.Id = "g-1"
+			groups = append(groups, in)
+			return &v1.Empty{}, nil
+		},
+	}
+	// The external name of a group that was deleted in Central must not
+	// prevent the recreated group from being found.
+	cr := group(withExternalName("g-0"))
+	e := external{svc: svc}
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	if got := meta.GetExternalName(cr); got != "g-1" {
+		t.Errorf("e.Create(...): want external name %q, got %q", "g-1", got)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var updated *storage.Group
+	svc := withGroups(&storage.Group{Props: &storage.GroupProperties{Id: "g-1", AuthProviderId: "ap-1"}, RoleName: "None"})
+	svc.MockUpdateGroup = func(_ context.Context, in *v1.UpdateGroupRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+		updated = in.GetGroup()
+		return &v1.Empty{}, nil
+	}
+	e := external{svc: svc}
+
+	cr := group(withExternalName("g-1"), func(cr *v1alpha1.Group) {
+		cr.Spec.ForProvider = v1alpha1.GroupParameters{AuthProviderID: "ap-1", DefaultForProvider: true, RoleName: "Analyst"}
+	})
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if updated.GetProps().GetId() != "g-1" || updated.GetRoleName() != "Analyst" {
+		t.Errorf("e.Update(...): want default group %q switched to role %q in place, got %v", "g-1", "Analyst", updated)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var deleted *v1.DeleteGroupRequest
+	svc := withGroups(&storage.Group{Props: &storage.GroupProperties{Id: "g-1", AuthProviderId: "ap-1", Key: "groups", Value: "admins"}, RoleName: "Admin"})
+	svc.MockDeleteGroup = func(_ context.Context, in *v1.DeleteGroupRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+		deleted = in
+		return &v1.Empty{}, nil
+	}
+	e := external{svc: svc}
+
+	if err := e.Delete(context.Background(), group(withExternalName("g-1"))); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if deleted.GetId() != "g-1" {
+		t.Errorf("e.Delete(...): want group %q deleted, got %v", "g-1", deleted)
+	}
+}
@@ -0,0 +1,474 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageintegration
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/imageintegration/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotImageIntegration = "managed resource is not a ImageIntegration custom resource"
+	errTrackPCUsage        = "cannot track ProviderConfig usage"
+	errGetPC               = "cannot get ProviderConfig"
+	errGetCreds            = "cannot get credentials"
+	errGetSecret           = "cannot get image integration secret"
+	errNoConfig            = "exactly one image integration config must be set"
+	errTestFailed          = "cannot test image integration"
+	errGetFailed           = "cannot get image integration"
+	errObserveFailed       = "cannot observe image integration"
+	errCreateFailed        = "cannot create image integration"
+	errUpdateFailed        = "cannot update image integration"
+	errDeleteFailed        = "cannot delete image integration"
+)
+
+// Image integration types as registered in Central.
+const (
+	typeDocker      = "docker"
+	typeArtifactory = "artifactory"
+	typeNexus       = "nexus"
+	typeQuay        = "quay"
+	typeECR         = "ecr"
+)
+
+// Setup adds a controller that reconciles ImageIntegration managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ImageIntegrationGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ImageIntegrationGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ImageIntegration{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ImageIntegration)
+	if !ok {
+		return nil, errors.New(errNotImageIntegration)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{kube: c.kube, svc: v1.NewImageIntegrationServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube client.Client
+	svc  v1.ImageIntegrationServiceClient
+}
+
+func (c *external) getSecret(ctx context.Context, ref *xpv1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	v, err := resource.ExtractSecret(ctx, c.kube, xpv1.CommonCredentialSelectors{SecretRef: ref})
+	return string(v), errors.Wrap(err, errGetSecret)
+}
+
+// setSecrets resolves the secret references of the spec and writes their
+// values into the image integration config.
+func (c *external) setSecrets(ctx context.Context, in *v1alpha1.ImageIntegrationParameters, ii *storage.ImageIntegration) error {
+	var err error
+	switch {
+	case dockerConfig(in) != nil:
+		ii.GetDocker().Password, err = c.getSecret(ctx, dockerConfig(in).PasswordSecretRef)
+	case in.Quay != nil:
+		if ii.GetQuay().OauthToken, err = c.getSecret(ctx, in.Quay.OAuthTokenSecretRef); err != nil {
+			return err
+		}
+		if in.Quay.RobotAccount != nil {
+			ii.GetQuay().RegistryRobotCredentials.Password, err = c.getSecret(ctx, &in.Quay.RobotAccount.PasswordSecretRef)
+		}
+	case in.ECR != nil:
+		if ii.GetEcr().AccessKeyId, err = c.getSecret(ctx, in.ECR.AccessKeyIDSecretRef); err != nil {
+			return err
+		}
+		ii.GetEcr().SecretAccessKey, err = c.getSecret(ctx, in.ECR.SecretAccessKeySecretRef)
+	}
+	return err
+}
+
+// dockerConfig returns the config of the Docker compatible integration types.
+func dockerConfig(in *v1alpha1.ImageIntegrationParameters) *v1alpha1.DockerConfig {
+	switch {
+	case in.Docker != nil:
+		return in.Docker
+	case in.Artifactory != nil:
+		return in.Artifactory
+	case in.Nexus != nil:
+		return in.Nexus
+	}
+	return nil
+}
+
+func integrationType(in *v1alpha1.ImageIntegrationParameters) string {
+	configs := map[string]bool{
+		typeDocker:      in.Docker != nil,
+		typeArtifactory: in.Artifactory != nil,
+		typeNexus:       in.Nexus != nil,
+		typeQuay:        in.Quay != nil,
+		typeECR:         in.ECR != nil,
+	}
+	t := ""
+	for k, set := range configs {
+		if !set {
+			continue
+		}
+		if t != "" {
+			return ""
+		}
+		t = k
+	}
+	return t
+}
+
+func generateObservation(in *storage.ImageIntegration) v1alpha1.ImageIntegrationObservation {
+	return v1alpha1.ImageIntegrationObservation{
+		ID:            in.GetId(),
+		Name:          in.GetName(),
+		Type:          in.GetType(),
+		Autogenerated: in.GetAutogenerated(),
+		ClusterID:     in.GetClusterId(),
+	}
+}
+
+func generateConfig(in *v1alpha1.ImageIntegrationParameters, base *storage.ImageIntegration) {
+	switch {
+	case dockerConfig(in) != nil:
+		d := dockerConfig(in)
+		base.IntegrationConfig = &storage.ImageIntegration_Docker{Docker: &storage.DockerConfig{
+			Endpoint: d.Endpoint,
+			Username: d.Username,
+			Insecure: d.Insecure,
+		}}
+	case in.Quay != nil:
+		quay := &storage.QuayConfig{
+			Endpoint: in.Quay.Endpoint,
+			Insecure: in.Quay.Insecure,
+		}
+		if in.Quay.RobotAccount != nil {
+			quay.RegistryRobotCredentials = &storage.QuayConfig_RobotAccount{Username: in.Quay.RobotAccount.Username}
+		}
+		base.IntegrationConfig = &storage.ImageIntegration_Quay{Quay: quay}
+	case in.ECR != nil:
+		base.IntegrationConfig = &storage.ImageIntegration_Ecr{Ecr: &storage.ECRConfig{
+			RegistryId:           in.ECR.RegistryID,
+			Region:               in.ECR.Region,
+			Endpoint:             in.ECR.Endpoint,
+			UseIam:               in.ECR.UseIAM,
+			UseAssumeRole:        in.ECR.UseAssumeRole,
+			AssumeRoleId:         in.ECR.AssumeRoleID,
+			AssumeRoleExternalId: in.ECR.AssumeRoleExternalID,
+		}}
+	}
+}
+
+func generateImageIntegration(in *v1alpha1.ImageIntegrationParameters, base *storage.ImageIntegration) *storage.ImageIntegration {
+	if base == nil {
+		base = &storage.ImageIntegration{}
+	}
+	base.Name = in.Name
+	base.Type = integrationType(in)
+	base.SkipTestIntegration = in.SkipTestIntegration
+	base.Categories = nil
+	for _, it := range in.Categories {
+		base.Categories = append(base.Categories, storage.ImageIntegrationCategory(storage.ImageIntegrationCategory_value[string(it)]))
+	}
+	generateConfig(in, base)
+	return base
+}
+
+func generateDockerParameters(in *storage.DockerConfig, spec *v1alpha1.DockerConfig) *v1alpha1.DockerConfig {
+	out := &v1alpha1.DockerConfig{
+		Endpoint: in.GetEndpoint(),
+		Username: in.GetUsername(),
+		Insecure: in.GetInsecure(),
+	}
+	if spec != nil {
+		out.PasswordSecretRef = spec.PasswordSecretRef
+	}
+	return out
+}
+
+func generateQuayParameters(in *storage.QuayConfig, spec *v1alpha1.QuayConfig) *v1alpha1.QuayConfig {
+	out := &v1alpha1.QuayConfig{
+		Endpoint: in.GetEndpoint(),
+		Insecure: in.GetInsecure(),
+	}
+	if r := in.GetRegistryRobotCredentials(); r != nil {
+		out.RobotAccount = &v1alpha1.QuayRobotAccount{Username: r.GetUsername()}
+	}
+	if spec != nil {
+		out.OAuthTokenSecretRef = spec.OAuthTokenSecretRef
+		if out.RobotAccount != nil && spec.RobotAccount != nil {
+			out.RobotAccount.PasswordSecretRef = spec.RobotAccount.PasswordSecretRef
+		}
+	}
+	return out
+}
+
+func generateECRParameters(in *storage.ECRConfig, spec *v1alpha1.ECRConfig) *v1alpha1.ECRConfig {
+	out := &v1alpha1.ECRConfig{
+		RegistryID:           in.GetRegistryId(),
+		Region:               in.GetRegion(),
+		Endpoint:             in.GetEndpoint(),
+		UseIAM:               in.GetUseIam(),
+		UseAssumeRole:        in.GetUseAssumeRole(),
+		AssumeRoleID:         in.GetAssumeRoleId(),
+		AssumeRoleExternalID: in.GetAssumeRoleExternalId(),
+	}
+	if spec != nil {
+		out.AccessKeyIDSecretRef = spec.AccessKeyIDSecretRef
+		out.SecretAccessKeySecretRef = spec.SecretAccessKeySecretRef
+		// Central fills in the regional endpoint if none is given.
+		if spec.Endpoint == "" {
+			out.Endpoint = ""
+		}
+	}
+	return out
+}
+
+// generateImageIntegrationParameters converts an observed image integration
+// into parameters. Central masks credentials in its responses, so the secret
+// references are taken from the supplied spec instead.
+func generateImageIntegrationParameters(in *storage.ImageIntegration, spec *v1alpha1.ImageIntegrationParameters) v1alpha1.ImageIntegrationParameters {
+	out := v1alpha1.ImageIntegrationParameters{
+		Name:                in.GetName(),
+		SkipTestIntegration: in.GetSkipTestIntegration(),
+	}
+	for _, it := range in.GetCategories() {
+		out.Categories = append(out.Categories, v1alpha1.Category(storage.ImageIntegrationCategory_name[int32(it)]))
+	}
+	switch in.GetType() {
+	case typeDocker:
+		out.Docker = generateDockerParameters(in.GetDocker(), spec.Docker)
+	case typeArtifactory:
+		out.Artifactory = generateDockerParameters(in.GetDocker(), spec.Artifactory)
+	case typeNexus:
+		out.Nexus = generateDockerParameters(in.GetDocker(), spec.Nexus)
+	case typeQuay:
+		out.Quay = generateQuayParameters(in.GetQuay(), spec.Quay)
+	case typeECR:
+		out.ECR = generateECRParameters(in.GetEcr(), spec.ECR)
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.ImageIntegration, observed *storage.ImageIntegration) (bool, string) {
+	observedParams := generateImageIntegrationParameters(observed, &in.Spec.ForProvider)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in image integration\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getImageIntegration(ctx context.Context, cr *v1alpha1.ImageIntegration) (*storage.ImageIntegration, error) {
+	resp, err := c.svc.GetImageIntegrations(ctx, &v1.GetImageIntegrationsRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetIntegrations() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			return it, nil
+		}
+	}
+	return nil, nil
+}
+
+// testedCondition returns the Tested condition of an integration that was
+// created.
+func testedCondition(in *v1alpha1.ImageIntegrationParameters) xpv1.Condition {
+	if in.SkipTestIntegration {
+		return apisv1alpha1.TestSkipped()
+	}
+	return apisv1alpha1.TestSucceeded()
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ImageIntegration)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotImageIntegration)
+	}
+
+	integration, err := c.getImageIntegration(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if integration == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(integration)
+	cr.SetConditions(xpv1.Available())
+	// The Tested condition set by Create is lost. An integration that was
+	// created by the provider passed its test unless the test was skipped,
+	// which also supersedes a failed test of an earlier Create.
+	if !meta.GetExternalCreateSucceeded(cr).IsZero() {
+		cr.SetConditions(testedCondition(&cr.Spec.ForProvider))
+	}
+	meta.SetExternalName(cr, integration.GetName())
+	upToDate, diff := isUpToDate(cr, integration)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ImageIntegration)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotImageIntegration)
+	}
+	if integrationType(&cr.Spec.ForProvider) == "" {
+		return managed.ExternalCreation{}, errors.New(errNoConfig)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	req := generateImageIntegration(&cr.Spec.ForProvider, nil)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, req); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	if !cr.Spec.ForProvider.SkipTestIntegration {
+		if _, err := c.svc.TestImageIntegration(ctx, req); err != nil {
+			cr.SetConditions(apisv1alpha1.TestFailed(err))
+			return managed.ExternalCreation{}, errors.Wrap(err, errTestFailed)
+		}
+	}
+	cr.SetConditions(testedCondition(&cr.Spec.ForProvider))
+
+	resp, err := c.svc.PostImageIntegration(ctx, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	cr.Status.AtProvider = generateObservation(resp)
+	meta.SetExternalName(cr, resp.GetName())
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ImageIntegration)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotImageIntegration)
+	}
+	if integrationType(&cr.Spec.ForProvider) == "" {
+		return managed.ExternalUpdate{}, errors.New(errNoConfig)
+	}
+
+	integration, err := c.getImageIntegration(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if integration == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	req := generateImageIntegration(&cr.Spec.ForProvider, integration)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, req); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+
+	_, err = c.svc.UpdateImageIntegration(ctx, &v1.UpdateImageIntegrationRequest{Config: req, UpdatePassword: true})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ImageIntegration)
+	if !ok {
+		return errors.New(errNotImageIntegration)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeleteImageIntegration(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
@@ -0,0 +1,385 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageintegration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/imageintegration/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	nexus := v1alpha1.ImageIntegrationParameters{
+		Name:       "nexus",
+		Categories: []v1alpha1.Category{"REGISTRY"},
+		Nexus: &v1alpha1.DockerConfig{
+			Endpoint:          "nexus.example.com",
+			Username:          "stackrox",
+			PasswordSecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "nexus", Namespace: "crossplane-system"}, Key: "password"},
+		},
+	}
+	ecr := v1alpha1.ImageIntegrationParameters{
+		Name:       "ecr",
+		Categories: []v1alpha1.Category{"REGISTRY"},
+		ECR:        &v1alpha1.ECRConfig{RegistryID: "123456789012", Region: "us-east-1", UseIAM: true},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		spec     v1alpha1.ImageIntegrationParameters
+		observed *storage.ImageIntegration
+		want     bool
+	}{
+		"MaskedPasswordIgnored": {
+			reason: "A masked password returned by Central should not cause drift.",
+			spec:   nexus,
+			observed: &storage.ImageIntegration{Name: "nexus", Type: "nexus", Categories: []storage.ImageIntegrationCategory{storage.ImageIntegrationCategory_REGISTRY},
+				IntegrationConfig: &storage.ImageIntegration_Docker{Docker: &storage.DockerConfig{Endpoint: "nexus.example.com", Username: "stackrox", Password: "******"}}},
+			want: true,
+		},
+		"TypeChanged": {
+			reason: "A Docker integration of another type should cause drift.",
+			spec:   nexus,
+			observed: &storage.ImageIntegration{Name: "nexus", Type: "artifactory", Categories: []storage.ImageIntegrationCategory{storage.ImageIntegrationCategory_REGISTRY},
+				IntegrationConfig: &storage.ImageIntegration_Docker{Docker: &storage.DockerConfig{Endpoint: "nexus.example.com", Username: "stackrox"}}},
+			want: false,
+		},
+		"ECRDefaultEndpoint": {
+			reason: "The ECR endpoint populated by Central should not cause drift if none is specified.",
+			spec:   ecr,
+			observed: &storage.ImageIntegration{Name: "ecr", Type: "ecr", Categories: []storage.ImageIntegrationCategory{storage.ImageIntegrationCategory_REGISTRY},
+				IntegrationConfig: &storage.ImageIntegration_Ecr{Ecr: &storage.ECRConfig{RegistryId: "123456789012", Region: "us-east-1", UseIam: true, Endpoint: "123456789012.dkr.ecr.us-east-1.amazonaws.com"}}},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.ImageIntegration{Spec: v1alpha1.ImageIntegrationSpec{ForProvider: tc.spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+type fakeImageIntegrationService struct {
+	v1.ImageIntegrationServiceClient
+
+	MockGetImageIntegrations   func(ctx context.Context, in *v1.GetImageIntegrationsRequest, opts ...grpc.CallOption) (*v1.GetImageIntegrationsResponse, error)
+	MockPostImageIntegration   func(ctx context.Context, in *storage.ImageIntegration, opts ...grpc.CallOption) (*storage.ImageIntegration, error)
+	MockTestImageIntegration   func(ctx context.Context, in *storage.ImageIntegration, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockUpdateImageIntegration func(ctx context.Context, in *v1.UpdateImageIntegrationRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeleteImageIntegration func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeImageIntegrationService) GetImageIntegrations(ctx context.Context, in *v1.GetImageIntegrationsRequest, opts ...grpc.CallOption) (*v1.GetImageIntegrationsResponse, error) {
+	return f.MockGetImageIntegrations(ctx, in, opts...)
+}
+
+func (f *fakeImageIntegrationService) PostImageIntegration(ctx context.Context, in *storage.ImageIntegration, opts ...grpc.CallOption) (*storage.ImageIntegration, error) {
+	return f.MockPostImageIntegration(ctx, in, opts...)
+}
+
+func (f *fakeImageIntegrationService) TestImageIntegration(ctx context.Context, in *storage.ImageIntegration, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockTestImageIntegration(ctx, in, opts...)
+}
+
+func (f *fakeImageIntegrationService) UpdateImageIntegration(ctx context.Context, in *v1.UpdateImageIntegrationRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockUpdateImageIntegration(ctx, in, opts...)
+}
+
+func (f *fakeImageIntegrationService) DeleteImageIntegration(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteImageIntegration(ctx, in, opts...)
+}
+
+func ecrIntegration(m ...func(*v1alpha1.ImageIntegrationParameters)) *v1alpha1.ImageIntegration {
+	cr := &v1alpha1.ImageIntegration{Spec: v1alpha1.ImageIntegrationSpec{ForProvider: v1alpha1.ImageIntegrationParameters{
+		Name:       "ecr",
+		Categories: []v1alpha1.Category{"REGISTRY"},
+		ECR:        &v1alpha1.ECRConfig{RegistryID: "123456789012", Region: "us-east-1", UseIAM: true},
+	}}}
+	for _, f := range m {
+		f(&cr.Spec.ForProvider)
+	}
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o      managed.ExternalObservation
+		tested xpv1.Condition
+		err    error
+	}
+
+	withIntegration := &fakeImageIntegrationService{
+		MockGetImageIntegrations: func(_ context.Context, _ *v1.GetImageIntegrationsRequest, _ ...grpc.CallOption) (*v1.GetImageIntegrationsResponse, error) {
+			ii := generateImageIntegration(&ecrIntegration().Spec.ForProvider, &storage.ImageIntegration{Id: "id"})
+			return &v1.GetImageIntegrationsResponse{Integrations: []*storage.ImageIntegration{ii}}, nil
+		},
+	}
+	created := func(m ...func(*v1alpha1.ImageIntegrationParameters)) *v1alpha1.ImageIntegration {
+		cr := ecrIntegration(m...)
+		cr.SetConditions(apisv1alpha1.TestFailed(errBoom))
+		meta.SetExternalCreateSucceeded(cr, time.Now())
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeImageIntegrationService
+		mg     resource.Managed
+		want   want
+	}{
+		"NotImageIntegration": {
+			reason: "An error should be returned if the managed resource is not an ImageIntegration.",
+			svc:    &fakeImageIntegrationService{},
+			want:   want{err: errors.New(errNotImageIntegration)},
+		},
+		"GetFailed": {
+			reason: "Errors listing image integrations should be returned.",
+			svc: &fakeImageIntegrationService{
+				MockGetImageIntegrations: func(_ context.Context, _ *v1.GetImageIntegrationsRequest, _ ...grpc.CallOption) (*v1.GetImageIntegrationsResponse, error) {
+					return nil, errBoom
+				},
+			},
+			mg:   ecrIntegration(),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "An image integration that does not exist in Central should be reported as not existing.",
+			svc: &fakeImageIntegrationService{
+				MockGetImageIntegrations: func(_ context.Context, _ *v1.GetImageIntegrationsRequest, _ ...grpc.CallOption) (*v1.GetImageIntegrationsResponse, error) {
+					return &v1.GetImageIntegrationsResponse{}, nil
+				},
+			},
+			mg:   ecrIntegration(),
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "An image integration matching the spec should be reported as up to date.",
+			svc: &fakeImageIntegrationService{
+				MockGetImageIntegrations: func(_ context.Context, _ *v1.GetImageIntegrationsRequest, _ ...grpc.CallOption) (*v1.GetImageIntegrationsResponse, error) {
+					ii := generateImageIntegration(&ecrIntegration().Spec.ForProvider, &storage.ImageIntegration{Id: "id"})
+					return &v1.GetImageIntegrationsResponse{Integrations: []*storage.ImageIntegration{ii}}, nil
+				},
+			},
+			mg: ecrIntegration(),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				tested: xpv1.Condition{Type: apisv1alpha1.TypeTested, Status: corev1.ConditionUnknown},
+			},
+		},
+		"Created": {
+			reason: "An integration created by the provider should have passed its test, superseding an earlier failed test.",
+			svc:    withIntegration,
+			mg:     created(),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				tested: apisv1alpha1.TestSucceeded(),
+			},
+		},
+		"CreatedWithoutTest": {
+			reason: "An integration created without a test should report the skipped test, superseding an earlier failed test.",
+			svc:    withIntegration,
+			mg:     created(func(p *v1alpha1.ImageIntegrationParameters) { p.SkipTestIntegration = true }),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				tested: apisv1alpha1.TestSkipped(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			cr, ok := tc.mg.(*v1alpha1.ImageIntegration)
+			if !ok || !got.ResourceExists {
+				return
+			}
+			if diff := cmp.Diff(tc.want.tested, cr.GetCondition(apisv1alpha1.TypeTested), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want tested condition, +got tested condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		id     string
+		tested xpv1.Condition
+		tests  int
+		posts  int
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		cr      *v1alpha1.ImageIntegration
+		testErr error
+		want    want
+	}{
+		"TestFailed": {
+			reason:  "An integration that fails the test should not be created and should report the failed test.",
+			cr:      ecrIntegration(),
+			testErr: errBoom,
+			want: want{
+				tested: apisv1alpha1.TestFailed(errBoom),
+				tests:  1,
+				err:    errors.Wrap(errBoom, errTestFailed),
+			},
+		},
+		"TestSucceeded": {
+			reason: "An integration that passes the test should be created and should report the successful test.",
+			cr:     ecrIntegration(),
+			want: want{
+				id:     "id",
+				tested: apisv1alpha1.TestSucceeded(),
+				tests:  1,
+				posts:  1,
+			},
+		},
+		"SkipTestIntegration": {
+			reason: "An integration that skips the test should be created without testing it.",
+			cr:     ecrIntegration(func(p *v1alpha1.ImageIntegrationParameters) { p.SkipTestIntegration = true }),
+			want: want{
+				id:     "id",
+				tested: apisv1alpha1.TestSkipped(),
+				posts:  1,
+			},
+		},
+		"NoConfig": {
+			reason: "An integration without config should be rejected.",
+			cr:     ecrIntegration(func(p *v1alpha1.ImageIntegrationParameters) { p.ECR = nil }),
+			want: want{
+				tested: xpv1.Condition{Type: apisv1alpha1.TypeTested, Status: corev1.ConditionUnknown},
+				err:    errors.New(errNoConfig),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tests, posts := 0, 0
+			e := external{svc: &fakeImageIntegrationService{
+				MockTestImageIntegration: func(_ context.Context, _ *storage.ImageIntegration, _ ...grpc.CallOption) (*v1.Empty, error) {
+					tests++
+					return &v1.Empty{}, tc.testErr
+				},
+				MockPostImageIntegration: func(_ context.Context, in *storage.ImageIntegration, _ ...grpc.CallOption) (*storage.ImageIntegration, error) {
+					posts++
+					out := *in
+					out.Id = "id"
+					return &out, nil
+				},
+			}}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, tc.cr.Status.AtProvider.ID); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.tested, tc.cr.GetCondition(apisv1alpha1.TypeTested), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want tested condition, +got tested condition:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.tests, tests); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want tests, +got tests:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.posts, posts); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want posts, +got posts:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var updated *v1.UpdateImageIntegrationRequest
+	e := external{svc: &fakeImageIntegrationService{
+		MockGetImageIntegrations: func(_ context.Context, _ *v1.GetImageIntegrationsRequest, _ ...grpc.CallOption) (*v1.GetImageIntegrationsResponse, error) {
+			ii := generateImageIntegration(&ecrIntegration().Spec.ForProvider, &storage.ImageIntegration{Id: "id"})
+			ii.GetEcr().Region = "eu-west-1"
+			return &v1.GetImageIntegrationsResponse{Integrations: []*storage.ImageIntegration{ii}}, nil
+		},
+		MockUpdateImageIntegration: func(_ context.Context, in *v1.UpdateImageIntegrationRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+			updated = in
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if _, err := e.Update(context.Background(), ecrIntegration()); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if got := updated.GetConfig(); got.GetId() != "id" || got.GetEcr().GetRegion() != "us-east-1" {
+		t.Errorf("e.Update(...): want integration %q in region %q, got %v", "id", "us-east-1", got)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var deleted string
+	cr := ecrIntegration()
+	cr.Status.AtProvider.ID = "id"
+	e := external{svc: &fakeImageIntegrationService{
+		MockDeleteImageIntegration: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+			deleted = in.GetId()
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if deleted != "id" {
+		t.Errorf("e.Delete(...): want image integration %q deleted, got %q", "id", deleted)
+	}
+}
@@ -24,7 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	v1 "github.com/stackrox/rox/generated/api/v1"
-	"google.golang.org/grpc"
+	"github.com/stackrox/rox/pkg/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -43,6 +43,7 @@ import (
 	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
 	"github.com/stehessel/provider-stackrox/pkg/clients/central"
 	"github.com/stehessel/provider-stackrox/pkg/features"
+	"github.com/stehessel/provider-stackrox/pkg/management"
 )
 
 const (
@@ -66,12 +67,19 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	conn := managed.NewNopDisconnecter(&connector{
+		kube:  mgr.GetClient(),
+		usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+		pool:  central.Connections,
+	})
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		conn = management.NewConnectDisconnecter(conn)
+	}
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.InitBundleGroupVersionKind),
-		managed.WithExternalConnectDisconnecter(&connector{
-			kube:  mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-		}),
+		managed.WithExternalConnectDisconnecter(conn),
+		managed.WithInitializers(),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...))
@@ -86,9 +94,9 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube     client.Client
-	usage    resource.Tracker
-	external *external
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
 }
 
 // Connect typically produces an ExternalClient by:
@@ -118,32 +126,17 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 	stringToken := string(token)
 
-	client, err := central.NewGRPC(ctx, pc.Spec.Endpoint, stringToken)
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
 	if err != nil {
 		return nil, errors.Wrap(err, central.ErrNewClient)
 	}
-	c.external = &external{client: client}
-	return c.external, nil
-}
-
-// Disconnect closes the connection of the external client.
-func (c *connector) Disconnect(ctx context.Context) error {
-	err := c.external.close()
-	return errors.Wrap(err, central.ErrCloseClient)
+	return &external{svc: v1.NewClusterInitServiceClient(client)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	client *grpc.ClientConn
-}
-
-func (c *external) close() error {
-	if c != nil && c.client != nil {
-		err := c.client.Close()
-		return errors.Wrap(err, central.ErrCloseClient)
-	}
-	return nil
+	svc v1.ClusterInitServiceClient
 }
 
 func generateObservation(in *v1.InitBundleMeta) v1alpha1.InitBundleObservation {
@@ -180,13 +173,34 @@ func isUpToDate(in *v1alpha1.InitBundle, observed *v1.InitBundleMeta) (bool, str
 	return true, ""
 }
 
+// lateInitialize fills the unset parameters of an imported init bundle from
+// the observed one.
+func lateInitialize(in *v1alpha1.InitBundleParameters, observed *v1.InitBundleMeta) bool {
+	if in.Name == "" {
+		in.Name = observed.GetName()
+		return true
+	}
+	return false
+}
+
+// getInitBundle returns the init bundle with the ID in the external name.
+// Without ID, e.g. before the init bundle was created or when the external
+// name still holds its name, it searches the init bundle by name.
 func (c *external) getInitBundle(ctx context.Context, cr *v1alpha1.InitBundle) (*v1.InitBundleMeta, error) {
-	svc := v1.NewClusterInitServiceClient(c.client)
-	resp, err := svc.GetInitBundles(ctx, &v1.Empty{})
+	resp, err := c.svc.GetInitBundles(ctx, &v1.Empty{})
 	if err != nil {
 		return nil, errors.Wrap(err, errGetFailed)
 	}
-	for _, it := range resp.Items {
+	id := meta.GetExternalName(cr)
+	if _, err := uuid.FromString(id); err == nil {
+		for _, it := range resp.GetItems() {
+			if it.GetId() == id {
+				return it, nil
+			}
+		}
+		return nil, nil
+	}
+	for _, it := range resp.GetItems() {
 		if it.GetName() == cr.Spec.ForProvider.Name {
 			return it, nil
 		}
@@ -210,13 +224,15 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	cr.Status.AtProvider = generateObservation(bundle)
 	cr.SetConditions(xpv1.Available())
-	meta.SetExternalName(cr, bundle.GetName())
+	meta.SetExternalName(cr, bundle.GetId())
+	lateInitialized := lateInitialize(&cr.Spec.ForProvider, bundle)
 	upToDate, diff := isUpToDate(cr, bundle)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: upToDate,
-		Diff:             diff,
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: lateInitialized,
+		Diff:                    diff,
 	}, nil
 }
 
@@ -227,16 +243,15 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 	cr.SetConditions(xpv1.Creating())
 
-	svc := v1.NewClusterInitServiceClient(c.client)
 	req := v1.InitBundleGenRequest{Name: cr.Spec.ForProvider.Name}
-	resp, err := svc.GenerateInitBundle(ctx, &req)
+	resp, err := c.svc.GenerateInitBundle(ctx, &req)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 	}
 
 	if m := resp.GetMeta(); m != nil {
 		cr.Status.AtProvider = generateObservation(m)
-		meta.SetExternalName(cr, m.GetName())
+		meta.SetExternalName(cr, m.GetId())
 	}
 	return managed.ExternalCreation{
 		ConnectionDetails: managed.ConnectionDetails{
@@ -267,8 +282,7 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	}
 	mg.SetConditions(xpv1.Deleting())
 
-	svc := v1.NewClusterInitServiceClient(c.client)
-	req := v1.InitBundleRevokeRequest{Ids: []string{cr.Status.AtProvider.ID}}
-	_, err := svc.RevokeInitBundle(ctx, &req)
+	req := v1.InitBundleRevokeRequest{Ids: []string{meta.GetExternalName(cr)}}
+	_, err := c.svc.RevokeInitBundle(ctx, &req)
 	return errors.Wrap(err, errDeleteFailed)
 }
@@ -21,11 +21,16 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
 	"google.golang.org/grpc"
 
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
-	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/initbundle/v1alpha1"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,36 +41,130 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+type fakeClusterInitService struct {
+	v1.ClusterInitServiceClient
+
+	MockGetInitBundles   func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.InitBundleMetasResponse, error)
+	MockRevokeInitBundle func(ctx context.Context, in *v1.InitBundleRevokeRequest, opts ...grpc.CallOption) (*v1.InitBundleRevokeResponse, error)
+}
+
+func (f *fakeClusterInitService) GetInitBundles(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.InitBundleMetasResponse, error) {
+	return f.MockGetInitBundles(ctx, in, opts...)
+}
+
+func (f *fakeClusterInitService) RevokeInitBundle(ctx context.Context, in *v1.InitBundleRevokeRequest, opts ...grpc.CallOption) (*v1.InitBundleRevokeResponse, error) {
+	return f.MockRevokeInitBundle(ctx, in, opts...)
+}
+
+const bundleID = "3f1c5f7e-1e0a-4d56-9a43-2b6e7c1d9f00"
+
+func withInitBundles(bundles ...*v1.InitBundleMeta) *fakeClusterInitService {
+	return &fakeClusterInitService{
+		MockGetInitBundles: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.InitBundleMetasResponse, error) {
+			return &v1.InitBundleMetasResponse{Items: bundles}, nil
+		},
 	}
+}
+
+func initBundle(name, externalName string) *v1alpha1.InitBundle {
+	cr := &v1alpha1.InitBundle{Spec: v1alpha1.InitBundleSpec{ForProvider: v1alpha1.InitBundleParameters{Name: name}}}
+	meta.SetExternalName(cr, externalName)
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	observed := &v1.InitBundleMeta{Id: bundleID, Name: "prod"}
 
 	type want struct {
-		o   managed.ExternalObservation
-		err error
+		o            managed.ExternalObservation
+		name         string
+		externalName string
+		err          error
 	}
 
 	cases := map[string]struct {
 		reason string
-		client *grpc.ClientConn
-		args   args
+		svc    *fakeClusterInitService
+		cr     *v1alpha1.InitBundle
 		want   want
 	}{
-		// TODO: Add test cases.
+		"GetFailed": {
+			reason: "Errors listing the init bundles should be returned.",
+			svc: &fakeClusterInitService{
+				MockGetInitBundles: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.InitBundleMetasResponse, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   initBundle("prod", ""),
+			want: want{name: "prod", err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "An init bundle that does not exist in Central should be reported as not existing.",
+			svc:    withInitBundles(),
+			cr:     initBundle("prod", ""),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}, name: "prod"},
+		},
+		"NotFoundByID": {
+			reason: "An init bundle whose ID is not known to Central should be reported as not existing.",
+			svc:    withInitBundles(&v1.InitBundleMeta{Id: "0f1c5f7e-1e0a-4d56-9a43-2b6e7c1d9f00", Name: "prod"}),
+			cr:     initBundle("prod", bundleID),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}, name: "prod", externalName: bundleID},
+		},
+		"ByName": {
+			reason: "An init bundle without ID should be found by name and get its ID as external name.",
+			svc:    withInitBundles(observed),
+			cr:     initBundle("prod", "prod"),
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				name:         "prod",
+				externalName: bundleID,
+			},
+		},
+		"Import": {
+			reason: "An init bundle imported by ID should late-initialize its name.",
+			svc:    withInitBundles(observed),
+			cr:     initBundle("", bundleID),
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				name:         "prod",
+				externalName: bundleID,
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.client}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.cr)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if diff := cmp.Diff(tc.want.name, tc.cr.Spec.ForProvider.Name); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want name, +got name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.cr)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
 		})
 	}
 }
+
+func TestDelete(t *testing.T) {
+	var got []string
+	e := external{svc: &fakeClusterInitService{
+		MockRevokeInitBundle: func(_ context.Context, in *v1.InitBundleRevokeRequest, _ ...grpc.CallOption) (*v1.InitBundleRevokeResponse, error) {
+			got = append(got, in.GetIds()...)
+			return &v1.InitBundleRevokeResponse{InitBundleRevokedIds: in.GetIds()}, nil
+		},
+	}}
+	if err := e.Delete(context.Background(), initBundle("prod", bundleID)); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{bundleID}, got); diff != "" {
+		t.Errorf("e.Delete(...): -want revoked IDs, +got revoked IDs:\n%s\n", diff)
+	}
+}
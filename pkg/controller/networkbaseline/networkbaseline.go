@@ -0,0 +1,436 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkbaseline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"github.com/stackrox/rox/pkg/networkgraph"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/networkbaseline/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotNetworkBaseline = "managed resource is not a NetworkBaseline custom resource"
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+	errGetPC              = "cannot get ProviderConfig"
+	errGetCreds           = "cannot get credentials"
+	errListDeployments    = "cannot list deployments"
+	errDeploymentNotFound = "cannot find deployment %s/%s in cluster %q"
+	errGetFailed          = "cannot get network baseline"
+	errObserveFailed      = "cannot observe network baseline"
+	errCreateFailed       = "cannot create network baseline"
+	errModifyPeersFailed  = "cannot modify peers of network baseline"
+	errLockFailed         = "cannot lock network baseline"
+	errUnlockFailed       = "cannot unlock network baseline"
+	errUpdateFailed       = "cannot update network baseline"
+	errDeleteFailed       = "cannot delete network baseline"
+)
+
+// Setup adds a controller that reconciles NetworkBaseline managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.NetworkBaselineGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.NetworkBaselineGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		// The baseline belongs to a deployment and is looked up by its name.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.NetworkBaseline{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.NetworkBaseline)
+	if !ok {
+		return nil, errors.New(errNotNetworkBaseline)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{
+		svc:         v1.NewNetworkBaselineServiceClient(client),
+		deployments: v1.NewDeploymentServiceClient(client),
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc         v1.NetworkBaselineServiceClient
+	deployments v1.DeploymentServiceClient
+}
+
+// peerKey identifies a peer connection independent of the ID of its entity.
+type peerKey struct {
+	Type      string
+	ID        string
+	Namespace string
+	Name      string
+	Port      int32
+	Protocol  string
+	Ingress   bool
+}
+
+func keyOf(in v1alpha1.Peer) peerKey {
+	return peerKey{
+		Type:      in.Entity.Type,
+		ID:        in.Entity.ID,
+		Namespace: in.Entity.Namespace,
+		Name:      in.Entity.Name,
+		Port:      in.Port,
+		Protocol:  in.Protocol,
+		Ingress:   in.Ingress,
+	}
+}
+
+// generatePeerEntity converts an observed entity into the form used in the
+// spec. Deployments are identified by namespace and name, external sources
+// by ID. The internet is a single entity.
+func generatePeerEntity(in *storage.NetworkEntityInfo) v1alpha1.PeerEntity {
+	out := v1alpha1.PeerEntity{Type: in.GetType().String()}
+	switch in.GetType() {
+	case storage.NetworkEntityInfo_DEPLOYMENT:
+		out.Namespace = in.GetDeployment().GetNamespace()
+		out.Name = in.GetDeployment().GetName()
+	case storage.NetworkEntityInfo_EXTERNAL_SOURCE:
+		out.ID = in.GetId()
+	}
+	return out
+}
+
+// observedPeers returns the connections of the baseline together with the IDs
+// of their entities.
+func observedPeers(in *storage.NetworkBaseline) ([]v1alpha1.Peer, map[peerKey]string) {
+	var out []v1alpha1.Peer
+	ids := map[peerKey]string{}
+	for _, p := range in.GetPeers() {
+		entity := generatePeerEntity(p.GetEntity().GetInfo())
+		for _, prop := range p.GetProperties() {
+			peer := v1alpha1.Peer{
+				Entity:   entity,
+				Port:     int32(prop.GetPort()),
+				Protocol: prop.GetProtocol().String(),
+				Ingress:  prop.GetIngress(),
+			}
+			out = append(out, peer)
+			ids[keyOf(peer)] = p.GetEntity().GetInfo().GetId()
+		}
+	}
+	return out, ids
+}
+
+func generateNetworkBaselineParameters(observed *storage.NetworkBaseline, spec *v1alpha1.NetworkBaselineParameters) *v1alpha1.NetworkBaselineParameters {
+	out := spec.DeepCopy()
+	out.Locked = observed.GetLocked()
+	if len(spec.Peers) > 0 {
+		out.Peers, _ = observedPeers(observed)
+	}
+	return out
+}
+
+func generateObservation(in *storage.NetworkBaseline) v1alpha1.NetworkBaselineObservation {
+	return v1alpha1.NetworkBaselineObservation{
+		DeploymentID:         in.GetDeploymentId(),
+		ClusterID:            in.GetClusterId(),
+		Locked:               in.GetLocked(),
+		ObservationPeriodEnd: central.ToTime(in.GetObservationPeriodEnd()),
+	}
+}
+
+func isUpToDate(in *v1alpha1.NetworkBaselineParameters, observed *storage.NetworkBaseline) (bool, string) {
+	observedParams := generateNetworkBaselineParameters(observed, in)
+	if diff := cmp.Diff(*in, *observedParams, cmpopts.EquateEmpty(), cmpopts.SortSlices(lessPeer)); diff != "" {
+		diff = "Observed difference in network baseline\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func lessPeer(a, b v1alpha1.Peer) bool {
+	return fmt.Sprint(keyOf(a)) < fmt.Sprint(keyOf(b))
+}
+
+// getDeployment looks up the deployment by cluster, namespace and name. It
+// returns nil if the deployment does not exist.
+func (c *external) getDeployment(ctx context.Context, cluster, namespace, name string) (*storage.ListDeployment, error) {
+	resp, err := c.deployments.ListDeployments(ctx, &v1.RawQuery{
+		Query: fmt.Sprintf("Cluster:%q+Namespace:%q+Deployment:%q", cluster, namespace, name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errListDeployments)
+	}
+	for _, d := range resp.GetDeployments() {
+		if d.GetCluster() == cluster && d.GetNamespace() == namespace && d.GetName() == name {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+// getNetworkBaseline returns the baseline of the deployment targeted by the
+// spec, or nil if the deployment does not exist.
+func (c *external) getNetworkBaseline(ctx context.Context, in *v1alpha1.NetworkBaselineParameters) (*storage.NetworkBaseline, error) {
+	d, err := c.getDeployment(ctx, in.ClusterName, in.Namespace, in.DeploymentName)
+	if err != nil || d == nil {
+		return nil, err
+	}
+	baseline, err := c.svc.GetNetworkBaseline(ctx, &v1.ResourceByID{Id: d.GetId()})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	return baseline, errors.Wrap(err, errGetFailed)
+}
+
+// peerEntityID returns the ID of the entity of a peer that is not part of the
+// baseline yet.
+func (c *external) peerEntityID(ctx context.Context, cluster string, in v1alpha1.PeerEntity) (string, error) {
+	switch in.Type {
+	case storage.NetworkEntityInfo_INTERNET.String():
+		return networkgraph.InternetExternalSourceID, nil
+	case storage.NetworkEntityInfo_DEPLOYMENT.String():
+		d, err := c.getDeployment(ctx, cluster, in.Namespace, in.Name)
+		if err != nil {
+			return "", err
+		}
+		if d == nil {
+			return "", errors.Errorf(errDeploymentNotFound, in.Namespace, in.Name, cluster)
+		}
+		return d.GetId(), nil
+	}
+	return in.ID, nil
+}
+
+// generatePeerStatuses returns the status changes that turn the observed peers
+// into the declared ones. Declared peers are added to the baseline, all other
+// observed peers are marked as anomalous.
+func (c *external) generatePeerStatuses(ctx context.Context, in *v1alpha1.NetworkBaselineParameters, observed *storage.NetworkBaseline) ([]*v1.NetworkBaselinePeerStatus, error) {
+	peers, ids := observedPeers(observed)
+	declared := map[peerKey]bool{}
+	var out []*v1.NetworkBaselinePeerStatus
+	for _, p := range in.Peers {
+		k := keyOf(p)
+		declared[k] = true
+		if _, ok := ids[k]; ok {
+			continue
+		}
+		id, err := c.peerEntityID(ctx, in.ClusterName, p.Entity)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, generatePeerStatus(p, id, v1.NetworkBaselinePeerStatus_BASELINE))
+	}
+	for _, p := range peers {
+		if k := keyOf(p); !declared[k] {
+			out = append(out, generatePeerStatus(p, ids[k], v1.NetworkBaselinePeerStatus_ANOMALOUS))
+		}
+	}
+	return out, nil
+}
+
+func generatePeerStatus(in v1alpha1.Peer, id string, s v1.NetworkBaselinePeerStatus_Status) *v1.NetworkBaselinePeerStatus {
+	return &v1.NetworkBaselinePeerStatus{
+		Peer: &v1.NetworkBaselineStatusPeer{
+			Entity: &v1.NetworkBaselinePeerEntity{
+				Id:   id,
+				Type: storage.NetworkEntityInfo_Type(storage.NetworkEntityInfo_Type_value[in.Entity.Type]),
+			},
+			Port:     uint32(in.Port),
+			Protocol: storage.L4Protocol(storage.L4Protocol_value[in.Protocol]),
+			Ingress:  in.Ingress,
+		},
+		Status: s,
+	}
+}
+
+// apply reconciles the peers and then the lock of the observed baseline.
+func (c *external) apply(ctx context.Context, in *v1alpha1.NetworkBaselineParameters, observed *storage.NetworkBaseline) error {
+	id := &v1.ResourceByID{Id: observed.GetDeploymentId()}
+
+	if len(in.Peers) > 0 {
+		peers, err := c.generatePeerStatuses(ctx, in, observed)
+		if err != nil {
+			return errors.Wrap(err, errModifyPeersFailed)
+		}
+		if len(peers) > 0 {
+			if _, err := c.svc.ModifyBaselineStatusForPeers(ctx, &v1.ModifyBaselineStatusForPeersRequest{
+				DeploymentId: observed.GetDeploymentId(),
+				Peers:        peers,
+			}); err != nil {
+				return errors.Wrap(err, errModifyPeersFailed)
+			}
+		}
+	}
+
+	switch {
+	case in.Locked && !observed.GetLocked():
+		_, err := c.svc.LockNetworkBaseline(ctx, id)
+		return errors.Wrap(err, errLockFailed)
+	case !in.Locked && observed.GetLocked():
+		_, err := c.svc.UnlockNetworkBaseline(ctx, id)
+		return errors.Wrap(err, errUnlockFailed)
+	}
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.NetworkBaseline)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNetworkBaseline)
+	}
+
+	baseline, err := c.getNetworkBaseline(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if baseline == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	cr.Status.AtProvider = generateObservation(baseline)
+	// The baseline outlives the managed resource. Once Delete unlocked it
+	// there is nothing left to delete.
+	if meta.WasDeleted(cr) && !baseline.GetLocked() {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+	upToDate, diff := isUpToDate(&cr.Spec.ForProvider, baseline)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+// Create applies the spec to the baseline. Baselines are created by Central
+// for every deployment, so Create fails until the deployment exists.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.NetworkBaseline)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNetworkBaseline)
+	}
+	in := &cr.Spec.ForProvider
+	cr.SetConditions(xpv1.Creating())
+
+	baseline, err := c.getNetworkBaseline(ctx, in)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	if baseline == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.Errorf(errDeploymentNotFound, in.Namespace, in.DeploymentName, in.ClusterName), errCreateFailed)
+	}
+	return managed.ExternalCreation{}, errors.Wrap(c.apply(ctx, in, baseline), errCreateFailed)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.NetworkBaseline)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNetworkBaseline)
+	}
+
+	baseline, err := c.getNetworkBaseline(ctx, &cr.Spec.ForProvider)
+	if err != nil || baseline == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	return managed.ExternalUpdate{}, errors.Wrap(c.apply(ctx, &cr.Spec.ForProvider, baseline), errUpdateFailed)
+}
+
+// Delete unlocks the baseline. Its peers are left in place.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.NetworkBaseline)
+	if !ok {
+		return errors.New(errNotNetworkBaseline)
+	}
+	cr.SetConditions(xpv1.Deleting())
+
+	if !cr.Status.AtProvider.Locked {
+		return nil
+	}
+	_, err := c.svc.UnlockNetworkBaseline(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.DeploymentID})
+	return errors.Wrap(err, errDeleteFailed)
+}
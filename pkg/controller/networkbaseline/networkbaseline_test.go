@@ -0,0 +1,328 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkbaseline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"github.com/stackrox/rox/pkg/networkgraph"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/networkbaseline/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type fakeNetworkBaselineService struct {
+	v1.NetworkBaselineServiceClient
+
+	MockGetNetworkBaseline           func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*storage.NetworkBaseline, error)
+	MockModifyBaselineStatusForPeers func(ctx context.Context, in *v1.ModifyBaselineStatusForPeersRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockLockNetworkBaseline          func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockUnlockNetworkBaseline        func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeNetworkBaselineService) GetNetworkBaseline(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*storage.NetworkBaseline, error) {
+	return f.MockGetNetworkBaseline(ctx, in, opts...)
+}
+
+func (f *fakeNetworkBaselineService) ModifyBaselineStatusForPeers(ctx context.Context, in *v1.ModifyBaselineStatusForPeersRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockModifyBaselineStatusForPeers(ctx, in, opts...)
+}
+
+func (f *fakeNetworkBaselineService) LockNetworkBaseline(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockLockNetworkBaseline(ctx, in, opts...)
+}
+
+func (f *fakeNetworkBaselineService) UnlockNetworkBaseline(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockUnlockNetworkBaseline(ctx, in, opts...)
+}
+
+type fakeDeploymentService struct {
+	v1.DeploymentServiceClient
+
+	MockListDeployments func(ctx context.Context, in *v1.RawQuery, opts ...grpc.CallOption) (*v1.ListDeploymentsResponse, error)
+}
+
+func (f *fakeDeploymentService) ListDeployments(ctx context.Context, in *v1.RawQuery, opts ...grpc.CallOption) (*v1.ListDeploymentsResponse, error) {
+	return f.MockListDeployments(ctx, in, opts...)
+}
+
+func withDeployments(deployments ...*storage.ListDeployment) *fakeDeploymentService {
+	return &fakeDeploymentService{
+		MockListDeployments: func(_ context.Context, _ *v1.RawQuery, _ ...grpc.CallOption) (*v1.ListDeploymentsResponse, error) {
+			return &v1.ListDeploymentsResponse{Deployments: deployments}, nil
+		},
+	}
+}
+
+func deployment(id, name string) *storage.ListDeployment {
+	return &storage.ListDeployment{Id: id, Name: name, Cluster: "remote", Namespace: "payments"}
+}
+
+func withBaseline(b *storage.NetworkBaseline) *fakeNetworkBaselineService {
+	return &fakeNetworkBaselineService{
+		MockGetNetworkBaseline: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*storage.NetworkBaseline, error) {
+			return b, nil
+		},
+	}
+}
+
+func observedBaseline(locked bool) *storage.NetworkBaseline {
+	return &storage.NetworkBaseline{
+		DeploymentId: "api-id",
+		ClusterId:    "remote-id",
+		Namespace:    "payments",
+		Locked:       locked,
+		Peers: []*storage.NetworkBaselinePeer{
+			{
+				Entity: &storage.NetworkEntity{Info: &storage.NetworkEntityInfo{
+					Type: storage.NetworkEntityInfo_DEPLOYMENT,
+					Id:   "db-id",
+					Desc: &storage.NetworkEntityInfo_Deployment_{Deployment: &storage.NetworkEntityInfo_Deployment{Name: "db", Namespace: "payments"}},
+				}},
+				Properties: []*storage.NetworkBaselineConnectionProperties{{Port: 5432, Protocol: storage.L4Protocol_L4_PROTOCOL_TCP}},
+			},
+			{
+				Entity: &storage.NetworkEntity{Info: &storage.NetworkEntityInfo{
+					Type: storage.NetworkEntityInfo_INTERNET,
+					Id:   networkgraph.InternetExternalSourceID,
+				}},
+				Properties: []*storage.NetworkBaselineConnectionProperties{{Port: 443, Protocol: storage.L4Protocol_L4_PROTOCOL_TCP}},
+			},
+		},
+	}
+}
+
+func networkBaseline(locked bool, peers ...v1alpha1.Peer) *v1alpha1.NetworkBaseline {
+	return &v1alpha1.NetworkBaseline{Spec: v1alpha1.NetworkBaselineSpec{ForProvider: v1alpha1.NetworkBaselineParameters{
+		ClusterName:    "remote",
+		Namespace:      "payments",
+		DeploymentName: "api",
+		Locked:         locked,
+		Peers:          peers,
+	}}}
+}
+
+var (
+	dbPeer = v1alpha1.Peer{
+		Entity:   v1alpha1.PeerEntity{Type: "DEPLOYMENT", Namespace: "payments", Name: "db"},
+		Port:     5432,
+		Protocol: "L4_PROTOCOL_TCP",
+	}
+	internetPeer = v1alpha1.Peer{
+		Entity:   v1alpha1.PeerEntity{Type: "INTERNET"},
+		Port:     443,
+		Protocol: "L4_PROTOCOL_TCP",
+	}
+	cachePeer = v1alpha1.Peer{
+		Entity:   v1alpha1.PeerEntity{Type: "DEPLOYMENT", Namespace: "payments", Name: "cache"},
+		Port:     6379,
+		Protocol: "L4_PROTOCOL_TCP",
+	}
+)
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.NetworkBaselineObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason      string
+		deployments *fakeDeploymentService
+		svc         *fakeNetworkBaselineService
+		cr          *v1alpha1.NetworkBaseline
+		want        want
+	}{
+		"ListFailed": {
+			reason: "Errors listing deployments should be returned.",
+			deployments: &fakeDeploymentService{
+				MockListDeployments: func(_ context.Context, _ *v1.RawQuery, _ ...grpc.CallOption) (*v1.ListDeploymentsResponse, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   networkBaseline(true),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errListDeployments), errObserveFailed)},
+		},
+		"DeploymentNotFound": {
+			reason:      "The baseline of a missing deployment should be reported as not existing.",
+			deployments: withDeployments(deployment("other-id", "other")),
+			cr:          networkBaseline(true),
+			want:        want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason:      "A locked baseline with the declared peers should be up to date.",
+			deployments: withDeployments(deployment("api-id", "api")),
+			svc:         withBaseline(observedBaseline(true)),
+			cr:          networkBaseline(true, internetPeer, dbPeer),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.NetworkBaselineObservation{DeploymentID: "api-id", ClusterID: "remote-id", Locked: true},
+			},
+		},
+		"PeersNotManaged": {
+			reason:      "The peers of the baseline should be ignored if none are declared.",
+			deployments: withDeployments(deployment("api-id", "api")),
+			svc:         withBaseline(observedBaseline(true)),
+			cr:          networkBaseline(true),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.NetworkBaselineObservation{DeploymentID: "api-id", ClusterID: "remote-id", Locked: true},
+			},
+		},
+		"Unlocked": {
+			reason:      "An unlocked baseline should not be up to date if it should be locked.",
+			deployments: withDeployments(deployment("api-id", "api")),
+			svc:         withBaseline(observedBaseline(false)),
+			cr:          networkBaseline(true),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs: v1alpha1.NetworkBaselineObservation{DeploymentID: "api-id", ClusterID: "remote-id"},
+			},
+		},
+		"PeerDrift": {
+			reason:      "A baseline with different peers should not be up to date.",
+			deployments: withDeployments(deployment("api-id", "api")),
+			svc:         withBaseline(observedBaseline(true)),
+			cr:          networkBaseline(true, dbPeer, cachePeer),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs: v1alpha1.NetworkBaselineObservation{DeploymentID: "api-id", ClusterID: "remote-id", Locked: true},
+			},
+		},
+		"Unlocked after deletion": {
+			reason:      "A deleted baseline should be reported as not existing once it is unlocked.",
+			deployments: withDeployments(deployment("api-id", "api")),
+			svc:         withBaseline(observedBaseline(false)),
+			cr: func() *v1alpha1.NetworkBaseline {
+				cr := networkBaseline(true)
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				obs: v1alpha1.NetworkBaselineObservation{DeploymentID: "api-id", ClusterID: "remote-id"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc, deployments: tc.deployments}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got.ResourceExists && got.ResourceUpToDate != (got.Diff == "") {
+				t.Errorf("\n%s\ne.Observe(...): want a diff if and only if the baseline is not up to date, got %q", tc.reason, got.Diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want observation, +got observation:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var modified *v1.ModifyBaselineStatusForPeersRequest
+	var locked *v1.ResourceByID
+	svc := withBaseline(observedBaseline(false))
+	svc.MockModifyBaselineStatusForPeers = func(_ context.Context, in *v1.ModifyBaselineStatusForPeersRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+		modified = in
+		return &v1.Empty{}, nil
+	}
+	svc.MockLockNetworkBaseline = func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+		locked = in
+		return &v1.Empty{}, nil
+	}
+	e := external{svc: svc, deployments: withDeployments(deployment("api-id", "api"), deployment("cache-id", "cache"))}
+
+	if _, err := e.Update(context.Background(), networkBaseline(true, dbPeer, cachePeer)); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+
+	want := &v1.ModifyBaselineStatusForPeersRequest{
+		DeploymentId: "api-id",
+		Peers: []*v1.NetworkBaselinePeerStatus{
+			{
+				Peer: &v1.NetworkBaselineStatusPeer{
+					Entity:   &v1.NetworkBaselinePeerEntity{Id: "cache-id", Type: storage.NetworkEntityInfo_DEPLOYMENT},
+					Port:     6379,
+					Protocol: storage.L4Protocol_L4_PROTOCOL_TCP,
+				},
+				Status: v1.NetworkBaselinePeerStatus_BASELINE,
+			},
+			{
+				Peer: &v1.NetworkBaselineStatusPeer{
+					Entity:   &v1.NetworkBaselinePeerEntity{Id: networkgraph.InternetExternalSourceID, Type: storage.NetworkEntityInfo_INTERNET},
+					Port:     443,
+					Protocol: storage.L4Protocol_L4_PROTOCOL_TCP,
+				},
+				Status: v1.NetworkBaselinePeerStatus_ANOMALOUS,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, modified); diff != "" {
+		t.Errorf("e.Update(...): -want peers, +got peers:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(&v1.ResourceByID{Id: "api-id"}, locked); diff != "" {
+		t.Errorf("e.Update(...): -want lock, +got lock:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var unlocked *v1.ResourceByID
+	cr := networkBaseline(true)
+	cr.Status.AtProvider = v1alpha1.NetworkBaselineObservation{DeploymentID: "api-id", Locked: true}
+	e := external{svc: &fakeNetworkBaselineService{
+		MockUnlockNetworkBaseline: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+			unlocked = in
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if diff := cmp.Diff(&v1.ResourceByID{Id: "api-id"}, unlocked); diff != "" {
+		t.Errorf("e.Delete(...): -want unlock, +got unlock:\n%s\n", diff)
+	}
+}
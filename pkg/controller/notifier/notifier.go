@@ -0,0 +1,619 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/notifier/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotNotifier   = "managed resource is not a Notifier custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+	errGetCreds      = "cannot get credentials"
+	errGetSecret     = "cannot get notifier secret"
+	errNoConfig      = "exactly one notifier config must be set"
+	errSlackLabel    = "labelDefault must not be set for slack notifiers, use slack.webhookURL instead"
+	errGetFailed     = "cannot get notifier"
+	errObserveFailed = "cannot observe notifier"
+	errCreateFailed  = "cannot create notifier"
+	errUpdateFailed  = "cannot update notifier"
+	errDeleteFailed  = "cannot delete notifier"
+)
+
+// Notifier types as registered in Central.
+const (
+	typeSlack          = "slack"
+	typeGeneric        = "generic"
+	typeEmail          = "email"
+	typeJira           = "jira"
+	typePagerDuty      = "pagerduty"
+	typeSplunk         = "splunk"
+	typeSumoLogic      = "sumologic"
+	typeSyslog         = "syslog"
+	typeAWSSecurityHub = "awsSecurityHub"
+)
+
+// Setup adds a controller that reconciles Notifier managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.NotifierGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.NotifierGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Notifier{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Notifier)
+	if !ok {
+		return nil, errors.New(errNotNotifier)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{kube: c.kube, svc: v1.NewNotifierServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube client.Client
+	svc  v1.NotifierServiceClient
+}
+
+func (c *external) getSecret(ctx context.Context, ref *xpv1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	v, err := resource.ExtractSecret(ctx, c.kube, xpv1.CommonCredentialSelectors{SecretRef: ref})
+	return string(v), errors.Wrap(err, errGetSecret)
+}
+
+// setSecrets resolves the secret references of the spec and writes their
+// values into the notifier config.
+func (c *external) setSecrets(ctx context.Context, in *v1alpha1.NotifierParameters, n *storage.Notifier) error {
+	var err error
+	switch {
+	case in.Generic != nil:
+		n.GetGeneric().Password, err = c.getSecret(ctx, in.Generic.PasswordSecretRef)
+	case in.Email != nil:
+		n.GetEmail().Password, err = c.getSecret(ctx, in.Email.PasswordSecretRef)
+	case in.Jira != nil:
+		n.GetJira().Password, err = c.getSecret(ctx, &in.Jira.PasswordSecretRef)
+	case in.PagerDuty != nil:
+		n.GetPagerduty().ApiKey, err = c.getSecret(ctx, &in.PagerDuty.APIKeySecretRef)
+	case in.Splunk != nil:
+		n.GetSplunk().HttpToken, err = c.getSecret(ctx, &in.Splunk.HTTPTokenSecretRef)
+	case in.AWSSecurityHub != nil:
+		creds := n.GetAwsSecurityHub().Credentials
+		if creds.AccessKeyId, err = c.getSecret(ctx, &in.AWSSecurityHub.AccessKeyIDSecretRef); err != nil {
+			return err
+		}
+		creds.SecretAccessKey, err = c.getSecret(ctx, &in.AWSSecurityHub.SecretAccessKeySecretRef)
+	}
+	return err
+}
+
+// secretHash returns a hash of the secret values of a notifier config, or an
+// empty string if it has none.
+func secretHash(n *storage.Notifier) string {
+	creds := n.GetAwsSecurityHub().GetCredentials()
+	values := []string{
+		n.GetGeneric().GetPassword(),
+		n.GetEmail().GetPassword(),
+		n.GetJira().GetPassword(),
+		n.GetPagerduty().GetApiKey(),
+		n.GetSplunk().GetHttpToken(),
+		creds.GetAccessKeyId(),
+		creds.GetSecretAccessKey(),
+	}
+	h := sha256.New()
+	empty := true
+	for _, v := range values {
+		empty = empty && v == ""
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	if empty {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lastSecretHash returns the hash of the secret values last written to
+// Central. It falls back to the annotation recorded on creation.
+func lastSecretHash(cr *v1alpha1.Notifier) string {
+	if h := cr.Status.AtProvider.SecretHash; h != "" {
+		return h
+	}
+	return cr.GetAnnotations()[v1alpha1.AnnotationKeySecretHash]
+}
+
+func notifierType(in *v1alpha1.NotifierParameters) string {
+	configs := map[string]bool{
+		typeSlack:          in.Slack != nil,
+		typeGeneric:        in.Generic != nil,
+		typeEmail:          in.Email != nil,
+		typeJira:           in.Jira != nil,
+		typePagerDuty:      in.PagerDuty != nil,
+		typeSplunk:         in.Splunk != nil,
+		typeSumoLogic:      in.SumoLogic != nil,
+		typeSyslog:         in.Syslog != nil,
+		typeAWSSecurityHub: in.AWSSecurityHub != nil,
+	}
+	t := ""
+	for k, set := range configs {
+		if !set {
+			continue
+		}
+		if t != "" {
+			return ""
+		}
+		t = k
+	}
+	return t
+}
+
+// validate rejects parameters Central cannot represent. Slack notifiers keep
+// their webhook URL in the default label, so it cannot be set separately.
+func validate(in *v1alpha1.NotifierParameters) error {
+	if notifierType(in) == "" {
+		return errors.New(errNoConfig)
+	}
+	if in.Slack != nil && in.LabelDefault != "" {
+		return errors.New(errSlackLabel)
+	}
+	return nil
+}
+
+func generateObservation(in *storage.Notifier) v1alpha1.NotifierObservation {
+	return v1alpha1.NotifierObservation{
+		ID:   in.GetId(),
+		Name: in.GetName(),
+		Type: in.GetType(),
+	}
+}
+
+func generateKeyValuePairs(in []v1alpha1.KeyValuePair) []*storage.KeyValuePair {
+	var out []*storage.KeyValuePair
+	for _, it := range in {
+		out = append(out, &storage.KeyValuePair{Key: it.Key, Value: it.Value})
+	}
+	return out
+}
+
+func generateJira(in *v1alpha1.JiraConfig) *storage.Notifier_Jira {
+	out := &storage.Jira{
+		Url:               in.URL,
+		Username:          in.Username,
+		IssueType:         in.IssueType,
+		DefaultFieldsJson: in.DefaultFieldsJSON,
+	}
+	for _, it := range in.PriorityMappings {
+		out.PriorityMappings = append(out.PriorityMappings, &storage.Jira_PriorityMapping{
+			Severity:     storage.Severity(storage.Severity_value[it.Severity]),
+			PriorityName: it.PriorityName,
+		})
+	}
+	return &storage.Notifier_Jira{Jira: out}
+}
+
+func generateSyslog(in *v1alpha1.SyslogConfig) *storage.Notifier_Syslog {
+	return &storage.Notifier_Syslog{Syslog: &storage.Syslog{
+		LocalFacility: storage.Syslog_LocalFacility(storage.Syslog_LocalFacility_value[in.LocalFacility]),
+		Endpoint: &storage.Syslog_TcpConfig{TcpConfig: &storage.Syslog_TCPConfig{
+			Hostname:      in.TCPConfig.Hostname,
+			Port:          in.TCPConfig.Port,
+			SkipTlsVerify: in.TCPConfig.SkipTLSVerify,
+			UseTls:        in.TCPConfig.UseTLS,
+		}},
+		ExtraFields: generateKeyValuePairs(in.ExtraFields),
+	}}
+}
+
+func generateConfig(in *v1alpha1.NotifierParameters, base *storage.Notifier) { //nolint:gocyclo // one case per notifier type
+	switch {
+	case in.Slack != nil:
+		base.Config = nil
+		base.LabelDefault = in.Slack.WebhookURL
+	case in.Generic != nil:
+		base.Config = &storage.Notifier_Generic{Generic: &storage.Generic{
+			Endpoint:            in.Generic.Endpoint,
+			SkipTLSVerify:       in.Generic.SkipTLSVerify,
+			CaCert:              in.Generic.CACert,
+			Username:            in.Generic.Username,
+			Headers:             generateKeyValuePairs(in.Generic.Headers),
+			ExtraFields:         generateKeyValuePairs(in.Generic.ExtraFields),
+			AuditLoggingEnabled: in.Generic.AuditLoggingEnabled,
+		}}
+	case in.Email != nil:
+		base.Config = &storage.Notifier_Email{Email: &storage.Email{
+			Server:                   in.Email.Server,
+			Sender:                   in.Email.Sender,
+			From:                     in.Email.From,
+			Username:                 in.Email.Username,
+			DisableTLS:               in.Email.DisableTLS,
+			StartTLSAuthMethod:       storage.Email_AuthMethod(storage.Email_AuthMethod_value[in.Email.StartTLSAuthMethod]),
+			AllowUnauthenticatedSmtp: in.Email.AllowUnauthenticatedSMTP,
+		}}
+	case in.Jira != nil:
+		base.Config = generateJira(in.Jira)
+	case in.PagerDuty != nil:
+		base.Config = &storage.Notifier_Pagerduty{Pagerduty: &storage.PagerDuty{}}
+	case in.Splunk != nil:
+		base.Config = &storage.Notifier_Splunk{Splunk: &storage.Splunk{
+			HttpEndpoint:        in.Splunk.HTTPEndpoint,
+			Insecure:            in.Splunk.Insecure,
+			Truncate:            in.Splunk.Truncate,
+			AuditLoggingEnabled: in.Splunk.AuditLoggingEnabled,
+			SourceTypes:         in.Splunk.SourceTypes,
+		}}
+	case in.SumoLogic != nil:
+		base.Config = &storage.Notifier_Sumologic{Sumologic: &storage.SumoLogic{
+			HttpSourceAddress: in.SumoLogic.HTTPSourceAddress,
+			SkipTLSVerify:     in.SumoLogic.SkipTLSVerify,
+		}}
+	case in.Syslog != nil:
+		base.Config = generateSyslog(in.Syslog)
+	case in.AWSSecurityHub != nil:
+		base.Config = &storage.Notifier_AwsSecurityHub{AwsSecurityHub: &storage.AWSSecurityHub{
+			Region:      in.AWSSecurityHub.Region,
+			AccountId:   in.AWSSecurityHub.AccountID,
+			Credentials: &storage.AWSSecurityHub_Credentials{},
+		}}
+	}
+}
+
+func generateNotifier(in *v1alpha1.NotifierParameters, base *storage.Notifier) *storage.Notifier {
+	if base == nil {
+		base = &storage.Notifier{}
+	}
+	base.Name = in.Name
+	base.Type = notifierType(in)
+	base.UiEndpoint = in.UIEndpoint
+	base.LabelKey = in.LabelKey
+	base.LabelDefault = in.LabelDefault
+	generateConfig(in, base)
+	return base
+}
+
+func generateKeyValuePairParameters(in []*storage.KeyValuePair) []v1alpha1.KeyValuePair {
+	var out []v1alpha1.KeyValuePair
+	for _, it := range in {
+		out = append(out, v1alpha1.KeyValuePair{Key: it.GetKey(), Value: it.GetValue()})
+	}
+	return out
+}
+
+func generateJiraParameters(in *storage.Jira, spec *v1alpha1.JiraConfig) *v1alpha1.JiraConfig {
+	out := &v1alpha1.JiraConfig{
+		URL:               in.GetUrl(),
+		Username:          in.GetUsername(),
+		PasswordSecretRef: spec.PasswordSecretRef,
+		IssueType:         in.GetIssueType(),
+		DefaultFieldsJSON: in.GetDefaultFieldsJson(),
+	}
+	for _, it := range in.GetPriorityMappings() {
+		out.PriorityMappings = append(out.PriorityMappings, v1alpha1.JiraPriorityMapping{
+			Severity:     storage.Severity_name[int32(it.GetSeverity())],
+			PriorityName: it.GetPriorityName(),
+		})
+	}
+	return out
+}
+
+func generateSyslogParameters(in *storage.Syslog) *v1alpha1.SyslogConfig {
+	tcp := in.GetTcpConfig()
+	return &v1alpha1.SyslogConfig{
+		LocalFacility: storage.Syslog_LocalFacility_name[int32(in.GetLocalFacility())],
+		TCPConfig: v1alpha1.SyslogTCPConfig{
+			Hostname:      tcp.GetHostname(),
+			Port:          tcp.GetPort(),
+			SkipTLSVerify: tcp.GetSkipTlsVerify(),
+			UseTLS:        tcp.GetUseTls(),
+		},
+		ExtraFields: generateKeyValuePairParameters(in.GetExtraFields()),
+	}
+}
+
+// generateNotifierParameters converts an observed notifier into parameters.
+// Central masks credentials in its responses, so the secret references are
+// taken from the supplied spec instead.
+func generateNotifierParameters(in *storage.Notifier, spec *v1alpha1.NotifierParameters) v1alpha1.NotifierParameters { //nolint:gocyclo // one case per notifier type
+	out := v1alpha1.NotifierParameters{
+		Name:         in.GetName(),
+		UIEndpoint:   in.GetUiEndpoint(),
+		LabelKey:     in.GetLabelKey(),
+		LabelDefault: in.GetLabelDefault(),
+	}
+	switch in.GetType() {
+	case typeSlack:
+		out.Slack = &v1alpha1.SlackConfig{WebhookURL: in.GetLabelDefault()}
+		out.LabelDefault = ""
+	case typeGeneric:
+		g := in.GetGeneric()
+		out.Generic = &v1alpha1.GenericConfig{
+			Endpoint:            g.GetEndpoint(),
+			SkipTLSVerify:       g.GetSkipTLSVerify(),
+			CACert:              g.GetCaCert(),
+			Username:            g.GetUsername(),
+			Headers:             generateKeyValuePairParameters(g.GetHeaders()),
+			ExtraFields:         generateKeyValuePairParameters(g.GetExtraFields()),
+			AuditLoggingEnabled: g.GetAuditLoggingEnabled(),
+		}
+		if spec.Generic != nil {
+			out.Generic.PasswordSecretRef = spec.Generic.PasswordSecretRef
+		}
+	case typeEmail:
+		e := in.GetEmail()
+		out.Email = &v1alpha1.EmailConfig{
+			Server:                   e.GetServer(),
+			Sender:                   e.GetSender(),
+			From:                     e.GetFrom(),
+			Username:                 e.GetUsername(),
+			DisableTLS:               e.GetDisableTLS(),
+			StartTLSAuthMethod:       storage.Email_AuthMethod_name[int32(e.GetStartTLSAuthMethod())],
+			AllowUnauthenticatedSMTP: e.GetAllowUnauthenticatedSmtp(),
+		}
+		if spec.Email != nil {
+			out.Email.PasswordSecretRef = spec.Email.PasswordSecretRef
+		}
+	case typeJira:
+		jira := spec.Jira
+		if jira == nil {
+			jira = &v1alpha1.JiraConfig{}
+		}
+		out.Jira = generateJiraParameters(in.GetJira(), jira)
+	case typePagerDuty:
+		out.PagerDuty = &v1alpha1.PagerDutyConfig{}
+		if spec.PagerDuty != nil {
+			out.PagerDuty.APIKeySecretRef = spec.PagerDuty.APIKeySecretRef
+		}
+	case typeSplunk:
+		s := in.GetSplunk()
+		out.Splunk = &v1alpha1.SplunkConfig{
+			HTTPEndpoint:        s.GetHttpEndpoint(),
+			Insecure:            s.GetInsecure(),
+			Truncate:            s.GetTruncate(),
+			AuditLoggingEnabled: s.GetAuditLoggingEnabled(),
+			SourceTypes:         s.GetSourceTypes(),
+		}
+		if spec.Splunk != nil {
+			out.Splunk.HTTPTokenSecretRef = spec.Splunk.HTTPTokenSecretRef
+		}
+	case typeSumoLogic:
+		out.SumoLogic = &v1alpha1.SumoLogicConfig{
+			HTTPSourceAddress: in.GetSumologic().GetHttpSourceAddress(),
+			SkipTLSVerify:     in.GetSumologic().GetSkipTLSVerify(),
+		}
+	case typeSyslog:
+		out.Syslog = generateSyslogParameters(in.GetSyslog())
+	case typeAWSSecurityHub:
+		out.AWSSecurityHub = &v1alpha1.AWSSecurityHubConfig{
+			Region:    in.GetAwsSecurityHub().GetRegion(),
+			AccountID: in.GetAwsSecurityHub().GetAccountId(),
+		}
+		if spec.AWSSecurityHub != nil {
+			out.AWSSecurityHub.AccessKeyIDSecretRef = spec.AWSSecurityHub.AccessKeyIDSecretRef
+			out.AWSSecurityHub.SecretAccessKeySecretRef = spec.AWSSecurityHub.SecretAccessKeySecretRef
+		}
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.Notifier, observed *storage.Notifier) (bool, string) {
+	observedParams := generateNotifierParameters(observed, &in.Spec.ForProvider)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in notifier\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getNotifier(ctx context.Context, cr *v1alpha1.Notifier) (*storage.Notifier, error) {
+	resp, err := c.svc.GetNotifiers(ctx, &v1.GetNotifiersRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetNotifiers() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			return it, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Notifier)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNotifier)
+	}
+
+	notifier, err := c.getNotifier(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if notifier == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	last := lastSecretHash(cr)
+	cr.Status.AtProvider = generateObservation(notifier)
+	cr.Status.AtProvider.SecretHash = last
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, notifier.GetName())
+	upToDate, diff := isUpToDate(cr, notifier)
+
+	// Central masks secrets in its responses, so they are compared against
+	// the hash of the values last written to it.
+	desired := generateNotifier(&cr.Spec.ForProvider, nil)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, desired); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if upToDate && secretHash(desired) != last {
+		upToDate, diff = false, "Observed change of notifier secrets"
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Notifier)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNotifier)
+	}
+	if err := validate(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	req := generateNotifier(&cr.Spec.ForProvider, nil)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, req); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	resp, err := c.svc.PostNotifier(ctx, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	cr.Status.AtProvider = generateObservation(resp)
+	cr.Status.AtProvider.SecretHash = secretHash(req)
+	meta.SetExternalName(cr, resp.GetName())
+	if h := cr.Status.AtProvider.SecretHash; h != "" {
+		meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeySecretHash: h})
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Notifier)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNotifier)
+	}
+	if err := validate(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	notifier, err := c.getNotifier(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if notifier == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	req := generateNotifier(&cr.Spec.ForProvider, notifier)
+	if err := c.setSecrets(ctx, &cr.Spec.ForProvider, req); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+
+	if _, err := c.svc.UpdateNotifier(ctx, &v1.UpdateNotifierRequest{Notifier: req, UpdatePassword: true}); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	cr.Status.AtProvider.SecretHash = secretHash(req)
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Notifier)
+	if !ok {
+		return errors.New(errNotNotifier)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeleteNotifier(ctx, &v1.DeleteNotifierRequest{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
@@ -0,0 +1,410 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/notifier/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	jira := v1alpha1.NotifierParameters{
+		Name:         "jira",
+		LabelDefault: "SEC",
+		Jira: &v1alpha1.JiraConfig{
+			URL:               "https://jira.example.com",
+			Username:          "bot",
+			PasswordSecretRef: xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "jira", Namespace: "crossplane-system"}, Key: "token"},
+			IssueType:         "Task",
+		},
+	}
+	slack := v1alpha1.NotifierParameters{
+		Name:  "slack",
+		Slack: &v1alpha1.SlackConfig{WebhookURL: "https://hooks.slack.com/services/a"},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		spec     v1alpha1.NotifierParameters
+		observed *storage.Notifier
+		want     bool
+	}{
+		"MaskedSecretIgnored": {
+			reason: "A masked password returned by Central should not cause drift.",
+			spec:   jira,
+			observed: &storage.Notifier{Name: "jira", Type: "jira", LabelDefault: "SEC", Config: &storage.Notifier_Jira{Jira: &storage.Jira{
+				Url: "https://jira.example.com", Username: "bot", Password: "******", IssueType: "Task",
+			}}},
+			want: true,
+		},
+		"JiraIssueTypeChanged": {
+			reason: "A different Jira issue type should cause drift.",
+			spec:   jira,
+			observed: &storage.Notifier{Name: "jira", Type: "jira", LabelDefault: "SEC", Config: &storage.Notifier_Jira{Jira: &storage.Jira{
+				Url: "https://jira.example.com", Username: "bot", Password: "******", IssueType: "Bug",
+			}}},
+			want: false,
+		},
+		"SlackWebhook": {
+			reason:   "The Slack webhook is stored as label default.",
+			spec:     slack,
+			observed: generateNotifier(&slack, nil),
+			want:     true,
+		},
+		"TypeChanged": {
+			reason:   "A notifier of a different type should cause drift.",
+			spec:     slack,
+			observed: &storage.Notifier{Name: "slack", Type: "pagerduty", Config: &storage.Notifier_Pagerduty{Pagerduty: &storage.PagerDuty{}}},
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Notifier{Spec: v1alpha1.NotifierSpec{ForProvider: tc.spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+func TestNotifierType(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.NotifierParameters
+		want   string
+	}{
+		"None": {
+			reason: "No config should yield no type.",
+			want:   "",
+		},
+		"Email": {
+			reason: "An email config should yield the email type.",
+			spec:   v1alpha1.NotifierParameters{Email: &v1alpha1.EmailConfig{}},
+			want:   "email",
+		},
+		"Multiple": {
+			reason: "Multiple configs should yield no type.",
+			spec:   v1alpha1.NotifierParameters{Email: &v1alpha1.EmailConfig{}, Slack: &v1alpha1.SlackConfig{}},
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := notifierType(&tc.spec)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nnotifierType(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type fakeNotifierService struct {
+	v1.NotifierServiceClient
+
+	MockGetNotifiers   func(ctx context.Context, in *v1.GetNotifiersRequest, opts ...grpc.CallOption) (*v1.GetNotifiersResponse, error)
+	MockPostNotifier   func(ctx context.Context, in *storage.Notifier, opts ...grpc.CallOption) (*storage.Notifier, error)
+	MockUpdateNotifier func(ctx context.Context, in *v1.UpdateNotifierRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeleteNotifier func(ctx context.Context, in *v1.DeleteNotifierRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeNotifierService) GetNotifiers(ctx context.Context, in *v1.GetNotifiersRequest, opts ...grpc.CallOption) (*v1.GetNotifiersResponse, error) {
+	return f.MockGetNotifiers(ctx, in, opts...)
+}
+
+func (f *fakeNotifierService) PostNotifier(ctx context.Context, in *storage.Notifier, opts ...grpc.CallOption) (*storage.Notifier, error) {
+	return f.MockPostNotifier(ctx, in, opts...)
+}
+
+func (f *fakeNotifierService) UpdateNotifier(ctx context.Context, in *v1.UpdateNotifierRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockUpdateNotifier(ctx, in, opts...)
+}
+
+func (f *fakeNotifierService) DeleteNotifier(ctx context.Context, in *v1.DeleteNotifierRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteNotifier(ctx, in, opts...)
+}
+
+func slackNotifier(m ...func(*v1alpha1.NotifierParameters)) *v1alpha1.Notifier {
+	cr := &v1alpha1.Notifier{Spec: v1alpha1.NotifierSpec{ForProvider: v1alpha1.NotifierParameters{
+		Name:  "slack",
+		Slack: &v1alpha1.SlackConfig{WebhookURL: "https://hooks.slack.com/services/a"},
+	}}}
+	for _, f := range m {
+		f(&cr.Spec.ForProvider)
+	}
+	return cr
+}
+
+func pagerDutyNotifier(m ...func(*v1alpha1.Notifier)) *v1alpha1.Notifier {
+	cr := &v1alpha1.Notifier{Spec: v1alpha1.NotifierSpec{ForProvider: v1alpha1.NotifierParameters{
+		Name: "pagerduty",
+		PagerDuty: &v1alpha1.PagerDutyConfig{APIKeySecretRef: xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "pagerduty", Namespace: "crossplane-system"}, Key: "key",
+		}},
+	}}}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// withSecret returns a kube client that serves the supplied value from any
+// Secret under the key "key".
+func withSecret(v string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			if s, ok := obj.(*corev1.Secret); ok {
+				s.Data = map[string][]byte{"key": []byte(v)}
+			}
+			return nil
+		}),
+	}
+}
+
+// hashOf returns the secret hash of a PagerDuty notifier with the supplied
+// API key.
+func hashOf(key string) string {
+	return secretHash(&storage.Notifier{Config: &storage.Notifier_Pagerduty{Pagerduty: &storage.PagerDuty{ApiKey: key}}})
+}
+
+func withNotifiers(n ...*storage.Notifier) *fakeNotifierService {
+	return &fakeNotifierService{
+		MockGetNotifiers: func(_ context.Context, _ *v1.GetNotifiersRequest, _ ...grpc.CallOption) (*v1.GetNotifiersResponse, error) {
+			return &v1.GetNotifiersResponse{Notifiers: n}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	pagerDuty := generateNotifier(&pagerDutyNotifier().Spec.ForProvider, &storage.Notifier{Id: "id"})
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		svc    *fakeNotifierService
+		mg     resource.Managed
+		want   want
+	}{
+		"NotNotifier": {
+			reason: "An error should be returned if the managed resource is not a Notifier.",
+			svc:    &fakeNotifierService{},
+			want:   want{err: errors.New(errNotNotifier)},
+		},
+		"GetFailed": {
+			reason: "Errors listing notifiers should be returned.",
+			svc: &fakeNotifierService{
+				MockGetNotifiers: func(_ context.Context, _ *v1.GetNotifiersRequest, _ ...grpc.CallOption) (*v1.GetNotifiersResponse, error) {
+					return nil, errBoom
+				},
+			},
+			mg:   slackNotifier(),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A notifier that does not exist in Central should be reported as not existing.",
+			svc:    withNotifiers(&storage.Notifier{Id: "other", Name: "other"}),
+			mg:     slackNotifier(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A notifier matching the spec should be reported as up to date.",
+			svc:    withNotifiers(generateNotifier(&slackNotifier().Spec.ForProvider, &storage.Notifier{Id: "id"})),
+			mg:     slackNotifier(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SecretUnchanged": {
+			reason: "A notifier whose secret has the recorded hash should be reported as up to date.",
+			kube:   withSecret("k1"),
+			svc:    withNotifiers(pagerDuty),
+			mg:     pagerDutyNotifier(func(cr *v1alpha1.Notifier) { cr.Status.AtProvider.SecretHash = hashOf("k1") }),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SecretUnchangedSinceCreate": {
+			reason: "The hash recorded on creation should be used if the status has none.",
+			kube:   withSecret("k1"),
+			svc:    withNotifiers(pagerDuty),
+			mg: pagerDutyNotifier(func(cr *v1alpha1.Notifier) {
+				meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeySecretHash: hashOf("k1")})
+			}),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SecretChanged": {
+			reason: "A notifier whose secret changed should be reported as not up to date.",
+			kube:   withSecret("k2"),
+			svc:    withNotifiers(pagerDuty),
+			mg:     pagerDutyNotifier(func(cr *v1alpha1.Notifier) { cr.Status.AtProvider.SecretHash = hashOf("k1") }),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "Observed change of notifier secrets"}},
+		},
+		"SecretNotRecorded": {
+			reason: "A notifier without recorded secret hash should be updated to make sure Central has the secret.",
+			kube:   withSecret("k1"),
+			svc:    withNotifiers(pagerDuty),
+			mg:     pagerDutyNotifier(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "Observed change of notifier secrets"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{kube: tc.kube, svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		id   string
+		hash string
+		err  error
+	}
+
+	post := &fakeNotifierService{
+		MockPostNotifier: func(_ context.Context, in *storage.Notifier, _ ...grpc.CallOption) (*storage.Notifier, error) {
+			out := *in
+			out.Id = "id"
+			return &out, nil
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Notifier
+		want   want
+	}{
+		"NoConfig": {
+			reason: "A notifier without config should be rejected.",
+			cr:     slackNotifier(func(p *v1alpha1.NotifierParameters) { p.Slack = nil }),
+			want:   want{err: errors.New(errNoConfig)},
+		},
+		"SlackLabelDefault": {
+			reason: "A Slack notifier with a default label should be rejected instead of overwriting it.",
+			cr:     slackNotifier(func(p *v1alpha1.NotifierParameters) { p.LabelDefault = "#alerts" }),
+			want:   want{err: errors.New(errSlackLabel)},
+		},
+		"Success": {
+			reason: "The ID of the created notifier should be recorded in the status.",
+			cr:     slackNotifier(),
+			want:   want{id: "id"},
+		},
+		"Secret": {
+			reason: "The hash of the secret of the created notifier should be recorded as annotation, which is persisted after creation.",
+			cr:     pagerDutyNotifier(),
+			want:   want{id: "id", hash: hashOf("k1")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{kube: withSecret("k1"), svc: post}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, tc.cr.Status.AtProvider.ID); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.hash, tc.cr.GetAnnotations()[v1alpha1.AnnotationKeySecretHash]); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want secret hash, +got secret hash:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var updated *v1.UpdateNotifierRequest
+	svc := withNotifiers(&storage.Notifier{Id: "id", Name: "slack", Type: "slack", LabelDefault: "https://hooks.slack.com/services/old"})
+	svc.MockUpdateNotifier = func(_ context.Context, in *v1.UpdateNotifierRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+		updated = in
+		return &v1.Empty{}, nil
+	}
+	e := external{svc: svc}
+
+	_, err := e.Update(context.Background(), slackNotifier(func(p *v1alpha1.NotifierParameters) { p.LabelDefault = "#alerts" }))
+	if diff := cmp.Diff(errors.New(errSlackLabel), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	if updated != nil {
+		t.Fatalf("e.Update(...): want no update of a rejected notifier, got %v", updated)
+	}
+
+	if _, err := e.Update(context.Background(), slackNotifier()); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if got := updated.GetNotifier(); got.GetId() != "id" || got.GetLabelDefault() != "https://hooks.slack.com/services/a" {
+		t.Errorf("e.Update(...): want notifier %q with the new webhook, got %v", "id", got)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var deleted string
+	cr := slackNotifier()
+	cr.Status.AtProvider.ID = "id"
+	e := external{svc: &fakeNotifierService{
+		MockDeleteNotifier: func(_ context.Context, in *v1.DeleteNotifierRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+			deleted = in.GetId()
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if deleted != "id" {
+		t.Errorf("e.Delete(...): want notifier %q deleted, got %q", "id", deleted)
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionset
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/permissionset/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotPermissionSet = "managed resource is not a PermissionSet custom resource"
+	errTrackPCUsage     = "cannot track ProviderConfig usage"
+	errGetPC            = "cannot get ProviderConfig"
+	errGetCreds         = "cannot get credentials"
+	errGetFailed        = "cannot get permission set"
+	errObserveFailed    = "cannot observe permission set"
+	errCreateFailed     = "cannot create permission set"
+	errUpdateFailed     = "cannot update permission set"
+	errDeleteFailed     = "cannot delete permission set"
+)
+
+// Setup adds a controller that reconciles PermissionSet managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.PermissionSetGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.PermissionSetGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.PermissionSet{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.PermissionSet)
+	if !ok {
+		return nil, errors.New(errNotPermissionSet)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{svc: v1.NewRoleServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc v1.RoleServiceClient
+}
+
+func generateObservation(in *storage.PermissionSet) v1alpha1.PermissionSetObservation {
+	return v1alpha1.PermissionSetObservation{
+		ID: in.GetId(),
+	}
+}
+
+func generatePermissionSet(in *v1alpha1.PermissionSetParameters, base *storage.PermissionSet) *storage.PermissionSet {
+	if base == nil {
+		base = &storage.PermissionSet{}
+	}
+	base.Name = in.Name
+	base.Description = in.Description
+	base.ResourceToAccess = make(map[string]storage.Access, len(in.ResourceToAccess))
+	for res, access := range in.ResourceToAccess {
+		base.ResourceToAccess[res] = storage.Access(storage.Access_value[string(access)])
+	}
+	return base
+}
+
+func generatePermissionSetParameters(in *storage.PermissionSet) v1alpha1.PermissionSetParameters {
+	out := v1alpha1.PermissionSetParameters{
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+	}
+	if len(in.GetResourceToAccess()) > 0 {
+		out.ResourceToAccess = make(map[string]v1alpha1.Access, len(in.GetResourceToAccess()))
+		for res, access := range in.GetResourceToAccess() {
+			out.ResourceToAccess[res] = v1alpha1.Access(access.String())
+		}
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.PermissionSet, observed *storage.PermissionSet) (bool, string) {
+	observedParams := generatePermissionSetParameters(observed)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in permission set\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getPermissionSet(ctx context.Context, cr *v1alpha1.PermissionSet) (*storage.PermissionSet, error) {
+	resp, err := c.svc.ListPermissionSets(ctx, &v1.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetPermissionSets() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			return it, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.PermissionSet)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPermissionSet)
+	}
+
+	ps, err := c.getPermissionSet(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if ps == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(ps)
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, ps.GetName())
+	upToDate, diff := isUpToDate(cr, ps)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.PermissionSet)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPermissionSet)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	resp, err := c.svc.PostPermissionSet(ctx, generatePermissionSet(&cr.Spec.ForProvider, nil))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	cr.Status.AtProvider = generateObservation(resp)
+	meta.SetExternalName(cr, resp.GetName())
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.PermissionSet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPermissionSet)
+	}
+
+	ps, err := c.getPermissionSet(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if ps == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	_, err = c.svc.PutPermissionSet(ctx, generatePermissionSet(&cr.Spec.ForProvider, ps))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.PermissionSet)
+	if !ok {
+		return errors.New(errNotPermissionSet)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeletePermissionSet(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
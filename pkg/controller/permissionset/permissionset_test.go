@@ -0,0 +1,306 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/permissionset/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	spec := v1alpha1.PermissionSetParameters{
+		Name:             "analyst",
+		ResourceToAccess: map[string]v1alpha1.Access{"Alert": "READ_ACCESS", "Deployment": "READ_WRITE_ACCESS"},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *storage.PermissionSet
+		want     bool
+	}{
+		"UpToDate": {
+			reason: "A permission set with the same access levels should be up to date.",
+			observed: &storage.PermissionSet{Id: "io.stackrox.authz.permissionset.analyst", Name: "analyst", ResourceToAccess: map[string]storage.Access{
+				"Alert": storage.Access_READ_ACCESS, "Deployment": storage.Access_READ_WRITE_ACCESS,
+			}},
+			want: true,
+		},
+		"AccessChanged": {
+			reason: "A different access level should cause drift.",
+			observed: &storage.PermissionSet{Name: "analyst", ResourceToAccess: map[string]storage.Access{
+				"Alert": storage.Access_READ_ACCESS, "Deployment": storage.Access_READ_ACCESS,
+			}},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.PermissionSet{Spec: v1alpha1.PermissionSetSpec{ForProvider: spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+func permissionSet() *v1alpha1.PermissionSet {
+	return &v1alpha1.PermissionSet{Spec: v1alpha1.PermissionSetSpec{ForProvider: v1alpha1.PermissionSetParameters{
+		Name:             "analyst",
+		ResourceToAccess: map[string]v1alpha1.Access{"Alert": "READ_ACCESS"},
+	}}}
+}
+
+func withPermissionSets(p ...*storage.PermissionSet) *fake.RoleService {
+	return &fake.RoleService{
+		MockListPermissionSets: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.ListPermissionSetsResponse, error) {
+			return &v1.ListPermissionSetsResponse{PermissionSets: p}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.RoleService
+		mg     resource.Managed
+		want   want
+	}{
+		"NotPermissionSet": {
+			reason: "An error should be returned if the managed resource is not a PermissionSet.",
+			svc:    &fake.RoleService{},
+			want:   want{err: errors.New(errNotPermissionSet)},
+		},
+		"ListFailed": {
+			reason: "Errors listing permission sets should be returned.",
+			svc: &fake.RoleService{
+				MockListPermissionSets: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.ListPermissionSetsResponse, error) {
+					return nil, errBoom
+				},
+			},
+			mg:   permissionSet(),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A permission set that does not exist in Central should be reported as not existing.",
+			svc:    withPermissionSets(),
+			mg:     permissionSet(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A permission set matching the spec should be reported as up to date.",
+			svc:    withPermissionSets(generatePermissionSet(&permissionSet().Spec.ForProvider, &storage.PermissionSet{Id: "id"})),
+			mg:     permissionSet(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		id  string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors creating the permission set should be returned.",
+			err:    errBoom,
+			want:   want{err: errors.Wrap(errBoom, errCreateFailed)},
+		},
+		"Success": {
+			reason: "The ID of the created permission set should be recorded in the status.",
+			want:   want{id: "id"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := permissionSet()
+			e := external{svc: &fake.RoleService{
+				MockPostPermissionSet: func(_ context.Context, in *storage.PermissionSet, _ ...grpc.CallOption) (*storage.PermissionSet, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					out := *in
+					out.Id = "id"
+					return &out, nil
+				},
+			}}
+
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, cr.Status.AtProvider.ID); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		id     string
+		access map[string]storage.Access
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed []*storage.PermissionSet
+		err      error
+		want     want
+	}{
+		"NotFound": {
+			reason: "A permission set that no longer exists should not be updated.",
+			want:   want{},
+		},
+		"UpdateFailed": {
+			reason:   "Errors updating the permission set should be returned.",
+			observed: []*storage.PermissionSet{{Id: "id", Name: "analyst"}},
+			err:      errBoom,
+			want:     want{id: "id", access: map[string]storage.Access{"Alert": storage.Access_READ_ACCESS}, err: errors.Wrap(errBoom, errUpdateFailed)},
+		},
+		"Success": {
+			reason:   "The observed permission set should be updated by ID to the spec.",
+			observed: []*storage.PermissionSet{{Id: "id", Name: "analyst"}},
+			want:     want{id: "id", access: map[string]storage.Access{"Alert": storage.Access_READ_ACCESS}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated *storage.PermissionSet
+			svc := withPermissionSets(tc.observed...)
+			svc.MockPutPermissionSet = func(_ context.Context, in *storage.PermissionSet, _ ...grpc.CallOption) (*v1.Empty, error) {
+				updated = in
+				return &v1.Empty{}, tc.err
+			}
+			e := external{svc: svc}
+
+			_, err := e.Update(context.Background(), permissionSet())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, updated.GetId()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.access, updated.GetResourceToAccess(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want access, +got access:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		deleted string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"DeleteFailed": {
+			reason: "Errors deleting the permission set should be returned.",
+			err:    errBoom,
+			want:   want{deleted: "id", err: errors.Wrap(errBoom, errDeleteFailed)},
+		},
+		"Success": {
+			reason: "The permission set should be deleted by the ID in its status.",
+			want:   want{deleted: "id"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted string
+			cr := permissionSet()
+			cr.Status.AtProvider.ID = "id"
+			e := external{svc: &fake.RoleService{
+				MockDeletePermissionSet: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+					deleted = in.GetId()
+					return &v1.Empty{}, tc.err
+				},
+			}}
+
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want deleted permission set, +got deleted permission set:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
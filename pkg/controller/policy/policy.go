@@ -0,0 +1,403 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/policy/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotPolicy     = "managed resource is not a Policy custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+	errGetCreds      = "cannot get credentials"
+	errGetFailed     = "cannot get policy"
+	errObserveFailed = "cannot observe policy"
+	errCreateFailed  = "cannot create policy"
+	errUpdateFailed  = "cannot update policy"
+	errDeleteFailed  = "cannot delete policy"
+)
+
+// Setup adds a controller that reconciles Policy managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.PolicyGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.PolicyGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Policy{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Policy)
+	if !ok {
+		return nil, errors.New(errNotPolicy)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, ktypes.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{svc: v1.NewPolicyServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc v1.PolicyServiceClient
+}
+
+func generateObservation(in *storage.Policy) v1alpha1.PolicyObservation {
+	return v1alpha1.PolicyObservation{
+		ID:                 in.GetId(),
+		Name:               in.GetName(),
+		CriteriaLocked:     in.GetCriteriaLocked(),
+		IsDefault:          in.GetIsDefault(),
+		LastUpdated:        central.ToTime(in.GetLastUpdated()),
+		MitreVectorsLocked: in.GetMitreVectorsLocked(),
+		PolicyVersion:      in.GetPolicyVersion(),
+	}
+}
+
+func generateScope(in *v1alpha1.Scope) *storage.Scope {
+	if in == nil {
+		return nil
+	}
+	out := &storage.Scope{Cluster: in.Cluster, Namespace: in.Namespace}
+	if in.Label != nil {
+		out.Label = &storage.Scope_Label{Key: in.Label.Key, Value: in.Label.Value}
+	}
+	return out
+}
+
+func generateExclusion(in v1alpha1.Exclusion) *storage.Exclusion {
+	out := &storage.Exclusion{Name: in.Name, Expiration: central.ToTimestamp(in.Expiration)}
+	if in.Deployment != nil {
+		out.Deployment = &storage.Exclusion_Deployment{
+			Name:  in.Deployment.Name,
+			Scope: generateScope(in.Deployment.Scope),
+		}
+	}
+	if in.Image != nil {
+		out.Image = &storage.Exclusion_Image{Name: in.Image.Name}
+	}
+	return out
+}
+
+func generatePolicySection(in v1alpha1.PolicySection) *storage.PolicySection {
+	out := &storage.PolicySection{SectionName: in.SectionName}
+	for _, g := range in.PolicyGroups {
+		group := &storage.PolicyGroup{
+			FieldName:       g.FieldName,
+			BooleanOperator: storage.BooleanOperator(storage.BooleanOperator_value[g.BooleanOperator]),
+			Negate:          g.Negate,
+		}
+		for _, v := range g.Values {
+			group.Values = append(group.Values, &storage.PolicyValue{Value: v})
+		}
+		out.PolicyGroups = append(out.PolicyGroups, group)
+	}
+	return out
+}
+
+func generatePolicy(in *v1alpha1.PolicyParameters, base *storage.Policy) *storage.Policy {
+	if base == nil {
+		base = &storage.Policy{}
+	}
+	base.Name = in.Name
+	base.Description = in.Description
+	base.Rationale = in.Rationale
+	base.Remediation = in.Remediation
+	base.Disabled = in.Disabled
+	base.Categories = in.Categories
+	base.EventSource = storage.EventSource(storage.EventSource_value[in.EventSource])
+	base.Severity = storage.Severity(storage.Severity_value[in.Severity])
+	base.Notifiers = in.Notifiers
+
+	base.LifecycleStages = nil
+	for _, it := range in.LifecycleStages {
+		base.LifecycleStages = append(base.LifecycleStages, storage.LifecycleStage(storage.LifecycleStage_value[string(it)]))
+	}
+	base.EnforcementActions = nil
+	for _, it := range in.EnforcementActions {
+		base.EnforcementActions = append(base.EnforcementActions, storage.EnforcementAction(storage.EnforcementAction_value[string(it)]))
+	}
+	base.Exclusions = nil
+	for _, it := range in.Exclusions {
+		base.Exclusions = append(base.Exclusions, generateExclusion(it))
+	}
+	base.Scope = nil
+	for i := range in.Scope {
+		base.Scope = append(base.Scope, generateScope(&in.Scope[i]))
+	}
+	base.PolicySections = nil
+	for _, it := range in.PolicySections {
+		base.PolicySections = append(base.PolicySections, generatePolicySection(it))
+	}
+	base.MitreAttackVectors = nil
+	for _, it := range in.MitreAttackVectors {
+		base.MitreAttackVectors = append(base.MitreAttackVectors, &storage.Policy_MitreAttackVectors{
+			Tactic:     it.Tactic,
+			Techniques: it.Techniques,
+		})
+	}
+	return base
+}
+
+func generateScopeParameters(in *storage.Scope) *v1alpha1.Scope {
+	if in == nil {
+		return nil
+	}
+	out := &v1alpha1.Scope{Cluster: in.GetCluster(), Namespace: in.GetNamespace()}
+	if l := in.GetLabel(); l != nil {
+		out.Label = &v1alpha1.ScopeLabel{Key: l.GetKey(), Value: l.GetValue()}
+	}
+	return out
+}
+
+func generateExclusionParameters(in *storage.Exclusion) v1alpha1.Exclusion {
+	out := v1alpha1.Exclusion{Name: in.GetName()}
+	out.Expiration = central.ToTime(in.GetExpiration())
+	if d := in.GetDeployment(); d != nil {
+		out.Deployment = &v1alpha1.ExclusionDeployment{
+			Name:  d.GetName(),
+			Scope: generateScopeParameters(d.GetScope()),
+		}
+	}
+	if i := in.GetImage(); i != nil {
+		out.Image = &v1alpha1.ExclusionImage{Name: i.GetName()}
+	}
+	return out
+}
+
+func generatePolicySectionParameters(in *storage.PolicySection) v1alpha1.PolicySection {
+	out := v1alpha1.PolicySection{SectionName: in.GetSectionName()}
+	for _, g := range in.GetPolicyGroups() {
+		group := v1alpha1.PolicyGroup{
+			FieldName:       g.GetFieldName(),
+			BooleanOperator: storage.BooleanOperator_name[int32(g.GetBooleanOperator())],
+			Negate:          g.GetNegate(),
+		}
+		for _, v := range g.GetValues() {
+			group.Values = append(group.Values, v.GetValue())
+		}
+		out.PolicyGroups = append(out.PolicyGroups, group)
+	}
+	return out
+}
+
+func generatePolicyParameters(in *storage.Policy) v1alpha1.PolicyParameters {
+	out := v1alpha1.PolicyParameters{
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+		Rationale:   in.GetRationale(),
+		Remediation: in.GetRemediation(),
+		Disabled:    in.GetDisabled(),
+		Categories:  in.GetCategories(),
+		EventSource: storage.EventSource_name[int32(in.GetEventSource())],
+		Severity:    storage.Severity_name[int32(in.GetSeverity())],
+		Notifiers:   in.GetNotifiers(),
+	}
+	for _, it := range in.GetLifecycleStages() {
+		out.LifecycleStages = append(out.LifecycleStages, v1alpha1.LifecycleStage(storage.LifecycleStage_name[int32(it)]))
+	}
+	for _, it := range in.GetEnforcementActions() {
+		out.EnforcementActions = append(out.EnforcementActions, v1alpha1.EnforcementAction(storage.EnforcementAction_name[int32(it)]))
+	}
+	for _, it := range in.GetExclusions() {
+		out.Exclusions = append(out.Exclusions, generateExclusionParameters(it))
+	}
+	for _, it := range in.GetScope() {
+		out.Scope = append(out.Scope, *generateScopeParameters(it))
+	}
+	for _, it := range in.GetPolicySections() {
+		out.PolicySections = append(out.PolicySections, generatePolicySectionParameters(it))
+	}
+	for _, it := range in.GetMitreAttackVectors() {
+		out.MitreAttackVectors = append(out.MitreAttackVectors, v1alpha1.MitreAttackVector{
+			Tactic:     it.GetTactic(),
+			Techniques: it.GetTechniques(),
+		})
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.Policy, observed *storage.Policy) (bool, string) {
+	observedParams := generatePolicyParameters(observed)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in policy\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getPolicy(ctx context.Context, cr *v1alpha1.Policy) (*storage.Policy, error) {
+	resp, err := c.svc.ListPolicies(ctx, &v1.RawQuery{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetPolicies() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			policy, err := c.svc.GetPolicy(ctx, &v1.ResourceByID{Id: it.GetId()})
+			return policy, errors.Wrap(err, errGetFailed)
+		}
+	}
+	return nil, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Policy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPolicy)
+	}
+
+	policy, err := c.getPolicy(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if policy == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(policy)
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, policy.GetName())
+	upToDate, diff := isUpToDate(cr, policy)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Policy)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPolicy)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	req := &v1.PostPolicyRequest{Policy: generatePolicy(&cr.Spec.ForProvider, nil)}
+	resp, err := c.svc.PostPolicy(ctx, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	cr.Status.AtProvider = generateObservation(resp)
+	meta.SetExternalName(cr, resp.GetName())
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Policy)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPolicy)
+	}
+
+	policy, err := c.getPolicy(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if policy == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	req := generatePolicy(&cr.Spec.ForProvider, policy)
+	_, err = c.svc.PutPolicy(ctx, req)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Policy)
+	if !ok {
+		return errors.New(errNotPolicy)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeletePolicy(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/policy/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func params() v1alpha1.PolicyParameters {
+	return v1alpha1.PolicyParameters{
+		Name:               "latest-tag",
+		Categories:         []string{"DevOps Best Practices"},
+		LifecycleStages:    []v1alpha1.LifecycleStage{"BUILD", "DEPLOY"},
+		EventSource:        "NOT_APPLICABLE",
+		Severity:           "LOW_SEVERITY",
+		EnforcementActions: []v1alpha1.EnforcementAction{"FAIL_BUILD_ENFORCEMENT"},
+		Scope:              []v1alpha1.Scope{{Namespace: "prod", Label: &v1alpha1.ScopeLabel{Key: "app", Value: "web"}}},
+		Exclusions:         []v1alpha1.Exclusion{{Name: "ignore-infra", Deployment: &v1alpha1.ExclusionDeployment{Scope: &v1alpha1.Scope{Namespace: "infra"}}}},
+		PolicySections: []v1alpha1.PolicySection{{
+			PolicyGroups: []v1alpha1.PolicyGroup{{FieldName: "Image Tag", BooleanOperator: "OR", Values: []string{"latest"}}},
+		}},
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type want struct {
+		upToDate bool
+	}
+
+	cases := map[string]struct {
+		reason   string
+		spec     v1alpha1.PolicyParameters
+		observed *storage.Policy
+		want     want
+	}{
+		"RoundTrip": {
+			reason:   "A policy generated from the spec should be up to date.",
+			spec:     params(),
+			observed: generatePolicy(func() *v1alpha1.PolicyParameters { p := params(); return &p }(), nil),
+			want:     want{upToDate: true},
+		},
+		"SeverityChanged": {
+			reason: "A policy with a different severity should not be up to date.",
+			spec: func() v1alpha1.PolicyParameters {
+				p := params()
+				p.Severity = "HIGH_SEVERITY"
+				return p
+			}(),
+			observed: generatePolicy(func() *v1alpha1.PolicyParameters { p := params(); return &p }(), nil),
+			want:     want{upToDate: false},
+		},
+		"ServerFieldsIgnored": {
+			reason:   "Server populated fields that are not part of the spec should not cause drift.",
+			spec:     params(),
+			observed: generatePolicy(func() *v1alpha1.PolicyParameters { p := params(); return &p }(), &storage.Policy{Id: "id", PolicyVersion: "1.1", IsDefault: true}),
+			want:     want{upToDate: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Policy{Spec: v1alpha1.PolicySpec{ForProvider: tc.spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want.upToDate, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+type fakePolicyService struct {
+	v1.PolicyServiceClient
+
+	MockListPolicies func(ctx context.Context, in *v1.RawQuery, opts ...grpc.CallOption) (*v1.ListPoliciesResponse, error)
+	MockGetPolicy    func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*storage.Policy, error)
+	MockPostPolicy   func(ctx context.Context, in *v1.PostPolicyRequest, opts ...grpc.CallOption) (*storage.Policy, error)
+	MockPutPolicy    func(ctx context.Context, in *storage.Policy, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeletePolicy func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakePolicyService) ListPolicies(ctx context.Context, in *v1.RawQuery, opts ...grpc.CallOption) (*v1.ListPoliciesResponse, error) {
+	return f.MockListPolicies(ctx, in, opts...)
+}
+
+func (f *fakePolicyService) GetPolicy(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*storage.Policy, error) {
+	return f.MockGetPolicy(ctx, in, opts...)
+}
+
+func (f *fakePolicyService) PostPolicy(ctx context.Context, in *v1.PostPolicyRequest, opts ...grpc.CallOption) (*storage.Policy, error) {
+	return f.MockPostPolicy(ctx, in, opts...)
+}
+
+func (f *fakePolicyService) PutPolicy(ctx context.Context, in *storage.Policy, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockPutPolicy(ctx, in, opts...)
+}
+
+func (f *fakePolicyService) DeletePolicy(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeletePolicy(ctx, in, opts...)
+}
+
+func policy() *v1alpha1.Policy {
+	return &v1alpha1.Policy{Spec: v1alpha1.PolicySpec{ForProvider: params()}}
+}
+
+// withPolicy returns a fake PolicyService that serves the supplied policy.
+func withPolicy(p *storage.Policy) *fakePolicyService {
+	return &fakePolicyService{
+		MockListPolicies: func(_ context.Context, _ *v1.RawQuery, _ ...grpc.CallOption) (*v1.ListPoliciesResponse, error) {
+			if p == nil {
+				return &v1.ListPoliciesResponse{}, nil
+			}
+			return &v1.ListPoliciesResponse{Policies: []*storage.ListPolicy{{Id: p.GetId(), Name: p.GetName()}}}, nil
+		},
+		MockGetPolicy: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*storage.Policy, error) {
+			return p, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakePolicyService
+		args   args
+		want   want
+	}{
+		"NotPolicy": {
+			reason: "An error should be returned if the managed resource is not a Policy.",
+			svc:    &fakePolicyService{},
+			args:   args{ctx: context.Background()},
+			want:   want{err: errors.New(errNotPolicy)},
+		},
+		"ListFailed": {
+			reason: "Errors listing policies should be returned.",
+			svc: &fakePolicyService{
+				MockListPolicies: func(_ context.Context, _ *v1.RawQuery, _ ...grpc.CallOption) (*v1.ListPoliciesResponse, error) {
+					return nil, errBoom
+				},
+			},
+			args: args{ctx: context.Background(), mg: policy()},
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A policy that does not exist in Central should be reported as not existing.",
+			svc:    withPolicy(nil),
+			args:   args{ctx: context.Background(), mg: policy()},
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A policy matching the spec should be reported as up to date.",
+			svc:    withPolicy(generatePolicy(func() *v1alpha1.PolicyParameters { p := params(); return &p }(), &storage.Policy{Id: "id"})),
+			args:   args{ctx: context.Background(), mg: policy()},
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveUnsetTimestamp(t *testing.T) {
+	cr := policy()
+	e := external{svc: withPolicy(&storage.Policy{Id: "id", Name: params().Name})}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if cr.Status.AtProvider.LastUpdated != nil {
+		t.Errorf("e.Observe(...): want nil lastUpdated for an unset timestamp, got %v", cr.Status.AtProvider.LastUpdated)
+	}
+	if got := meta.GetExternalName(cr); got != params().Name {
+		t.Errorf("e.Observe(...): want external name %q, got %q", params().Name, got)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		id  string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakePolicyService
+		want   want
+	}{
+		"PostFailed": {
+			reason: "Errors creating the policy should be returned.",
+			svc: &fakePolicyService{
+				MockPostPolicy: func(_ context.Context, _ *v1.PostPolicyRequest, _ ...grpc.CallOption) (*storage.Policy, error) {
+					return nil, errBoom
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errCreateFailed)},
+		},
+		"Success": {
+			reason: "The ID of the created policy should be recorded in the status.",
+			svc: &fakePolicyService{
+				MockPostPolicy: func(_ context.Context, in *v1.PostPolicyRequest, _ ...grpc.CallOption) (*storage.Policy, error) {
+					out := *in.GetPolicy()
+					out.Id = "id"
+					return &out, nil
+				},
+			},
+			want: want{id: "id"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := policy()
+			e := external{svc: tc.svc}
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, cr.Status.AtProvider.ID); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var updated *storage.Policy
+	svc := withPolicy(&storage.Policy{Id: "id", Name: params().Name, Severity: storage.Severity_HIGH_SEVERITY})
+	svc.MockPutPolicy = func(_ context.Context, in *storage.Policy, _ ...grpc.CallOption) (*v1.Empty, error) {
+		updated = in
+		return &v1.Empty{}, nil
+	}
+
+	e := external{svc: svc}
+	if _, err := e.Update(context.Background(), policy()); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if updated.GetId() != "id" {
+		t.Errorf("e.Update(...): want ID of the observed policy, got %q", updated.GetId())
+	}
+	if updated.GetSeverity() != storage.Severity_LOW_SEVERITY {
+		t.Errorf("e.Update(...): want severity of the spec, got %s", updated.GetSeverity())
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var deleted string
+	cr := policy()
+	cr.Status.AtProvider.ID = "id"
+	e := external{svc: &fakePolicyService{
+		MockDeletePolicy: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+			deleted = in.GetId()
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if deleted != "id" {
+		t.Errorf("e.Delete(...): want policy %q deleted, got %q", "id", deleted)
+	}
+}
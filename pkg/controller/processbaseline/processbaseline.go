@@ -0,0 +1,365 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processbaseline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/processbaseline/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotProcessBaseline   = "managed resource is not a ProcessBaseline custom resource"
+	errTrackPCUsage         = "cannot track ProviderConfig usage"
+	errGetPC                = "cannot get ProviderConfig"
+	errGetCreds             = "cannot get credentials"
+	errListDeployments      = "cannot list deployments"
+	errBaselineNotFound     = "cannot find process baseline of container %s in deployment %s/%s in cluster %s"
+	errGetFailed            = "cannot get process baseline"
+	errObserveFailed        = "cannot observe process baseline"
+	errCreateFailed         = "cannot create process baseline"
+	errUpdateElementsFailed = "cannot update processes of process baseline"
+	errLockFailed           = "cannot lock process baseline"
+	errUpdateFailed         = "cannot update process baseline"
+	errDeleteFailed         = "cannot delete process baseline"
+)
+
+// Setup adds a controller that reconciles ProcessBaseline managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ProcessBaselineGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ProcessBaselineGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		// The baseline belongs to a container and is looked up by its key.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProcessBaseline{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ProcessBaseline)
+	if !ok {
+		return nil, errors.New(errNotProcessBaseline)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{
+		svc:         v1.NewProcessBaselineServiceClient(client),
+		deployments: v1.NewDeploymentServiceClient(client),
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc         v1.ProcessBaselineServiceClient
+	deployments v1.DeploymentServiceClient
+}
+
+// isStackRoxLocked returns true once the observation period of the baseline
+// ended.
+func isStackRoxLocked(in *storage.ProcessBaseline) bool {
+	t := central.ToTime(in.GetStackRoxLockedTimestamp())
+	return t != nil && !t.After(time.Now())
+}
+
+func processNames(in []*storage.BaselineElement) []string {
+	out := make([]string, 0, len(in))
+	for _, e := range in {
+		out = append(out, e.GetElement().GetProcessName())
+	}
+	return out
+}
+
+func generateProcessBaselineParameters(observed *storage.ProcessBaseline, spec *v1alpha1.ProcessBaselineParameters) *v1alpha1.ProcessBaselineParameters {
+	out := spec.DeepCopy()
+	out.Locked = observed.GetUserLockedTimestamp() != nil
+	if len(spec.Processes) > 0 {
+		out.Processes = processNames(observed.GetElements())
+	}
+	return out
+}
+
+func generateObservation(in *storage.ProcessBaseline) v1alpha1.ProcessBaselineObservation {
+	out := v1alpha1.ProcessBaselineObservation{
+		ID:                 in.GetId(),
+		DeploymentID:       in.GetKey().GetDeploymentId(),
+		UserLocked:         in.GetUserLockedTimestamp() != nil,
+		StackRoxLocked:     isStackRoxLocked(in),
+		StackRoxLockedTime: central.ToTime(in.GetStackRoxLockedTimestamp()),
+	}
+	for _, e := range in.GetElements() {
+		out.Elements = append(out.Elements, v1alpha1.BaselineElement{
+			ProcessName: e.GetElement().GetProcessName(),
+			Auto:        e.GetAuto(),
+		})
+	}
+	return out
+}
+
+func isUpToDate(in *v1alpha1.ProcessBaselineParameters, observed *storage.ProcessBaseline) (bool, string) {
+	observedParams := generateProcessBaselineParameters(observed, in)
+	if diff := cmp.Diff(*in, *observedParams, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		diff = "Observed difference in process baseline\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+// getDeployment looks up the deployment by cluster ID, namespace and name. It
+// returns nil if the deployment does not exist.
+func (c *external) getDeployment(ctx context.Context, clusterID, namespace, name string) (*storage.ListDeployment, error) {
+	resp, err := c.deployments.ListDeployments(ctx, &v1.RawQuery{
+		Query: fmt.Sprintf("Cluster ID:%q+Namespace:%q+Deployment:%q", clusterID, namespace, name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errListDeployments)
+	}
+	for _, d := range resp.GetDeployments() {
+		if d.GetClusterId() == clusterID && d.GetNamespace() == namespace && d.GetName() == name {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+// getProcessBaseline returns the baseline of the container targeted by the
+// spec, or nil if the deployment or its baseline do not exist.
+func (c *external) getProcessBaseline(ctx context.Context, in *v1alpha1.ProcessBaselineParameters) (*storage.ProcessBaseline, error) {
+	d, err := c.getDeployment(ctx, in.ClusterID, in.Namespace, in.DeploymentName)
+	if err != nil || d == nil {
+		return nil, err
+	}
+	baseline, err := c.svc.GetProcessBaseline(ctx, &v1.GetProcessBaselineRequest{Key: &storage.ProcessBaselineKey{
+		DeploymentId:  d.GetId(),
+		ContainerName: in.ContainerName,
+		ClusterId:     in.ClusterID,
+		Namespace:     in.Namespace,
+	}})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	return baseline, errors.Wrap(err, errGetFailed)
+}
+
+// updateError returns the first error of an update of the baselines.
+func updateError(resp *v1.UpdateProcessBaselinesResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if errs := resp.GetErrors(); len(errs) > 0 {
+		return errors.New(errs[0].GetError())
+	}
+	return nil
+}
+
+// apply reconciles the processes and then the lock of the observed baseline.
+// Declared processes are added to the baseline, all others are removed.
+func (c *external) apply(ctx context.Context, in *v1alpha1.ProcessBaselineParameters, observed *storage.ProcessBaseline) error {
+	keys := []*storage.ProcessBaselineKey{observed.GetKey()}
+
+	if len(in.Processes) > 0 {
+		req := &v1.UpdateProcessBaselinesRequest{Keys: keys}
+		declared := map[string]bool{}
+		for _, p := range in.Processes {
+			declared[p] = true
+		}
+		present := map[string]bool{}
+		for _, p := range processNames(observed.GetElements()) {
+			present[p] = true
+			if !declared[p] {
+				req.RemoveElements = append(req.RemoveElements, &storage.BaselineItem{Item: &storage.BaselineItem_ProcessName{ProcessName: p}})
+			}
+		}
+		for _, p := range in.Processes {
+			if !present[p] {
+				present[p] = true
+				req.AddElements = append(req.AddElements, &storage.BaselineItem{Item: &storage.BaselineItem_ProcessName{ProcessName: p}})
+			}
+		}
+		if len(req.AddElements) > 0 || len(req.RemoveElements) > 0 {
+			if err := updateError(c.svc.UpdateProcessBaselines(ctx, req)); err != nil {
+				return errors.Wrap(err, errUpdateElementsFailed)
+			}
+		}
+	}
+
+	if in.Locked != (observed.GetUserLockedTimestamp() != nil) {
+		err := updateError(c.svc.LockProcessBaselines(ctx, &v1.LockProcessBaselinesRequest{Keys: keys, Locked: in.Locked}))
+		return errors.Wrap(err, errLockFailed)
+	}
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ProcessBaseline)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotProcessBaseline)
+	}
+
+	baseline, err := c.getProcessBaseline(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if baseline == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	cr.Status.AtProvider = generateObservation(baseline)
+	// The baseline outlives the managed resource. Once Delete unlocked it
+	// there is nothing left to delete.
+	if meta.WasDeleted(cr) && baseline.GetUserLockedTimestamp() == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+	upToDate, diff := isUpToDate(&cr.Spec.ForProvider, baseline)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+// Create applies the spec to the baseline. Baselines are created by Central
+// once the container runs, so Create fails until the baseline exists.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ProcessBaseline)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotProcessBaseline)
+	}
+	in := &cr.Spec.ForProvider
+	cr.SetConditions(xpv1.Creating())
+
+	baseline, err := c.getProcessBaseline(ctx, in)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	if baseline == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.Errorf(errBaselineNotFound, in.ContainerName, in.Namespace, in.DeploymentName, in.ClusterID), errCreateFailed)
+	}
+	return managed.ExternalCreation{}, errors.Wrap(c.apply(ctx, in, baseline), errCreateFailed)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ProcessBaseline)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotProcessBaseline)
+	}
+
+	baseline, err := c.getProcessBaseline(ctx, &cr.Spec.ForProvider)
+	if err != nil || baseline == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	return managed.ExternalUpdate{}, errors.Wrap(c.apply(ctx, &cr.Spec.ForProvider, baseline), errUpdateFailed)
+}
+
+// Delete unlocks the baseline. Its processes are left in place.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ProcessBaseline)
+	if !ok {
+		return errors.New(errNotProcessBaseline)
+	}
+	cr.SetConditions(xpv1.Deleting())
+
+	baseline, err := c.getProcessBaseline(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return errors.Wrap(err, errDeleteFailed)
+	}
+	if baseline == nil || baseline.GetUserLockedTimestamp() == nil {
+		return nil
+	}
+	err = updateError(c.svc.LockProcessBaselines(ctx, &v1.LockProcessBaselinesRequest{
+		Keys:   []*storage.ProcessBaselineKey{baseline.GetKey()},
+		Locked: false,
+	}))
+	return errors.Wrap(err, errDeleteFailed)
+}
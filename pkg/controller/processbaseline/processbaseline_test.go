@@ -0,0 +1,322 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processbaseline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/processbaseline/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type fakeProcessBaselineService struct {
+	v1.ProcessBaselineServiceClient
+
+	MockGetProcessBaseline     func(ctx context.Context, in *v1.GetProcessBaselineRequest, opts ...grpc.CallOption) (*storage.ProcessBaseline, error)
+	MockUpdateProcessBaselines func(ctx context.Context, in *v1.UpdateProcessBaselinesRequest, opts ...grpc.CallOption) (*v1.UpdateProcessBaselinesResponse, error)
+	MockLockProcessBaselines   func(ctx context.Context, in *v1.LockProcessBaselinesRequest, opts ...grpc.CallOption) (*v1.UpdateProcessBaselinesResponse, error)
+}
+
+func (f *fakeProcessBaselineService) GetProcessBaseline(ctx context.Context, in *v1.GetProcessBaselineRequest, opts ...grpc.CallOption) (*storage.ProcessBaseline, error) {
+	return f.MockGetProcessBaseline(ctx, in, opts...)
+}
+
+func (f *fakeProcessBaselineService) UpdateProcessBaselines(ctx context.Context, in *v1.UpdateProcessBaselinesRequest, opts ...grpc.CallOption) (*v1.UpdateProcessBaselinesResponse, error) {
+	return f.MockUpdateProcessBaselines(ctx, in, opts...)
+}
+
+func (f *fakeProcessBaselineService) LockProcessBaselines(ctx context.Context, in *v1.LockProcessBaselinesRequest, opts ...grpc.CallOption) (*v1.UpdateProcessBaselinesResponse, error) {
+	return f.MockLockProcessBaselines(ctx, in, opts...)
+}
+
+type fakeDeploymentService struct {
+	v1.DeploymentServiceClient
+
+	MockListDeployments func(ctx context.Context, in *v1.RawQuery, opts ...grpc.CallOption) (*v1.ListDeploymentsResponse, error)
+}
+
+func (f *fakeDeploymentService) ListDeployments(ctx context.Context, in *v1.RawQuery, opts ...grpc.CallOption) (*v1.ListDeploymentsResponse, error) {
+	return f.MockListDeployments(ctx, in, opts...)
+}
+
+func withDeployments(deployments ...*storage.ListDeployment) *fakeDeploymentService {
+	return &fakeDeploymentService{
+		MockListDeployments: func(_ context.Context, _ *v1.RawQuery, _ ...grpc.CallOption) (*v1.ListDeploymentsResponse, error) {
+			return &v1.ListDeploymentsResponse{Deployments: deployments}, nil
+		},
+	}
+}
+
+var (
+	apiDeployment = &storage.ListDeployment{Id: "api-id", Name: "api", ClusterId: "remote-id", Namespace: "payments"}
+
+	key = &storage.ProcessBaselineKey{
+		DeploymentId:  "api-id",
+		ContainerName: "server",
+		ClusterId:     "remote-id",
+		Namespace:     "payments",
+	}
+)
+
+func withBaseline(b *storage.ProcessBaseline) *fakeProcessBaselineService {
+	return &fakeProcessBaselineService{
+		MockGetProcessBaseline: func(_ context.Context, _ *v1.GetProcessBaselineRequest, _ ...grpc.CallOption) (*storage.ProcessBaseline, error) {
+			if b == nil {
+				return nil, status.Error(codes.NotFound, "not found")
+			}
+			return b, nil
+		},
+	}
+}
+
+func element(name string, auto bool) *storage.BaselineElement {
+	return &storage.BaselineElement{Element: &storage.BaselineItem{Item: &storage.BaselineItem_ProcessName{ProcessName: name}}, Auto: auto}
+}
+
+func observedBaseline(userLocked *types.Timestamp) *storage.ProcessBaseline {
+	return &storage.ProcessBaseline{
+		Id:                      "baseline-id",
+		Key:                     key,
+		Elements:                []*storage.BaselineElement{element("/usr/bin/server", true), element("/bin/sh", true)},
+		UserLockedTimestamp:     userLocked,
+		StackRoxLockedTimestamp: &types.Timestamp{Seconds: 1672531200},
+	}
+}
+
+func processBaseline(locked bool, processes ...string) *v1alpha1.ProcessBaseline {
+	return &v1alpha1.ProcessBaseline{Spec: v1alpha1.ProcessBaselineSpec{ForProvider: v1alpha1.ProcessBaselineParameters{
+		ClusterID:      "remote-id",
+		Namespace:      "payments",
+		DeploymentName: "api",
+		ContainerName:  "server",
+		Locked:         locked,
+		Processes:      processes,
+	}}}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	locked := &types.Timestamp{Seconds: 1675000000}
+	observation := func(userLocked bool) v1alpha1.ProcessBaselineObservation {
+		return v1alpha1.ProcessBaselineObservation{
+			ID:                 "baseline-id",
+			DeploymentID:       "api-id",
+			UserLocked:         userLocked,
+			StackRoxLocked:     true,
+			StackRoxLockedTime: central.ToTime(&types.Timestamp{Seconds: 1672531200}),
+			Elements: []v1alpha1.BaselineElement{
+				{ProcessName: "/usr/bin/server", Auto: true},
+				{ProcessName: "/bin/sh", Auto: true},
+			},
+		}
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.ProcessBaselineObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeProcessBaselineService
+		cr     *v1alpha1.ProcessBaseline
+		want   want
+	}{
+		"GetFailed": {
+			reason: "Errors getting the process baseline should be returned.",
+			svc: &fakeProcessBaselineService{
+				MockGetProcessBaseline: func(_ context.Context, _ *v1.GetProcessBaselineRequest, _ ...grpc.CallOption) (*storage.ProcessBaseline, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   processBaseline(true),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A process baseline that was not generated yet should be reported as not existing.",
+			svc:    withBaseline(nil),
+			cr:     processBaseline(true),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A user locked baseline with the declared processes should be up to date.",
+			svc:    withBaseline(observedBaseline(locked)),
+			cr:     processBaseline(true, "/bin/sh", "/usr/bin/server"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: observation(true),
+			},
+		},
+		"NotLocked": {
+			reason: "A baseline that is not locked by a user should not be up to date if it should be locked.",
+			svc:    withBaseline(observedBaseline(nil)),
+			cr:     processBaseline(true),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs: observation(false),
+			},
+		},
+		"ProcessDrift": {
+			reason: "A baseline with different processes should not be up to date.",
+			svc:    withBaseline(observedBaseline(locked)),
+			cr:     processBaseline(true, "/usr/bin/server"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs: observation(true),
+			},
+		},
+		"UnlockedAfterDeletion": {
+			reason: "A deleted baseline should be reported as not existing once it is unlocked.",
+			svc:    withBaseline(observedBaseline(nil)),
+			cr: func() *v1alpha1.ProcessBaseline {
+				cr := processBaseline(true)
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				obs: observation(false),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc, deployments: withDeployments(apiDeployment)}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want observation, +got observation:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var updated *v1.UpdateProcessBaselinesRequest
+	var locked *v1.LockProcessBaselinesRequest
+	svc := withBaseline(observedBaseline(nil))
+	svc.MockUpdateProcessBaselines = func(_ context.Context, in *v1.UpdateProcessBaselinesRequest, _ ...grpc.CallOption) (*v1.UpdateProcessBaselinesResponse, error) {
+		updated = in
+		return &v1.UpdateProcessBaselinesResponse{}, nil
+	}
+	svc.MockLockProcessBaselines = func(_ context.Context, in *v1.LockProcessBaselinesRequest, _ ...grpc.CallOption) (*v1.UpdateProcessBaselinesResponse, error) {
+		locked = in
+		return &v1.UpdateProcessBaselinesResponse{}, nil
+	}
+	e := external{svc: svc, deployments: withDeployments(apiDeployment)}
+
+	if _, err := e.Update(context.Background(), processBaseline(true, "/usr/bin/server", "/usr/bin/healthcheck")); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+
+	wantUpdate := &v1.UpdateProcessBaselinesRequest{
+		Keys:           []*storage.ProcessBaselineKey{key},
+		AddElements:    []*storage.BaselineItem{element("/usr/bin/healthcheck", false).GetElement()},
+		RemoveElements: []*storage.BaselineItem{element("/bin/sh", false).GetElement()},
+	}
+	if diff := cmp.Diff(wantUpdate, updated); diff != "" {
+		t.Errorf("e.Update(...): -want update, +got update:\n%s\n", diff)
+	}
+	wantLock := &v1.LockProcessBaselinesRequest{Keys: []*storage.ProcessBaselineKey{key}, Locked: true}
+	if diff := cmp.Diff(wantLock, locked); diff != "" {
+		t.Errorf("e.Update(...): -want lock, +got lock:\n%s\n", diff)
+	}
+}
+
+func TestUpdateError(t *testing.T) {
+	svc := withBaseline(observedBaseline(nil))
+	svc.MockLockProcessBaselines = func(_ context.Context, _ *v1.LockProcessBaselinesRequest, _ ...grpc.CallOption) (*v1.UpdateProcessBaselinesResponse, error) {
+		return &v1.UpdateProcessBaselinesResponse{Errors: []*v1.ProcessBaselineUpdateError{{Error: "boom", Key: key}}}, nil
+	}
+	e := external{svc: svc, deployments: withDeployments(apiDeployment)}
+
+	_, err := e.Update(context.Background(), processBaseline(true))
+	want := errors.Wrap(errors.Wrap(errors.New("boom"), errLockFailed), errUpdateFailed)
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var unlocked *v1.LockProcessBaselinesRequest
+	svc := withBaseline(observedBaseline(types.TimestampNow()))
+	svc.MockLockProcessBaselines = func(_ context.Context, in *v1.LockProcessBaselinesRequest, _ ...grpc.CallOption) (*v1.UpdateProcessBaselinesResponse, error) {
+		unlocked = in
+		return &v1.UpdateProcessBaselinesResponse{}, nil
+	}
+	e := external{svc: svc, deployments: withDeployments(apiDeployment)}
+
+	if err := e.Delete(context.Background(), processBaseline(true)); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	want := &v1.LockProcessBaselinesRequest{Keys: []*storage.ProcessBaselineKey{key}, Locked: false}
+	if diff := cmp.Diff(want, unlocked); diff != "" {
+		t.Errorf("e.Delete(...): -want unlock, +got unlock:\n%s\n", diff)
+	}
+}
+
+func TestIsStackRoxLocked(t *testing.T) {
+	future, _ := types.TimestampProto(time.Now().Add(time.Hour))
+	cases := map[string]struct {
+		ts   *types.Timestamp
+		want bool
+	}{
+		"Unset":     {ts: nil, want: false},
+		"Observing": {ts: future, want: false},
+		"Locked":    {ts: &types.Timestamp{Seconds: 1672531200}, want: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := isStackRoxLocked(&storage.ProcessBaseline{StackRoxLockedTimestamp: tc.ts})
+			if got != tc.want {
+				t.Errorf("isStackRoxLocked(...): want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reportconfiguration
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/reportconfiguration/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotReportConfiguration = "managed resource is not a ReportConfiguration custom resource"
+	errTrackPCUsage           = "cannot track ProviderConfig usage"
+	errGetPC                  = "cannot get ProviderConfig"
+	errGetCreds               = "cannot get credentials"
+	errRunFailed              = "cannot run report"
+	errGetFailed              = "cannot get report configuration"
+	errObserveFailed          = "cannot observe report configuration"
+	errCreateFailed           = "cannot create report configuration"
+	errUpdateFailed           = "cannot update report configuration"
+	errDeleteFailed           = "cannot delete report configuration"
+)
+
+// Setup adds a controller that reconciles ReportConfiguration managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ReportConfigurationGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ReportConfigurationGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ReportConfiguration{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ReportConfiguration)
+	if !ok {
+		return nil, errors.New(errNotReportConfiguration)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{
+		svc:     v1.NewReportConfigurationServiceClient(client),
+		reports: v1.NewReportServiceClient(client),
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc     v1.ReportConfigurationServiceClient
+	reports v1.ReportServiceClient
+}
+
+// pendingRun returns the value of the run annotation if it has not been acted
+// on yet.
+func pendingRun(cr *v1alpha1.ReportConfiguration) string {
+	v := cr.GetAnnotations()[v1alpha1.AnnotationKeyRun]
+	if v == cr.Status.AtProvider.LastRun {
+		return ""
+	}
+	return v
+}
+
+func generateObservation(in *storage.ReportConfiguration, lastRun string) v1alpha1.ReportConfigurationObservation {
+	out := v1alpha1.ReportConfigurationObservation{
+		ID:                    in.GetId(),
+		Name:                  in.GetName(),
+		LastSuccessfulRunTime: central.ToTime(in.GetLastSuccessfulRunTime()),
+		LastRun:               lastRun,
+	}
+	if s := in.GetLastRunStatus(); s != nil {
+		out.LastRunStatus = &v1alpha1.LastRunStatus{
+			Status: s.GetReportStatus().String(),
+			Time:   central.ToTime(s.GetLastRunTime()),
+			Error:  s.GetErrorMsg(),
+		}
+	}
+	return out
+}
+
+func generateSchedule(in v1alpha1.Schedule) *storage.Schedule {
+	out := &storage.Schedule{
+		IntervalType: storage.Schedule_IntervalType(storage.Schedule_IntervalType_value[in.Interval]),
+		Hour:         in.Hour,
+		Minute:       in.Minute,
+	}
+	switch out.IntervalType {
+	case storage.Schedule_WEEKLY:
+		out.Interval = &storage.Schedule_DaysOfWeek_{DaysOfWeek: &storage.Schedule_DaysOfWeek{Days: in.DaysOfWeek}}
+	case storage.Schedule_MONTHLY:
+		out.Interval = &storage.Schedule_DaysOfMonth_{DaysOfMonth: &storage.Schedule_DaysOfMonth{Days: in.DaysOfMonth}}
+	}
+	return out
+}
+
+func generateReportConfiguration(in *v1alpha1.ReportConfigurationParameters, base *storage.ReportConfiguration) *storage.ReportConfiguration {
+	if base == nil {
+		base = &storage.ReportConfiguration{}
+	}
+	severities := make([]storage.VulnerabilitySeverity, 0, len(in.VulnReportFilters.Severities))
+	for _, s := range in.VulnReportFilters.Severities {
+		severities = append(severities, storage.VulnerabilitySeverity(storage.VulnerabilitySeverity_value[string(s)]))
+	}
+	base.Name = in.Name
+	base.Description = in.Description
+	base.Type = storage.ReportConfiguration_VULNERABILITY
+	base.Filter = &storage.ReportConfiguration_VulnReportFilters{VulnReportFilters: &storage.VulnerabilityReportFilters{
+		Fixability:      storage.VulnerabilityReportFilters_Fixability(storage.VulnerabilityReportFilters_Fixability_value[in.VulnReportFilters.Fixability]),
+		SinceLastReport: in.VulnReportFilters.SinceLastReport,
+		Severities:      severities,
+	}}
+	base.ScopeId = in.ScopeID
+	base.NotifierConfig = &storage.ReportConfiguration_EmailConfig{EmailConfig: &storage.EmailNotifierConfiguration{
+		NotifierId:   in.EmailConfig.NotifierID,
+		MailingLists: in.EmailConfig.MailingLists,
+	}}
+	base.Schedule = generateSchedule(in.Schedule)
+	return base
+}
+
+// generateReportConfigurationParameters converts an observed report
+// configuration into parameters. References and selectors only exist in the
+// spec, so they are taken from the supplied spec.
+func generateReportConfigurationParameters(in *storage.ReportConfiguration, spec *v1alpha1.ReportConfigurationParameters) v1alpha1.ReportConfigurationParameters {
+	filters := in.GetVulnReportFilters()
+	var severities []v1alpha1.Severity
+	for _, s := range filters.GetSeverities() {
+		severities = append(severities, v1alpha1.Severity(s.String()))
+	}
+	return v1alpha1.ReportConfigurationParameters{
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+		VulnReportFilters: v1alpha1.VulnReportFilters{
+			Fixability:      filters.GetFixability().String(),
+			SinceLastReport: filters.GetSinceLastReport(),
+			Severities:      severities,
+		},
+		Schedule: v1alpha1.Schedule{
+			Interval:    in.GetSchedule().GetIntervalType().String(),
+			Hour:        in.GetSchedule().GetHour(),
+			Minute:      in.GetSchedule().GetMinute(),
+			DaysOfWeek:  in.GetSchedule().GetDaysOfWeek().GetDays(),
+			DaysOfMonth: in.GetSchedule().GetDaysOfMonth().GetDays(),
+		},
+		ScopeID:         in.GetScopeId(),
+		ScopeIDRef:      spec.ScopeIDRef,
+		ScopeIDSelector: spec.ScopeIDSelector,
+		EmailConfig: v1alpha1.EmailConfig{
+			NotifierID:         in.GetEmailConfig().GetNotifierId(),
+			NotifierIDRef:      spec.EmailConfig.NotifierIDRef,
+			NotifierIDSelector: spec.EmailConfig.NotifierIDSelector,
+			MailingLists:       in.GetEmailConfig().GetMailingLists(),
+		},
+	}
+}
+
+func isUpToDate(in *v1alpha1.ReportConfiguration, observed *storage.ReportConfiguration) (bool, string) {
+	observedParams := generateReportConfigurationParameters(observed, &in.Spec.ForProvider)
+	if diff := cmp.Diff(in.Spec.ForProvider, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in report configuration\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getReportConfiguration(ctx context.Context, cr *v1alpha1.ReportConfiguration) (*storage.ReportConfiguration, error) {
+	query := &v1.RawQuery{Query: "Report Name:\"" + cr.Spec.ForProvider.Name + "\""}
+	resp, err := c.svc.GetReportConfigurations(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetReportConfigs() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			return it, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ReportConfiguration)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotReportConfiguration)
+	}
+
+	report, err := c.getReportConfiguration(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if report == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	lastRun := cr.Status.AtProvider.LastRun
+	if lastRun == "" {
+		// A run consumed on creation is only recorded in an annotation,
+		// because the status written by Create is not persisted.
+		lastRun = cr.GetAnnotations()[v1alpha1.AnnotationKeyConsumedRun]
+	}
+	cr.Status.AtProvider = generateObservation(report, lastRun)
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, report.GetName())
+	upToDate, diff := isUpToDate(cr, report)
+	if r := pendingRun(cr); r != "" {
+		upToDate = false
+		diff += "Pending report run " + r + "\n"
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ReportConfiguration)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotReportConfiguration)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	req := &v1.PostReportConfigurationRequest{ReportConfig: generateReportConfiguration(&cr.Spec.ForProvider, nil)}
+	resp, err := c.svc.PostReportConfiguration(ctx, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	// A freshly created report has nothing to catch up on, so a run that is
+	// already requested must not fire right away.
+	if r := cr.GetAnnotations()[v1alpha1.AnnotationKeyRun]; r != "" {
+		meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyConsumedRun: r})
+	}
+	meta.SetExternalName(cr, resp.GetReportConfig().GetName())
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ReportConfiguration)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotReportConfiguration)
+	}
+
+	report, err := c.getReportConfiguration(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if report == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	if upToDate, _ := isUpToDate(cr, report); !upToDate {
+		req := &v1.UpdateReportConfigurationRequest{
+			Id:           report.GetId(),
+			ReportConfig: generateReportConfiguration(&cr.Spec.ForProvider, report),
+		}
+		if _, err := c.svc.UpdateReportConfiguration(ctx, req); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+		}
+	}
+
+	if r := pendingRun(cr); r != "" {
+		if _, err := c.reports.RunReport(ctx, &v1.ResourceByID{Id: report.GetId()}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRunFailed)
+		}
+		cr.Status.AtProvider.LastRun = r
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ReportConfiguration)
+	if !ok {
+		return errors.New(errNotReportConfiguration)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeleteReportConfiguration(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
@@ -0,0 +1,335 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reportconfiguration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/reportconfiguration/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type fakeReportConfigurationService struct {
+	v1.ReportConfigurationServiceClient
+
+	MockGetReportConfigurations   func(ctx context.Context, in *v1.RawQuery, opts ...grpc.CallOption) (*v1.GetReportConfigurationsResponse, error)
+	MockPostReportConfiguration   func(ctx context.Context, in *v1.PostReportConfigurationRequest, opts ...grpc.CallOption) (*v1.PostReportConfigurationResponse, error)
+	MockUpdateReportConfiguration func(ctx context.Context, in *v1.UpdateReportConfigurationRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeleteReportConfiguration func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeReportConfigurationService) GetReportConfigurations(ctx context.Context, in *v1.RawQuery, opts ...grpc.CallOption) (*v1.GetReportConfigurationsResponse, error) {
+	return f.MockGetReportConfigurations(ctx, in, opts...)
+}
+
+func (f *fakeReportConfigurationService) PostReportConfiguration(ctx context.Context, in *v1.PostReportConfigurationRequest, opts ...grpc.CallOption) (*v1.PostReportConfigurationResponse, error) {
+	return f.MockPostReportConfiguration(ctx, in, opts...)
+}
+
+func (f *fakeReportConfigurationService) UpdateReportConfiguration(ctx context.Context, in *v1.UpdateReportConfigurationRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockUpdateReportConfiguration(ctx, in, opts...)
+}
+
+func (f *fakeReportConfigurationService) DeleteReportConfiguration(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteReportConfiguration(ctx, in, opts...)
+}
+
+type fakeReportService struct {
+	v1.ReportServiceClient
+
+	MockRunReport func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeReportService) RunReport(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockRunReport(ctx, in, opts...)
+}
+
+func reportConfiguration(run, lastRun string) *v1alpha1.ReportConfiguration {
+	cr := &v1alpha1.ReportConfiguration{Spec: v1alpha1.ReportConfigurationSpec{ForProvider: v1alpha1.ReportConfigurationParameters{
+		Name: "payments",
+		VulnReportFilters: v1alpha1.VulnReportFilters{
+			Fixability: "FIXABLE",
+			Severities: []v1alpha1.Severity{"IMPORTANT_VULNERABILITY_SEVERITY", "CRITICAL_VULNERABILITY_SEVERITY"},
+		},
+		Schedule:   v1alpha1.Schedule{Interval: "WEEKLY", Hour: 6, DaysOfWeek: []int32{1}},
+		ScopeID:    "scope",
+		ScopeIDRef: &xpv1.Reference{Name: "payments"},
+		EmailConfig: v1alpha1.EmailConfig{
+			NotifierID:    "notifier",
+			NotifierIDRef: &xpv1.Reference{Name: "email"},
+			MailingLists:  []string{"payments@example.com"},
+		},
+	}}}
+	if run != "" {
+		cr.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyRun: run})
+	}
+	cr.Status.AtProvider.LastRun = lastRun
+	return cr
+}
+
+func withReportConfigurations(r ...*storage.ReportConfiguration) *fakeReportConfigurationService {
+	return &fakeReportConfigurationService{
+		MockGetReportConfigurations: func(_ context.Context, _ *v1.RawQuery, _ ...grpc.CallOption) (*v1.GetReportConfigurationsResponse, error) {
+			return &v1.GetReportConfigurationsResponse{ReportConfigs: r}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	runTime := time.Date(2022, 11, 7, 6, 0, 0, 0, time.UTC)
+	ts, _ := types.TimestampProto(runTime)
+	observed := generateReportConfiguration(&reportConfiguration("", "").Spec.ForProvider, &storage.ReportConfiguration{
+		Id: "id",
+		LastRunStatus: &storage.ReportLastRunStatus{
+			ReportStatus: storage.ReportLastRunStatus_FAILURE,
+			LastRunTime:  ts,
+			ErrorMsg:     "smtp unavailable",
+		},
+	})
+
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.ReportConfigurationObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeReportConfigurationService
+		cr     *v1alpha1.ReportConfiguration
+		want   want
+	}{
+		"GetFailed": {
+			reason: "Errors listing report configurations should be returned.",
+			svc: &fakeReportConfigurationService{
+				MockGetReportConfigurations: func(_ context.Context, _ *v1.RawQuery, _ ...grpc.CallOption) (*v1.GetReportConfigurationsResponse, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   reportConfiguration("", ""),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A report configuration that does not exist in Central should be reported as not existing.",
+			svc:    withReportConfigurations(),
+			cr:     reportConfiguration("", ""),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A report configuration matching the spec should be up to date and report its last run status.",
+			svc:    withReportConfigurations(observed),
+			cr:     reportConfiguration("", ""),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.ReportConfigurationObservation{
+					ID:   "id",
+					Name: "payments",
+					LastRunStatus: &v1alpha1.LastRunStatus{
+						Status: "FAILURE",
+						Time:   &metav1.Time{Time: runTime},
+						Error:  "smtp unavailable",
+					},
+				},
+			},
+		},
+		"PendingRun": {
+			reason: "A pending run should be reported as not up to date, so that Update runs the report.",
+			svc:    withReportConfigurations(observed),
+			cr:     reportConfiguration("2022-11-08T10:00:00Z", ""),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs: v1alpha1.ReportConfigurationObservation{
+					ID:   "id",
+					Name: "payments",
+					LastRunStatus: &v1alpha1.LastRunStatus{
+						Status: "FAILURE",
+						Time:   &metav1.Time{Time: runTime},
+						Error:  "smtp unavailable",
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want observation, +got observation:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	var posted, created *storage.ReportConfiguration
+	cr := reportConfiguration("2022-11-08T10:00:00Z", "")
+	e := external{svc: &fakeReportConfigurationService{
+		MockPostReportConfiguration: func(_ context.Context, in *v1.PostReportConfigurationRequest, _ ...grpc.CallOption) (*v1.PostReportConfigurationResponse, error) {
+			posted = in.GetReportConfig()
+			out := *in.GetReportConfig()
+			out.Id = "id"
+			created = &out
+			return &v1.PostReportConfigurationResponse{ReportConfig: &out}, nil
+		},
+		MockGetReportConfigurations: func(_ context.Context, _ *v1.RawQuery, _ ...grpc.CallOption) (*v1.GetReportConfigurationsResponse, error) {
+			return &v1.GetReportConfigurationsResponse{ReportConfigs: []*storage.ReportConfiguration{created}}, nil
+		},
+	}}
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	want := &storage.ReportConfiguration{
+		Name: "payments",
+		Type: storage.ReportConfiguration_VULNERABILITY,
+		Filter: &storage.ReportConfiguration_VulnReportFilters{VulnReportFilters: &storage.VulnerabilityReportFilters{
+			Fixability: storage.VulnerabilityReportFilters_FIXABLE,
+			Severities: []storage.VulnerabilitySeverity{storage.VulnerabilitySeverity_IMPORTANT_VULNERABILITY_SEVERITY, storage.VulnerabilitySeverity_CRITICAL_VULNERABILITY_SEVERITY},
+		}},
+		ScopeId: "scope",
+		NotifierConfig: &storage.ReportConfiguration_EmailConfig{EmailConfig: &storage.EmailNotifierConfiguration{
+			NotifierId:   "notifier",
+			MailingLists: []string{"payments@example.com"},
+		}},
+		Schedule: &storage.Schedule{
+			IntervalType: storage.Schedule_WEEKLY,
+			Hour:         6,
+			Interval:     &storage.Schedule_DaysOfWeek_{DaysOfWeek: &storage.Schedule_DaysOfWeek{Days: []int32{1}}},
+		},
+	}
+	if diff := cmp.Diff(want, posted); diff != "" {
+		t.Errorf("e.Create(...): -want request, +got request:\n%s\n", diff)
+	}
+
+	// The reconciler only persists the metadata of the managed resource after
+	// Create, so the next reconcile observes it without the status.
+	reread := &v1alpha1.ReportConfiguration{ObjectMeta: *cr.ObjectMeta.DeepCopy(), Spec: *cr.Spec.DeepCopy()}
+	o, err := e.Observe(context.Background(), reread)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if !o.ResourceUpToDate {
+		t.Errorf("e.Observe(...): want run requested before creation to be consumed, got diff:\n%s", o.Diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	inSync := generateReportConfiguration(&reportConfiguration("", "").Spec.ForProvider, &storage.ReportConfiguration{Id: "id"})
+	drifted := generateReportConfiguration(&reportConfiguration("", "").Spec.ForProvider, &storage.ReportConfiguration{Id: "id"})
+	drifted.GetEmailConfig().MailingLists = []string{"someone@example.com"}
+
+	type want struct {
+		updates int
+		runs    int
+		lastRun string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *storage.ReportConfiguration
+		cr       *v1alpha1.ReportConfiguration
+		want     want
+	}{
+		"RunOnly": {
+			reason:   "A pending run of a report configuration without drift should only run the report.",
+			observed: inSync,
+			cr:       reportConfiguration("2022-11-08T10:00:00Z", "2022-11-01T10:00:00Z"),
+			want:     want{runs: 1, lastRun: "2022-11-08T10:00:00Z"},
+		},
+		"DriftOnly": {
+			reason:   "Drift without pending run should only update the report configuration.",
+			observed: drifted,
+			cr:       reportConfiguration("2022-11-01T10:00:00Z", "2022-11-01T10:00:00Z"),
+			want:     want{updates: 1, lastRun: "2022-11-01T10:00:00Z"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updates, runs := 0, 0
+			svc := withReportConfigurations(tc.observed)
+			svc.MockUpdateReportConfiguration = func(_ context.Context, in *v1.UpdateReportConfigurationRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+				updates++
+				return &v1.Empty{}, nil
+			}
+			e := external{svc: svc, reports: &fakeReportService{
+				MockRunReport: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+					runs++
+					return &v1.Empty{}, nil
+				},
+			}}
+
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			got := want{updates: updates, runs: runs, lastRun: tc.cr.Status.AtProvider.LastRun}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var deleted string
+	cr := reportConfiguration("", "")
+	cr.Status.AtProvider.ID = "id"
+	e := external{svc: &fakeReportConfigurationService{
+		MockDeleteReportConfiguration: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+			deleted = in.GetId()
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if deleted != "id" {
+		t.Errorf("e.Delete(...): want report configuration %q deleted, got %q", "id", deleted)
+	}
+}
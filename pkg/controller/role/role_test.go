@@ -0,0 +1,304 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/role/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func TestIsUpToDate(t *testing.T) {
+	spec := v1alpha1.RoleParameters{
+		Name:               "analyst",
+		PermissionSetID:    "ps-1",
+		PermissionSetIDRef: &xpv1.Reference{Name: "analyst"},
+		AccessScopeID:      "as-1",
+		AccessScopeIDRef:   &xpv1.Reference{Name: "prod"},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *storage.Role
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "References that only exist in the spec should not cause drift.",
+			observed: &storage.Role{Name: "analyst", PermissionSetId: "ps-1", AccessScopeId: "as-1"},
+			want:     true,
+		},
+		"AccessScopeChanged": {
+			reason:   "A different access scope should cause drift.",
+			observed: &storage.Role{Name: "analyst", PermissionSetId: "ps-1", AccessScopeId: "as-2"},
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Role{Spec: v1alpha1.RoleSpec{ForProvider: spec}}
+			got, diff := isUpToDate(cr, tc.observed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s\n%s\n", tc.reason, d, diff)
+			}
+		})
+	}
+}
+
+func role() *v1alpha1.Role {
+	return &v1alpha1.Role{Spec: v1alpha1.RoleSpec{ForProvider: v1alpha1.RoleParameters{
+		Name:            "analyst",
+		PermissionSetID: "ps-1",
+		AccessScopeID:   "as-1",
+	}}}
+}
+
+func withRoles(r ...*storage.Role) *fake.RoleService {
+	return &fake.RoleService{
+		MockGetRoles: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.GetRolesResponse, error) {
+			return &v1.GetRolesResponse{Roles: r}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.RoleService
+		mg     resource.Managed
+		want   want
+	}{
+		"NotRole": {
+			reason: "An error should be returned if the managed resource is not a Role.",
+			svc:    &fake.RoleService{},
+			want:   want{err: errors.New(errNotRole)},
+		},
+		"GetFailed": {
+			reason: "Errors listing roles should be returned.",
+			svc: &fake.RoleService{
+				MockGetRoles: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.GetRolesResponse, error) {
+					return nil, errBoom
+				},
+			},
+			mg:   role(),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A role that does not exist in Central should be reported as not existing.",
+			svc:    withRoles(&storage.Role{Name: "Admin"}),
+			mg:     role(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A role matching the spec should be reported as up to date.",
+			svc:    withRoles(generateRole(&role().Spec.ForProvider, nil)),
+			mg:     role(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		request string
+		status  string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors creating the role should be returned.",
+			err:    errBoom,
+			want:   want{request: "analyst", err: errors.Wrap(errBoom, errCreateFailed)},
+		},
+		"Success": {
+			reason: "The role should be created by name and its name recorded in the status.",
+			want:   want{request: "analyst", status: "analyst"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created *v1.CreateRoleRequest
+			cr := role()
+			e := external{svc: &fake.RoleService{
+				MockCreateRole: func(_ context.Context, in *v1.CreateRoleRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+					created = in
+					return &v1.Empty{}, tc.err
+				},
+			}}
+
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.request, created.GetName()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want created role, +got created role:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff("ps-1", created.GetRole().GetPermissionSetId()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want permission set, +got permission set:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, cr.Status.AtProvider.Name); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want name in status, +got name in status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		accessScopeID string
+		err           error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed []*storage.Role
+		err      error
+		want     want
+	}{
+		"NotFound": {
+			reason: "A role that no longer exists should not be updated.",
+			want:   want{},
+		},
+		"UpdateFailed": {
+			reason:   "Errors updating the role should be returned.",
+			observed: []*storage.Role{{Name: "analyst", PermissionSetId: "ps-1", AccessScopeId: "as-2"}},
+			err:      errBoom,
+			want:     want{accessScopeID: "as-1", err: errors.Wrap(errBoom, errUpdateFailed)},
+		},
+		"Success": {
+			reason:   "The observed role should be updated to the access scope of the spec.",
+			observed: []*storage.Role{{Name: "analyst", PermissionSetId: "ps-1", AccessScopeId: "as-2"}},
+			want:     want{accessScopeID: "as-1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated *storage.Role
+			svc := withRoles(tc.observed...)
+			svc.MockUpdateRole = func(_ context.Context, in *storage.Role, _ ...grpc.CallOption) (*v1.Empty, error) {
+				updated = in
+				return &v1.Empty{}, tc.err
+			}
+			e := external{svc: svc}
+
+			_, err := e.Update(context.Background(), role())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.accessScopeID, updated.GetAccessScopeId()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want access scope, +got access scope:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		deleted string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"DeleteFailed": {
+			reason: "Errors deleting the role should be returned.",
+			err:    errBoom,
+			want:   want{deleted: "analyst", err: errors.Wrap(errBoom, errDeleteFailed)},
+		},
+		"Success": {
+			reason: "The role should be deleted by name.",
+			want:   want{deleted: "analyst"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted string
+			e := external{svc: &fake.RoleService{
+				MockDeleteRole: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+					deleted = in.GetId()
+					return &v1.Empty{}, tc.err
+				},
+			}}
+
+			err := e.Delete(context.Background(), role())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want deleted role, +got deleted role:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
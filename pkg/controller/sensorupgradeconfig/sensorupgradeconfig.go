@@ -0,0 +1,278 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sensorupgradeconfig
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/sensorupgradeconfig/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotSensorUpgradeConfig = "managed resource is not a SensorUpgradeConfig custom resource"
+	errTrackPCUsage           = "cannot track ProviderConfig usage"
+	errGetPC                  = "cannot get ProviderConfig"
+	errGetCreds               = "cannot get credentials"
+	errGetFailed              = "cannot get sensor upgrade config"
+	errPutFailed              = "cannot update sensor upgrade config"
+	errPreviousConfig         = "cannot parse previous sensor upgrade config"
+	errObserveFailed          = "cannot observe sensor upgrade config"
+	errCreateFailed           = "cannot create sensor upgrade config"
+	errUpdateFailed           = "cannot update sensor upgrade config"
+	errDeleteFailed           = "cannot delete sensor upgrade config"
+	errRecordPreviousValue    = "cannot record previous sensor upgrade config"
+)
+
+// Setup adds a controller that reconciles SensorUpgradeConfig managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.SensorUpgradeConfigGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SensorUpgradeConfigGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		// The configuration is a singleton without a name of its own.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.SensorUpgradeConfig{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.SensorUpgradeConfig)
+	if !ok {
+		return nil, errors.New(errNotSensorUpgradeConfig)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{
+		svc: v1.NewSensorUpgradeServiceClient(client),
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc v1.SensorUpgradeServiceClient
+}
+
+func generateSensorUpgradeConfigParameters(in *v1.GetSensorUpgradeConfigResponse_UpgradeConfig) v1alpha1.SensorUpgradeConfigParameters {
+	return v1alpha1.SensorUpgradeConfigParameters{EnableAutoUpgrade: in.GetEnableAutoUpgrade()}
+}
+
+func generateObservation(in *v1.GetSensorUpgradeConfigResponse_UpgradeConfig) v1alpha1.SensorUpgradeConfigObservation {
+	return v1alpha1.SensorUpgradeConfigObservation{
+		EnableAutoUpgrade:  in.GetEnableAutoUpgrade(),
+		AutoUpgradeFeature: in.GetAutoUpgradeFeature().String(),
+	}
+}
+
+func isUpToDate(spec, observed *v1alpha1.SensorUpgradeConfigParameters) (bool, string) {
+	if diff := cmp.Diff(*spec, *observed); diff != "" {
+		diff = "Observed difference in sensor upgrade config\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+// previousConfig returns the recorded previous configuration and whether it
+// was recorded.
+func previousConfig(cr *v1alpha1.SensorUpgradeConfig) (v1alpha1.SensorUpgradeConfigParameters, bool, error) {
+	prev := v1alpha1.SensorUpgradeConfigParameters{}
+	v, ok := cr.GetAnnotations()[v1alpha1.AnnotationKeyPreviousConfig]
+	if !ok {
+		return prev, false, nil
+	}
+	err := json.Unmarshal([]byte(v), &prev)
+	return prev, true, errors.Wrap(err, errPreviousConfig)
+}
+
+func (c *external) getConfig(ctx context.Context) (*v1.GetSensorUpgradeConfigResponse_UpgradeConfig, error) {
+	resp, err := c.svc.GetSensorUpgradeConfig(ctx, &v1.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	return resp.GetConfig(), nil
+}
+
+func (c *external) putConfig(ctx context.Context, in *v1alpha1.SensorUpgradeConfigParameters) error {
+	_, err := c.svc.UpdateSensorUpgradeConfig(ctx, &v1.UpdateSensorUpgradeConfigRequest{
+		Config: &storage.SensorUpgradeConfig{EnableAutoUpgrade: in.EnableAutoUpgrade},
+	})
+	return errors.Wrap(err, errPutFailed)
+}
+
+// Observe reports the configuration as not existing until the previous
+// configuration is recorded, so that Create records it before it changes
+// anything.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SensorUpgradeConfig)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSensorUpgradeConfig)
+	}
+
+	cfg, err := c.getConfig(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	cr.Status.AtProvider = generateObservation(cfg)
+	observed := generateSensorUpgradeConfigParameters(cfg)
+
+	prev, recorded, err := previousConfig(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if !recorded {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if meta.WasDeleted(cr) {
+		// Once Delete restored the previous configuration there is nothing
+		// left to delete.
+		if restored, _ := isUpToDate(&prev, &observed); restored {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
+
+	cr.SetConditions(xpv1.Available())
+	upToDate, diff := isUpToDate(&cr.Spec.ForProvider, &observed)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+// Create records the previous configuration and then applies the spec. The
+// record is kept in an annotation, because the managed reconciler persists
+// annotations, but not the status, set by Create.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SensorUpgradeConfig)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSensorUpgradeConfig)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	cfg, err := c.getConfig(ctx)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	b, err := json.Marshal(generateSensorUpgradeConfigParameters(cfg))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRecordPreviousValue)
+	}
+
+	if err := c.putConfig(ctx, &cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyPreviousConfig: string(b)})
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SensorUpgradeConfig)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSensorUpgradeConfig)
+	}
+
+	err := c.putConfig(ctx, &cr.Spec.ForProvider)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+// Delete restores the recorded previous configuration. It is not called for
+// the Orphan deletion policy, which leaves the current configuration in
+// place.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SensorUpgradeConfig)
+	if !ok {
+		return errors.New(errNotSensorUpgradeConfig)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	prev, recorded, err := previousConfig(cr)
+	if err != nil || !recorded {
+		return errors.Wrap(err, errDeleteFailed)
+	}
+	return errors.Wrap(c.putConfig(ctx, &prev), errDeleteFailed)
+}
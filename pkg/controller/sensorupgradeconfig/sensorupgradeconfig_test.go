@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sensorupgradeconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/sensorupgradeconfig/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type fakeSensorUpgradeService struct {
+	v1.SensorUpgradeServiceClient
+
+	MockGetSensorUpgradeConfig    func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.GetSensorUpgradeConfigResponse, error)
+	MockUpdateSensorUpgradeConfig func(ctx context.Context, in *v1.UpdateSensorUpgradeConfigRequest, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeSensorUpgradeService) GetSensorUpgradeConfig(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.GetSensorUpgradeConfigResponse, error) {
+	return f.MockGetSensorUpgradeConfig(ctx, in, opts...)
+}
+
+func (f *fakeSensorUpgradeService) UpdateSensorUpgradeConfig(ctx context.Context, in *v1.UpdateSensorUpgradeConfigRequest, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockUpdateSensorUpgradeConfig(ctx, in, opts...)
+}
+
+// withAutoUpgrade returns a sensor upgrade service that stores the last
+// updated setting.
+func withAutoUpgrade(enabled bool) *fakeSensorUpgradeService {
+	return &fakeSensorUpgradeService{
+		MockGetSensorUpgradeConfig: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.GetSensorUpgradeConfigResponse, error) {
+			return &v1.GetSensorUpgradeConfigResponse{Config: &v1.GetSensorUpgradeConfigResponse_UpgradeConfig{
+				EnableAutoUpgrade:  enabled,
+				AutoUpgradeFeature: v1.GetSensorUpgradeConfigResponse_SUPPORTED,
+			}}, nil
+		},
+		MockUpdateSensorUpgradeConfig: func(_ context.Context, in *v1.UpdateSensorUpgradeConfigRequest, _ ...grpc.CallOption) (*v1.Empty, error) {
+			enabled = in.GetConfig().GetEnableAutoUpgrade()
+			return &v1.Empty{}, nil
+		},
+	}
+}
+
+func sensorUpgradeConfig(enabled bool, previous string) *v1alpha1.SensorUpgradeConfig {
+	cr := &v1alpha1.SensorUpgradeConfig{Spec: v1alpha1.SensorUpgradeConfigSpec{ForProvider: v1alpha1.SensorUpgradeConfigParameters{
+		EnableAutoUpgrade: enabled,
+	}}}
+	if previous != "" {
+		cr.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyPreviousConfig: previous})
+	}
+	return cr
+}
+
+const enabledPrevious = `{"enableAutoUpgrade":true}`
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.SensorUpgradeConfigObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeSensorUpgradeService
+		cr     *v1alpha1.SensorUpgradeConfig
+		want   want
+	}{
+		"GetFailed": {
+			reason: "Errors getting the config should be returned.",
+			svc: &fakeSensorUpgradeService{
+				MockGetSensorUpgradeConfig: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.GetSensorUpgradeConfigResponse, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   sensorUpgradeConfig(false, ""),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotRecorded": {
+			reason: "A config without recorded previous value should be reported as not existing.",
+			svc:    withAutoUpgrade(true),
+			cr:     sensorUpgradeConfig(false, ""),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				obs: v1alpha1.SensorUpgradeConfigObservation{EnableAutoUpgrade: true, AutoUpgradeFeature: "SUPPORTED"},
+			},
+		},
+		"Drifted": {
+			reason: "A recorded config that differs from the spec should be reported as not up to date.",
+			svc:    withAutoUpgrade(true),
+			cr:     sensorUpgradeConfig(false, enabledPrevious),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs: v1alpha1.SensorUpgradeConfigObservation{EnableAutoUpgrade: true, AutoUpgradeFeature: "SUPPORTED"},
+			},
+		},
+		"UpToDate": {
+			reason: "A recorded config that matches the spec should be reported as up to date.",
+			svc:    withAutoUpgrade(false),
+			cr:     sensorUpgradeConfig(false, enabledPrevious),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.SensorUpgradeConfigObservation{AutoUpgradeFeature: "SUPPORTED"},
+			},
+		},
+		"Restored": {
+			reason: "A deleted config whose previous value is restored should be reported as not existing.",
+			svc:    withAutoUpgrade(true),
+			cr: func() *v1alpha1.SensorUpgradeConfig {
+				cr := sensorUpgradeConfig(false, enabledPrevious)
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				obs: v1alpha1.SensorUpgradeConfigObservation{EnableAutoUpgrade: true, AutoUpgradeFeature: "SUPPORTED"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want observation, +got observation:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	svc := withAutoUpgrade(true)
+	e := external{svc: svc}
+	cr := sensorUpgradeConfig(false, "")
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	if diff := cmp.Diff(enabledPrevious, cr.GetAnnotations()[v1alpha1.AnnotationKeyPreviousConfig]); diff != "" {
+		t.Errorf("e.Create(...): -want previous config, +got previous config:\n%s\n", diff)
+	}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s\n", diff)
+	}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if got, _ := svc.GetSensorUpgradeConfig(context.Background(), &v1.Empty{}); !got.GetConfig().GetEnableAutoUpgrade() {
+		t.Errorf("e.Delete(...): want auto upgrade enabled again")
+	}
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signatureintegration
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/stehessel/provider-stackrox/apis/signatureintegration/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotSignatureIntegration = "managed resource is not a SignatureIntegration custom resource"
+	errTrackPCUsage            = "cannot track ProviderConfig usage"
+	errGetPC                   = "cannot get ProviderConfig"
+	errGetCreds                = "cannot get credentials"
+	errGetSecret               = "cannot get public key secret"
+	errGetConfigMap            = "cannot get public key config map"
+	errNoConfigMapKey          = "public key config map has no such key"
+	errNoPublicKey             = "exactly one of publicKeyPEM, publicKeyPEMConfigMapRef and publicKeyPEMSecretRef must be set"
+	errGetFailed               = "cannot get signature integration"
+	errObserveFailed           = "cannot observe signature integration"
+	errCreateFailed            = "cannot create signature integration"
+	errUpdateFailed            = "cannot update signature integration"
+	errDeleteFailed            = "cannot delete signature integration"
+)
+
+// Setup adds a controller that reconciles SignatureIntegration managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.SignatureIntegrationGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SignatureIntegrationGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			pool:  central.Connections,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.SignatureIntegration{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	pool  *central.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.SignatureIntegration)
+	if !ok {
+		return nil, errors.New(errNotSignatureIntegration)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	if err != nil {
+		return nil, errors.Wrap(err, central.ErrNewClient)
+	}
+	return &external{kube: c.kube, svc: v1.NewSignatureIntegrationServiceClient(client)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube client.Client
+	svc  v1.SignatureIntegrationServiceClient
+}
+
+// getPublicKeyPEM returns the PEM of a public key from whichever source is set.
+func (c *external) getPublicKeyPEM(ctx context.Context, in v1alpha1.CosignPublicKey) (string, error) {
+	sources := 0
+	for _, set := range []bool{in.PublicKeyPEM != "", in.PublicKeyPEMConfigMapRef != nil, in.PublicKeyPEMSecretRef != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return "", errors.New(errNoPublicKey)
+	}
+
+	switch {
+	case in.PublicKeyPEMSecretRef != nil:
+		v, err := resource.ExtractSecret(ctx, c.kube, xpv1.CommonCredentialSelectors{SecretRef: in.PublicKeyPEMSecretRef})
+		return string(v), errors.Wrap(err, errGetSecret)
+	case in.PublicKeyPEMConfigMapRef != nil:
+		ref := in.PublicKeyPEMConfigMapRef
+		cm := &corev1.ConfigMap{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return "", errors.Wrap(err, errGetConfigMap)
+		}
+		v, ok := cm.Data[ref.Key]
+		if !ok {
+			return "", errors.Errorf("%s: %s", errNoConfigMapKey, ref.Key)
+		}
+		return v, nil
+	}
+	return in.PublicKeyPEM, nil
+}
+
+// resolve returns a copy of the parameters with all public keys inlined, so
+// that they can be compared with and sent to Central.
+func (c *external) resolve(ctx context.Context, in *v1alpha1.SignatureIntegrationParameters) (*v1alpha1.SignatureIntegrationParameters, error) {
+	out := &v1alpha1.SignatureIntegrationParameters{
+		Name:       in.Name,
+		PublicKeys: make([]v1alpha1.CosignPublicKey, 0, len(in.PublicKeys)),
+	}
+	for _, k := range in.PublicKeys {
+		pem, err := c.getPublicKeyPEM(ctx, k)
+		if err != nil {
+			return nil, err
+		}
+		out.PublicKeys = append(out.PublicKeys, v1alpha1.CosignPublicKey{Name: k.Name, PublicKeyPEM: pem})
+	}
+	return out, nil
+}
+
+func generateObservation(in *storage.SignatureIntegration) v1alpha1.SignatureIntegrationObservation {
+	return v1alpha1.SignatureIntegrationObservation{
+		ID:   in.GetId(),
+		Name: in.GetName(),
+	}
+}
+
+// generateSignatureIntegration converts resolved parameters into a signature
+// integration.
+func generateSignatureIntegration(in *v1alpha1.SignatureIntegrationParameters, base *storage.SignatureIntegration) *storage.SignatureIntegration {
+	if base == nil {
+		base = &storage.SignatureIntegration{}
+	}
+	base.Name = in.Name
+	keys := make([]*storage.CosignPublicKeyVerification_PublicKey, 0, len(in.PublicKeys))
+	for _, k := range in.PublicKeys {
+		keys = append(keys, &storage.CosignPublicKeyVerification_PublicKey{
+			Name:            k.Name,
+			PublicKeyPemEnc: k.PublicKeyPEM,
+		})
+	}
+	base.Cosign = &storage.CosignPublicKeyVerification{PublicKeys: keys}
+	return base
+}
+
+func generateSignatureIntegrationParameters(in *storage.SignatureIntegration) v1alpha1.SignatureIntegrationParameters {
+	out := v1alpha1.SignatureIntegrationParameters{Name: in.GetName()}
+	for _, k := range in.GetCosign().GetPublicKeys() {
+		out.PublicKeys = append(out.PublicKeys, v1alpha1.CosignPublicKey{
+			Name:         k.GetName(),
+			PublicKeyPEM: k.GetPublicKeyPemEnc(),
+		})
+	}
+	return out
+}
+
+// isUpToDate compares resolved parameters with an observed signature
+// integration.
+func isUpToDate(in *v1alpha1.SignatureIntegrationParameters, observed *storage.SignatureIntegration) (bool, string) {
+	observedParams := generateSignatureIntegrationParameters(observed)
+	if diff := cmp.Diff(*in, observedParams, cmpopts.EquateEmpty()); diff != "" {
+		diff = "Observed difference in signature integration\n" + diff
+		return false, diff
+	}
+	return true, ""
+}
+
+func (c *external) getSignatureIntegration(ctx context.Context, cr *v1alpha1.SignatureIntegration) (*storage.SignatureIntegration, error) {
+	resp, err := c.svc.ListSignatureIntegrations(ctx, &v1.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetFailed)
+	}
+	for _, it := range resp.GetIntegrations() {
+		if it.GetName() == cr.Spec.ForProvider.Name {
+			return it, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SignatureIntegration)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSignatureIntegration)
+	}
+
+	integration, err := c.getSignatureIntegration(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if integration == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(integration)
+	cr.SetConditions(xpv1.Available())
+	meta.SetExternalName(cr, integration.GetName())
+
+	params, err := c.resolve(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	upToDate, diff := isUpToDate(params, integration)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SignatureIntegration)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSignatureIntegration)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	params, err := c.resolve(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	resp, err := c.svc.PostSignatureIntegration(ctx, generateSignatureIntegration(params, nil))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	cr.Status.AtProvider = generateObservation(resp)
+	meta.SetExternalName(cr, resp.GetName())
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SignatureIntegration)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSignatureIntegration)
+	}
+
+	integration, err := c.getSignatureIntegration(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if integration == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	params, err := c.resolve(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+
+	_, err = c.svc.PutSignatureIntegration(ctx, generateSignatureIntegration(params, integration))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SignatureIntegration)
+	if !ok {
+		return errors.New(errNotSignatureIntegration)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := c.svc.DeleteSignatureIntegration(ctx, &v1.ResourceByID{Id: cr.Status.AtProvider.ID})
+	return errors.Wrap(err, errDeleteFailed)
+}
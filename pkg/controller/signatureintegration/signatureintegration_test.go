@@ -0,0 +1,322 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signatureintegration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/signatureintegration/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testPEM = "-----BEGIN PUBLIC KEY-----\nMFkw\n-----END PUBLIC KEY-----\n"
+
+// withKeySources returns a kube client that serves testPEM from any ConfigMap
+// or Secret under the key "cosign.pub".
+func withKeySources() *test.MockClient {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.ConfigMap:
+				o.Data = map[string]string{"cosign.pub": testPEM}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"cosign.pub": []byte(testPEM)}
+			}
+			return nil
+		}),
+	}
+}
+
+func TestGetPublicKeyPEM(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		pem string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		key    v1alpha1.CosignPublicKey
+		want   want
+	}{
+		"Inline": {
+			reason: "An inline PEM should be returned as is.",
+			key:    v1alpha1.CosignPublicKey{Name: "k", PublicKeyPEM: testPEM},
+			want:   want{pem: testPEM},
+		},
+		"ConfigMap": {
+			reason: "A PEM should be read from the referenced ConfigMap key.",
+			kube:   withKeySources(),
+			key: v1alpha1.CosignPublicKey{Name: "k", PublicKeyPEMConfigMapRef: &v1alpha1.ConfigMapKeySelector{
+				Name: "keys", Namespace: "crossplane-system", Key: "cosign.pub",
+			}},
+			want: want{pem: testPEM},
+		},
+		"ConfigMapKeyMissing": {
+			reason: "A missing ConfigMap key should return an error.",
+			kube:   withKeySources(),
+			key: v1alpha1.CosignPublicKey{Name: "k", PublicKeyPEMConfigMapRef: &v1alpha1.ConfigMapKeySelector{
+				Name: "keys", Namespace: "crossplane-system", Key: "other.pub",
+			}},
+			want: want{err: errors.Errorf("%s: %s", errNoConfigMapKey, "other.pub")},
+		},
+		"ConfigMapGetFailed": {
+			reason: "Errors getting the ConfigMap should be returned.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			key: v1alpha1.CosignPublicKey{Name: "k", PublicKeyPEMConfigMapRef: &v1alpha1.ConfigMapKeySelector{
+				Name: "keys", Namespace: "crossplane-system", Key: "cosign.pub",
+			}},
+			want: want{err: errors.Wrap(errBoom, errGetConfigMap)},
+		},
+		"Secret": {
+			reason: "A PEM should be read from the referenced Secret key.",
+			kube:   withKeySources(),
+			key: v1alpha1.CosignPublicKey{Name: "k", PublicKeyPEMSecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Name: "keys", Namespace: "crossplane-system"}, Key: "cosign.pub",
+			}},
+			want: want{pem: testPEM},
+		},
+		"NoSource": {
+			reason: "A public key without PEM source should be rejected.",
+			key:    v1alpha1.CosignPublicKey{Name: "k"},
+			want:   want{err: errors.New(errNoPublicKey)},
+		},
+		"MultipleSources": {
+			reason: "A public key with more than one PEM source should be rejected.",
+			key: v1alpha1.CosignPublicKey{Name: "k", PublicKeyPEM: testPEM, PublicKeyPEMConfigMapRef: &v1alpha1.ConfigMapKeySelector{
+				Name: "keys", Namespace: "crossplane-system", Key: "cosign.pub",
+			}},
+			want: want{err: errors.New(errNoPublicKey)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{kube: tc.kube}
+			got, err := e.getPublicKeyPEM(context.Background(), tc.key)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.getPublicKeyPEM(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pem, got); diff != "" {
+				t.Errorf("\n%s\ne.getPublicKeyPEM(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type fakeSignatureIntegrationService struct {
+	v1.SignatureIntegrationServiceClient
+
+	MockListSignatureIntegrations  func(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.ListSignatureIntegrationsResponse, error)
+	MockPostSignatureIntegration   func(ctx context.Context, in *storage.SignatureIntegration, opts ...grpc.CallOption) (*storage.SignatureIntegration, error)
+	MockPutSignatureIntegration    func(ctx context.Context, in *storage.SignatureIntegration, opts ...grpc.CallOption) (*v1.Empty, error)
+	MockDeleteSignatureIntegration func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeSignatureIntegrationService) ListSignatureIntegrations(ctx context.Context, in *v1.Empty, opts ...grpc.CallOption) (*v1.ListSignatureIntegrationsResponse, error) {
+	return f.MockListSignatureIntegrations(ctx, in, opts...)
+}
+
+func (f *fakeSignatureIntegrationService) PostSignatureIntegration(ctx context.Context, in *storage.SignatureIntegration, opts ...grpc.CallOption) (*storage.SignatureIntegration, error) {
+	return f.MockPostSignatureIntegration(ctx, in, opts...)
+}
+
+func (f *fakeSignatureIntegrationService) PutSignatureIntegration(ctx context.Context, in *storage.SignatureIntegration, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockPutSignatureIntegration(ctx, in, opts...)
+}
+
+func (f *fakeSignatureIntegrationService) DeleteSignatureIntegration(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteSignatureIntegration(ctx, in, opts...)
+}
+
+func signatureIntegration() *v1alpha1.SignatureIntegration {
+	return &v1alpha1.SignatureIntegration{Spec: v1alpha1.SignatureIntegrationSpec{ForProvider: v1alpha1.SignatureIntegrationParameters{
+		Name: "cosign",
+		PublicKeys: []v1alpha1.CosignPublicKey{{
+			Name: "release",
+			PublicKeyPEMConfigMapRef: &v1alpha1.ConfigMapKeySelector{
+				Name: "keys", Namespace: "crossplane-system", Key: "cosign.pub",
+			},
+		}},
+	}}}
+}
+
+func withSignatureIntegrations(s ...*storage.SignatureIntegration) *fakeSignatureIntegrationService {
+	return &fakeSignatureIntegrationService{
+		MockListSignatureIntegrations: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.ListSignatureIntegrationsResponse, error) {
+			return &v1.ListSignatureIntegrationsResponse{Integrations: s}, nil
+		},
+	}
+}
+
+func observedIntegration(pem string) *storage.SignatureIntegration {
+	return &storage.SignatureIntegration{
+		Id:   "io.stackrox.signatureintegration.id",
+		Name: "cosign",
+		Cosign: &storage.CosignPublicKeyVerification{PublicKeys: []*storage.CosignPublicKeyVerification_PublicKey{
+			{Name: "release", PublicKeyPemEnc: pem},
+		}},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.SignatureIntegrationObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeSignatureIntegrationService
+		want   want
+	}{
+		"GetFailed": {
+			reason: "Errors listing signature integrations should be returned.",
+			svc: &fakeSignatureIntegrationService{
+				MockListSignatureIntegrations: func(_ context.Context, _ *v1.Empty, _ ...grpc.CallOption) (*v1.ListSignatureIntegrationsResponse, error) {
+					return nil, errBoom
+				},
+			},
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A signature integration that does not exist in Central should be reported as not existing.",
+			svc:    withSignatureIntegrations(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A signature integration with the referenced PEM should be up to date and report its ID.",
+			svc:    withSignatureIntegrations(observedIntegration(testPEM)),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.SignatureIntegrationObservation{ID: "io.stackrox.signatureintegration.id", Name: "cosign"},
+			},
+		},
+		"KeyRotated": {
+			reason: "A signature integration with a different PEM should not be up to date.",
+			svc:    withSignatureIntegrations(observedIntegration("other")),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs: v1alpha1.SignatureIntegrationObservation{ID: "io.stackrox.signatureintegration.id", Name: "cosign"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := signatureIntegration()
+			e := external{kube: withKeySources(), svc: tc.svc}
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want observation, +got observation:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	var posted *storage.SignatureIntegration
+	cr := signatureIntegration()
+	e := external{kube: withKeySources(), svc: &fakeSignatureIntegrationService{
+		MockPostSignatureIntegration: func(_ context.Context, in *storage.SignatureIntegration, _ ...grpc.CallOption) (*storage.SignatureIntegration, error) {
+			posted = in
+			out := *in
+			out.Id = "io.stackrox.signatureintegration.id"
+			return &out, nil
+		},
+	}}
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	want := observedIntegration(testPEM)
+	want.Id = ""
+	if diff := cmp.Diff(want, posted); diff != "" {
+		t.Errorf("e.Create(...): -want request, +got request:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("io.stackrox.signatureintegration.id", cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var put *storage.SignatureIntegration
+	svc := withSignatureIntegrations(observedIntegration("other"))
+	svc.MockPutSignatureIntegration = func(_ context.Context, in *storage.SignatureIntegration, _ ...grpc.CallOption) (*v1.Empty, error) {
+		put = in
+		return &v1.Empty{}, nil
+	}
+	e := external{kube: withKeySources(), svc: svc}
+
+	if _, err := e.Update(context.Background(), signatureIntegration()); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if diff := cmp.Diff(observedIntegration(testPEM), put); diff != "" {
+		t.Errorf("e.Update(...): -want request, +got request:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var deleted string
+	cr := signatureIntegration()
+	cr.Status.AtProvider.ID = "io.stackrox.signatureintegration.id"
+	e := external{svc: &fakeSignatureIntegrationService{
+		MockDeleteSignatureIntegration: func(_ context.Context, in *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+			deleted = in.GetId()
+			return &v1.Empty{}, nil
+		},
+	}}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if deleted != cr.Status.AtProvider.ID {
+		t.Errorf("e.Delete(...): want signature integration %q deleted, got %q", cr.Status.AtProvider.ID, deleted)
+	}
+}
@@ -20,9 +20,26 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/stehessel/provider-stackrox/pkg/controller/accessscope"
+	"github.com/stehessel/provider-stackrox/pkg/controller/apitoken"
+	"github.com/stehessel/provider-stackrox/pkg/controller/authprovider"
+	"github.com/stehessel/provider-stackrox/pkg/controller/centralconfig"
 	"github.com/stehessel/provider-stackrox/pkg/controller/cluster"
 	"github.com/stehessel/provider-stackrox/pkg/controller/config"
+	"github.com/stehessel/provider-stackrox/pkg/controller/externalbackup"
+	"github.com/stehessel/provider-stackrox/pkg/controller/group"
+	"github.com/stehessel/provider-stackrox/pkg/controller/imageintegration"
 	"github.com/stehessel/provider-stackrox/pkg/controller/initbundle"
+	"github.com/stehessel/provider-stackrox/pkg/controller/networkbaseline"
+	"github.com/stehessel/provider-stackrox/pkg/controller/notifier"
+	"github.com/stehessel/provider-stackrox/pkg/controller/permissionset"
+	"github.com/stehessel/provider-stackrox/pkg/controller/policy"
+	"github.com/stehessel/provider-stackrox/pkg/controller/processbaseline"
+	"github.com/stehessel/provider-stackrox/pkg/controller/reportconfiguration"
+	"github.com/stehessel/provider-stackrox/pkg/controller/role"
+	"github.com/stehessel/provider-stackrox/pkg/controller/sensorupgradeconfig"
+	"github.com/stehessel/provider-stackrox/pkg/controller/signatureintegration"
+	"github.com/stehessel/provider-stackrox/pkg/controller/vulnerabilityexception"
 )
 
 // Setup creates all Stackrox controllers with the supplied logger and adds them to
@@ -32,6 +49,23 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		config.Setup,
 		cluster.Setup,
 		initbundle.Setup,
+		policy.Setup,
+		notifier.Setup,
+		imageintegration.Setup,
+		authprovider.Setup,
+		permissionset.Setup,
+		accessscope.Setup,
+		role.Setup,
+		group.Setup,
+		apitoken.Setup,
+		externalbackup.Setup,
+		signatureintegration.Setup,
+		centralconfig.Setup,
+		reportconfiguration.Setup,
+		vulnerabilityexception.Setup,
+		networkbaseline.Setup,
+		processbaseline.Setup,
+		sensorupgradeconfig.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
@@ -0,0 +1,465 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vulnerabilityexception
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/apis/vulnerabilityexception/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+	"github.com/stehessel/provider-stackrox/pkg/features"
+)
+
+const (
+	errNotVulnerabilityException = "managed resource is not a VulnerabilityException custom resource"
+	errTrackPCUsage              = "cannot track ProviderConfig usage"
+	errTrackApproverPCUsage      = "cannot track ProviderConfig usage of the approval"
+	errApplyApproverPCU          = "cannot apply ProviderConfigUsage of the approval"
+	errDeleteApproverPCU         = "cannot delete ProviderConfigUsage of the approval"
+	errGetPC                     = "cannot get ProviderConfig"
+	errGetCreds                  = "cannot get credentials"
+	errNoRequestType             = "exactly one of deferral and falsePositive must be set"
+	errApproveFailed             = "cannot approve vulnerability request"
+	errUndoFailed                = "cannot undo vulnerability request"
+	errGetFailed                 = "cannot get vulnerability request"
+	errObserveFailed             = "cannot observe vulnerability request"
+	errCreateFailed              = "cannot create vulnerability request"
+	errUpdateFailed              = "cannot update vulnerability request"
+	errDeleteFailed              = "cannot delete vulnerability request"
+)
+
+// Setup adds a controller that reconciles VulnerabilityException managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.VulnerabilityExceptionGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.VulnerabilityExceptionGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:          mgr.GetClient(),
+			usage:         resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			approverUsage: &approverUsageTracker{kube: mgr.GetClient()},
+			pool:          central.Connections,
+		}),
+		// Requests are identified by the ID Central assigns on creation.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.VulnerabilityException{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube          client.Client
+	usage         resource.Tracker
+	approverUsage resource.Tracker
+	pool          *central.Pool
+}
+
+// connect forms a client with the credentials of the named ProviderConfig.
+func (c *connector) connect(ctx context.Context, name string) (*grpc.ClientConn, error) {
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	stringToken := string(token)
+
+	client, err := c.pool.Get(ctx, pc.GetUID(), pc.Spec.Endpoint, stringToken)
+	return client, errors.Wrap(err, central.ErrNewClient)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+// A second client is formed if the approval uses a different ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.VulnerabilityException)
+	if !ok {
+		return nil, errors.New(errNotVulnerabilityException)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+	if err := c.approverUsage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackApproverPCUsage)
+	}
+
+	client, err := c.connect(ctx, cr.GetProviderConfigReference().Name)
+	if err != nil {
+		return nil, err
+	}
+	e := &external{svc: v1.NewVulnerabilityRequestServiceClient(client)}
+	e.approver = e.svc
+
+	if a := cr.Spec.ForProvider.Approval; a != nil && a.ProviderConfigReference != nil {
+		approverClient, err := c.connect(ctx, a.ProviderConfigReference.Name)
+		if err != nil {
+			return nil, err
+		}
+		e.approver = v1.NewVulnerabilityRequestServiceClient(approverClient)
+	}
+	return e, nil
+}
+
+// An approverUsageTracker tracks that a managed resource is using the
+// ProviderConfig of its approval, so that the ProviderConfig is not deleted
+// while it is in use. The usage is removed when the approval no longer
+// references a ProviderConfig.
+type approverUsageTracker struct {
+	kube client.Client
+}
+
+// approverUsageName returns the name of the ProviderConfigUsage of the
+// approval, which must differ from the usage of the managed resource's own
+// ProviderConfig.
+func approverUsageName(mg resource.Managed) string {
+	return string(mg.GetUID()) + "-approver"
+}
+
+// Track the ProviderConfig usage of the approval of the managed resource.
+func (u *approverUsageTracker) Track(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.VulnerabilityException)
+	if !ok {
+		return errors.New(errNotVulnerabilityException)
+	}
+
+	pcu := &apisv1alpha1.ProviderConfigUsage{}
+	pcu.SetName(approverUsageName(cr))
+	a := cr.Spec.ForProvider.Approval
+	if a == nil || a.ProviderConfigReference == nil {
+		return errors.Wrap(client.IgnoreNotFound(u.kube.Delete(ctx, pcu)), errDeleteApproverPCU)
+	}
+
+	gvk := cr.GetObjectKind().GroupVersionKind()
+	pcu.SetLabels(map[string]string{xpv1.LabelKeyProviderName: a.ProviderConfigReference.Name})
+	pcu.SetOwnerReferences([]metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(cr, gvk))})
+	pcu.SetProviderConfigReference(xpv1.Reference{Name: a.ProviderConfigReference.Name})
+	pcu.SetResourceReference(xpv1.TypedReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       cr.GetName(),
+	})
+
+	err := resource.NewAPIPatchingApplicator(u.kube).Apply(ctx, pcu,
+		resource.MustBeControllableBy(cr.GetUID()),
+		resource.AllowUpdateIf(func(current, _ runtime.Object) bool {
+			return current.(resource.ProviderConfigUsage).GetProviderConfigReference() != pcu.GetProviderConfigReference()
+		}),
+	)
+	return errors.Wrap(resource.Ignore(resource.IsNotAllowed, err), errApplyApproverPCU)
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	svc      v1.VulnerabilityRequestServiceClient
+	approver v1.VulnerabilityRequestServiceClient
+}
+
+func validate(in *v1alpha1.VulnerabilityExceptionParameters) error {
+	if (in.Deferral == nil) == (in.FalsePositive == nil) {
+		return errors.New(errNoRequestType)
+	}
+	return nil
+}
+
+func generateScope(in *v1alpha1.ImageScope) *storage.VulnerabilityRequest_Scope {
+	if in == nil {
+		return &storage.VulnerabilityRequest_Scope{Info: &storage.VulnerabilityRequest_Scope_GlobalScope{
+			GlobalScope: &storage.VulnerabilityRequest_Scope_Global{},
+		}}
+	}
+	return &storage.VulnerabilityRequest_Scope{Info: &storage.VulnerabilityRequest_Scope_ImageScope{
+		ImageScope: &storage.VulnerabilityRequest_Scope_Image{Registry: in.Registry, Remote: in.Remote, Tag: in.Tag},
+	}}
+}
+
+// generateExpiry converts a deferral into a request expiry. Indefinite
+// deferrals have an expiry without value.
+func generateExpiry(in *v1alpha1.Deferral) *storage.RequestExpiry {
+	switch {
+	case in.ExpiresWhenFixed:
+		return &storage.RequestExpiry{Expiry: &storage.RequestExpiry_ExpiresWhenFixed{ExpiresWhenFixed: true}}
+	case in.ExpiresOn != nil:
+		return &storage.RequestExpiry{Expiry: &storage.RequestExpiry_ExpiresOn{ExpiresOn: central.ToTimestamp(in.ExpiresOn)}}
+	}
+	return &storage.RequestExpiry{}
+}
+
+func generateDeferralParameters(in *storage.RequestExpiry) *v1alpha1.Deferral {
+	return &v1alpha1.Deferral{
+		ExpiresOn:        central.ToTime(in.GetExpiresOn()),
+		ExpiresWhenFixed: in.GetExpiresWhenFixed(),
+	}
+}
+
+func generateDeferVulnRequest(in *v1alpha1.VulnerabilityExceptionParameters) *v1.DeferVulnRequest {
+	out := &v1.DeferVulnRequest{
+		Cve:     in.CVE,
+		Comment: in.Comment,
+		Scope:   generateScope(in.Image),
+	}
+	switch e := generateExpiry(in.Deferral).GetExpiry().(type) {
+	case *storage.RequestExpiry_ExpiresWhenFixed:
+		out.Expiry = &v1.DeferVulnRequest_ExpiresWhenFixed{ExpiresWhenFixed: e.ExpiresWhenFixed}
+	case *storage.RequestExpiry_ExpiresOn:
+		out.Expiry = &v1.DeferVulnRequest_ExpiresOn{ExpiresOn: e.ExpiresOn}
+	}
+	return out
+}
+
+func generateObservation(in *storage.VulnerabilityRequest) v1alpha1.VulnerabilityExceptionObservation {
+	out := v1alpha1.VulnerabilityExceptionObservation{
+		ID:               in.GetId(),
+		Status:           in.GetStatus().String(),
+		Expired:          in.GetExpired(),
+		Requestor:        in.GetRequestor().GetName(),
+		ExpiresOn:        central.ToTime(in.GetDeferralReq().GetExpiry().GetExpiresOn()),
+		ExpiresWhenFixed: in.GetDeferralReq().GetExpiry().GetExpiresWhenFixed(),
+	}
+	for _, a := range in.GetApprovers() {
+		out.Approvers = append(out.Approvers, a.GetName())
+	}
+	return out
+}
+
+// requestedExpiry returns the expiry of a pending update, or the current
+// expiry if there is none.
+func requestedExpiry(in *storage.VulnerabilityRequest) *storage.RequestExpiry {
+	if u := in.GetUpdatedDeferralReq(); u != nil {
+		return u.GetExpiry()
+	}
+	return in.GetDeferralReq().GetExpiry()
+}
+
+// needsApproval returns whether the spec approves a request that is waiting
+// for approval.
+func needsApproval(in *v1alpha1.VulnerabilityExceptionParameters, observed *storage.VulnerabilityRequest) bool {
+	if in.Approval == nil {
+		return false
+	}
+	s := observed.GetStatus()
+	return s == storage.RequestStatus_PENDING || s == storage.RequestStatus_APPROVED_PENDING_UPDATE
+}
+
+// expiryChanged returns whether the spec requests a different expiry than
+// the observed deferral. Denied requests cannot be updated.
+func expiryChanged(in *v1alpha1.VulnerabilityExceptionParameters, observed *storage.VulnerabilityRequest) (bool, string) {
+	if in.Deferral == nil || observed.GetDeferralReq() == nil || observed.GetStatus() == storage.RequestStatus_DENIED {
+		return false, ""
+	}
+	diff := cmp.Diff(in.Deferral, generateDeferralParameters(requestedExpiry(observed)))
+	return diff != "", diff
+}
+
+// isUpToDate reports drift of the deferral expiry and pending approvals. The
+// CVE, scope and type of a request cannot be changed.
+func isUpToDate(in *v1alpha1.VulnerabilityExceptionParameters, observed *storage.VulnerabilityRequest) (bool, string) {
+	if changed, diff := expiryChanged(in, observed); changed {
+		return false, "Observed difference in vulnerability request expiry\n" + diff
+	}
+	if needsApproval(in, observed) {
+		return false, "Vulnerability request is pending approval\n"
+	}
+	return true, ""
+}
+
+func (c *external) getVulnerabilityRequest(ctx context.Context, id string) (*storage.VulnerabilityRequest, error) {
+	resp, err := c.svc.GetVulnerabilityRequest(ctx, &v1.ResourceByID{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	return resp.GetRequestInfo(), errors.Wrap(err, errGetFailed)
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.VulnerabilityException)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotVulnerabilityException)
+	}
+
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	req, err := c.getVulnerabilityRequest(ctx, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFailed)
+	}
+	if req == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(req)
+	// Approved requests are kept as history once they are undone, denied
+	// ones are never in effect.
+	if meta.WasDeleted(cr) && (req.GetExpired() || req.GetStatus() == storage.RequestStatus_DENIED) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if req.GetStatus() == storage.RequestStatus_APPROVED {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Unavailable())
+	}
+	upToDate, diff := isUpToDate(&cr.Spec.ForProvider, req)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.VulnerabilityException)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotVulnerabilityException)
+	}
+	in := &cr.Spec.ForProvider
+	if err := validate(in); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	var req *storage.VulnerabilityRequest
+	if in.Deferral != nil {
+		resp, err := c.svc.DeferVulnerability(ctx, generateDeferVulnRequest(in))
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+		}
+		req = resp.GetRequestInfo()
+	} else {
+		resp, err := c.svc.FalsePositiveVulnerability(ctx, &v1.FalsePositiveVulnRequest{
+			Cve:     in.CVE,
+			Scope:   generateScope(in.Image),
+			Comment: in.Comment,
+		})
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+		}
+		req = resp.GetRequestInfo()
+	}
+
+	cr.Status.AtProvider = generateObservation(req)
+	meta.SetExternalName(cr, req.GetId())
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.VulnerabilityException)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotVulnerabilityException)
+	}
+	in := &cr.Spec.ForProvider
+
+	req, err := c.getVulnerabilityRequest(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if req == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	if changed, _ := expiryChanged(in, req); changed {
+		resp, err := c.svc.UpdateVulnerabilityRequest(ctx, &v1.UpdateVulnRequest{
+			Id:      req.GetId(),
+			Comment: in.Comment,
+			Expiry:  generateExpiry(in.Deferral),
+		})
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+		}
+		req = resp.GetRequestInfo()
+	}
+
+	if needsApproval(in, req) {
+		resp, err := c.approver.ApproveVulnerabilityRequest(ctx, &v1.ApproveVulnRequest{Id: req.GetId(), Comment: in.Approval.Comment})
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errApproveFailed)
+		}
+		req = resp.GetRequestInfo()
+	}
+
+	cr.Status.AtProvider = generateObservation(req)
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete deletes pending requests and undoes approved ones. Central only
+// allows undoing requests with approval permissions, so approved requests are
+// undone by the approver.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.VulnerabilityException)
+	if !ok {
+		return errors.New(errNotVulnerabilityException)
+	}
+	mg.SetConditions(xpv1.Deleting())
+
+	id := &v1.ResourceByID{Id: meta.GetExternalName(cr)}
+	switch cr.Status.AtProvider.Status {
+	case storage.RequestStatus_PENDING.String():
+		_, err := c.svc.DeleteVulnerabilityRequest(ctx, id)
+		return errors.Wrap(err, errDeleteFailed)
+	case storage.RequestStatus_APPROVED.String(), storage.RequestStatus_APPROVED_PENDING_UPDATE.String():
+		_, err := c.approver.UndoVulnerabilityRequest(ctx, id)
+		return errors.Wrap(err, errUndoFailed)
+	}
+	return nil
+}
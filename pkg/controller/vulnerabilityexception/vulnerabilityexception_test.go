@@ -0,0 +1,473 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vulnerabilityexception
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "github.com/stackrox/rox/generated/api/v1"
+	"github.com/stackrox/rox/generated/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+	"github.com/stehessel/provider-stackrox/apis/vulnerabilityexception/v1alpha1"
+	"github.com/stehessel/provider-stackrox/pkg/clients/central"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type fakeVulnerabilityRequestService struct {
+	v1.VulnerabilityRequestServiceClient
+
+	MockGetVulnerabilityRequest     func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.GetVulnerabilityRequestResponse, error)
+	MockDeferVulnerability          func(ctx context.Context, in *v1.DeferVulnRequest, opts ...grpc.CallOption) (*v1.DeferVulnResponse, error)
+	MockFalsePositiveVulnerability  func(ctx context.Context, in *v1.FalsePositiveVulnRequest, opts ...grpc.CallOption) (*v1.FalsePositiveVulnResponse, error)
+	MockApproveVulnerabilityRequest func(ctx context.Context, in *v1.ApproveVulnRequest, opts ...grpc.CallOption) (*v1.ApproveVulnRequestResponse, error)
+	MockUpdateVulnerabilityRequest  func(ctx context.Context, in *v1.UpdateVulnRequest, opts ...grpc.CallOption) (*v1.UpdateVulnRequestResponse, error)
+	MockUndoVulnerabilityRequest    func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.UndoVulnRequestResponse, error)
+	MockDeleteVulnerabilityRequest  func(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error)
+}
+
+func (f *fakeVulnerabilityRequestService) GetVulnerabilityRequest(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.GetVulnerabilityRequestResponse, error) {
+	return f.MockGetVulnerabilityRequest(ctx, in, opts...)
+}
+
+func (f *fakeVulnerabilityRequestService) DeferVulnerability(ctx context.Context, in *v1.DeferVulnRequest, opts ...grpc.CallOption) (*v1.DeferVulnResponse, error) {
+	return f.MockDeferVulnerability(ctx, in, opts...)
+}
+
+func (f *fakeVulnerabilityRequestService) FalsePositiveVulnerability(ctx context.Context, in *v1.FalsePositiveVulnRequest, opts ...grpc.CallOption) (*v1.FalsePositiveVulnResponse, error) {
+	return f.MockFalsePositiveVulnerability(ctx, in, opts...)
+}
+
+func (f *fakeVulnerabilityRequestService) ApproveVulnerabilityRequest(ctx context.Context, in *v1.ApproveVulnRequest, opts ...grpc.CallOption) (*v1.ApproveVulnRequestResponse, error) {
+	return f.MockApproveVulnerabilityRequest(ctx, in, opts...)
+}
+
+func (f *fakeVulnerabilityRequestService) UpdateVulnerabilityRequest(ctx context.Context, in *v1.UpdateVulnRequest, opts ...grpc.CallOption) (*v1.UpdateVulnRequestResponse, error) {
+	return f.MockUpdateVulnerabilityRequest(ctx, in, opts...)
+}
+
+func (f *fakeVulnerabilityRequestService) UndoVulnerabilityRequest(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.UndoVulnRequestResponse, error) {
+	return f.MockUndoVulnerabilityRequest(ctx, in, opts...)
+}
+
+func (f *fakeVulnerabilityRequestService) DeleteVulnerabilityRequest(ctx context.Context, in *v1.ResourceByID, opts ...grpc.CallOption) (*v1.Empty, error) {
+	return f.MockDeleteVulnerabilityRequest(ctx, in, opts...)
+}
+
+var expiresOn = metav1.NewTime(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+
+func deferral(id string, approval *v1alpha1.Approval) *v1alpha1.VulnerabilityException {
+	cr := &v1alpha1.VulnerabilityException{Spec: v1alpha1.VulnerabilityExceptionSpec{ForProvider: v1alpha1.VulnerabilityExceptionParameters{
+		CVE:      "CVE-2022-42898",
+		Comment:  "Not reachable from the network.",
+		Image:    &v1alpha1.ImageScope{Registry: "quay.io", Remote: "example/payments", Tag: ".*"},
+		Deferral: &v1alpha1.Deferral{ExpiresOn: &expiresOn},
+		Approval: approval,
+	}}}
+	meta.SetExternalName(cr, id)
+	return cr
+}
+
+func observedRequest(s storage.RequestStatus, expiry *storage.RequestExpiry) *storage.VulnerabilityRequest {
+	return &storage.VulnerabilityRequest{
+		Id:        "id",
+		Status:    s,
+		Requestor: &storage.SlimUser{Id: "ci", Name: "ci"},
+		Req:       &storage.VulnerabilityRequest_DeferralReq{DeferralReq: &storage.DeferralRequest{Expiry: expiry}},
+	}
+}
+
+func withRequest(r *storage.VulnerabilityRequest) *fakeVulnerabilityRequestService {
+	return &fakeVulnerabilityRequestService{
+		MockGetVulnerabilityRequest: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*v1.GetVulnerabilityRequestResponse, error) {
+			if r == nil {
+				return nil, status.Error(codes.NotFound, "not found")
+			}
+			return &v1.GetVulnerabilityRequestResponse{RequestInfo: r}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	expiry := generateExpiry(&v1alpha1.Deferral{ExpiresOn: &expiresOn})
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fakeVulnerabilityRequestService
+		cr     *v1alpha1.VulnerabilityException
+		want   want
+	}{
+		"NoExternalName": {
+			reason: "A vulnerability exception without external name should be reported as not existing.",
+			cr:     deferral("", nil),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"GetFailed": {
+			reason: "Errors getting the vulnerability request should be returned.",
+			svc: &fakeVulnerabilityRequestService{
+				MockGetVulnerabilityRequest: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*v1.GetVulnerabilityRequestResponse, error) {
+					return nil, errBoom
+				},
+			},
+			cr:   deferral("id", nil),
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetFailed), errObserveFailed)},
+		},
+		"NotFound": {
+			reason: "A vulnerability request that does not exist in Central should be reported as not existing.",
+			svc:    withRequest(nil),
+			cr:     deferral("id", nil),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"PendingWithoutApproval": {
+			reason: "A pending request without approval in the spec should be up to date.",
+			svc:    withRequest(observedRequest(storage.RequestStatus_PENDING, expiry)),
+			cr:     deferral("id", nil),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"PendingApproval": {
+			reason: "A pending request that is approved in the spec should not be up to date.",
+			svc:    withRequest(observedRequest(storage.RequestStatus_PENDING, expiry)),
+			cr:     deferral("id", &v1alpha1.Approval{}),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"ExpiryChanged": {
+			reason: "An approved deferral with a different expiry should not be up to date.",
+			svc:    withRequest(observedRequest(storage.RequestStatus_APPROVED, generateExpiry(&v1alpha1.Deferral{ExpiresWhenFixed: true}))),
+			cr:     deferral("id", &v1alpha1.Approval{}),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"Undone": {
+			reason: "A deleted exception whose request expired after it was undone should be reported as not existing.",
+			svc: withRequest(func() *storage.VulnerabilityRequest {
+				r := observedRequest(storage.RequestStatus_APPROVED, expiry)
+				r.Expired = true
+				return r
+			}()),
+			cr: func() *v1alpha1.VulnerabilityException {
+				cr := deferral("id", &v1alpha1.Approval{})
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{svc: tc.svc}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("Deferral", func(t *testing.T) {
+		var got *v1.DeferVulnRequest
+		cr := deferral("", nil)
+		e := external{svc: &fakeVulnerabilityRequestService{
+			MockDeferVulnerability: func(_ context.Context, in *v1.DeferVulnRequest, _ ...grpc.CallOption) (*v1.DeferVulnResponse, error) {
+				got = in
+				return &v1.DeferVulnResponse{RequestInfo: observedRequest(storage.RequestStatus_PENDING, nil)}, nil
+			},
+		}}
+
+		if _, err := e.Create(context.Background(), cr); err != nil {
+			t.Fatalf("e.Create(...): %v", err)
+		}
+		want := &v1.DeferVulnRequest{
+			Cve:     "CVE-2022-42898",
+			Comment: "Not reachable from the network.",
+			Scope: &storage.VulnerabilityRequest_Scope{Info: &storage.VulnerabilityRequest_Scope_ImageScope{
+				ImageScope: &storage.VulnerabilityRequest_Scope_Image{Registry: "quay.io", Remote: "example/payments", Tag: ".*"},
+			}},
+			Expiry: &v1.DeferVulnRequest_ExpiresOn{ExpiresOn: central.ToTimestamp(&expiresOn)},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("e.Create(...): -want request, +got request:\n%s\n", diff)
+		}
+		if diff := cmp.Diff("id", meta.GetExternalName(cr)); diff != "" {
+			t.Errorf("e.Create(...): -want external name, +got external name:\n%s\n", diff)
+		}
+	})
+
+	t.Run("GlobalFalsePositive", func(t *testing.T) {
+		var got *v1.FalsePositiveVulnRequest
+		cr := deferral("", nil)
+		cr.Spec.ForProvider.Image = nil
+		cr.Spec.ForProvider.Deferral = nil
+		cr.Spec.ForProvider.FalsePositive = &v1alpha1.FalsePositive{}
+		e := external{svc: &fakeVulnerabilityRequestService{
+			MockFalsePositiveVulnerability: func(_ context.Context, in *v1.FalsePositiveVulnRequest, _ ...grpc.CallOption) (*v1.FalsePositiveVulnResponse, error) {
+				got = in
+				return &v1.FalsePositiveVulnResponse{RequestInfo: &storage.VulnerabilityRequest{Id: "id"}}, nil
+			},
+		}}
+
+		if _, err := e.Create(context.Background(), cr); err != nil {
+			t.Fatalf("e.Create(...): %v", err)
+		}
+		if got.GetScope().GetGlobalScope() == nil {
+			t.Errorf("e.Create(...): want global scope, got %v", got.GetScope())
+		}
+	})
+
+	t.Run("NoRequestType", func(t *testing.T) {
+		cr := deferral("", nil)
+		cr.Spec.ForProvider.FalsePositive = &v1alpha1.FalsePositive{}
+		e := external{}
+
+		_, err := e.Create(context.Background(), cr)
+		if diff := cmp.Diff(errors.New(errNoRequestType), err, test.EquateErrors()); diff != "" {
+			t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+		}
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	expiry := generateExpiry(&v1alpha1.Deferral{ExpiresOn: &expiresOn})
+
+	type want struct {
+		updates   int
+		approvals int
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *storage.VulnerabilityRequest
+		approval *v1alpha1.Approval
+		want     want
+	}{
+		"Approve": {
+			reason:   "A pending request should be approved by the approver.",
+			observed: observedRequest(storage.RequestStatus_PENDING, expiry),
+			approval: &v1alpha1.Approval{Comment: "Reviewed in PR 42."},
+			want:     want{approvals: 1},
+		},
+		"UpdateExpiry": {
+			reason:   "A changed expiry of an approved deferral should be updated and the update approved.",
+			observed: observedRequest(storage.RequestStatus_APPROVED, generateExpiry(&v1alpha1.Deferral{ExpiresWhenFixed: true})),
+			approval: &v1alpha1.Approval{},
+			want:     want{updates: 1, approvals: 1},
+		},
+		"UpdateExpiryWithoutApproval": {
+			reason:   "A changed expiry without approval in the spec should only be updated.",
+			observed: observedRequest(storage.RequestStatus_APPROVED, generateExpiry(&v1alpha1.Deferral{ExpiresWhenFixed: true})),
+			want:     want{updates: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updates, approvals := 0, 0
+			svc := withRequest(tc.observed)
+			svc.MockUpdateVulnerabilityRequest = func(_ context.Context, in *v1.UpdateVulnRequest, _ ...grpc.CallOption) (*v1.UpdateVulnRequestResponse, error) {
+				updates++
+				out := *tc.observed
+				out.Status = storage.RequestStatus_APPROVED_PENDING_UPDATE
+				out.UpdatedReq = &storage.VulnerabilityRequest_UpdatedDeferralReq{UpdatedDeferralReq: &storage.DeferralRequest{Expiry: in.GetExpiry()}}
+				return &v1.UpdateVulnRequestResponse{RequestInfo: &out}, nil
+			}
+			approver := &fakeVulnerabilityRequestService{
+				MockApproveVulnerabilityRequest: func(_ context.Context, in *v1.ApproveVulnRequest, _ ...grpc.CallOption) (*v1.ApproveVulnRequestResponse, error) {
+					approvals++
+					out := *tc.observed
+					out.Status = storage.RequestStatus_APPROVED
+					return &v1.ApproveVulnRequestResponse{RequestInfo: &out}, nil
+				},
+			}
+			e := external{svc: svc, approver: approver}
+
+			if _, err := e.Update(context.Background(), deferral("id", tc.approval)); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			got := want{updates: updates, approvals: approvals}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status string
+		want   string
+	}{
+		"Pending": {
+			reason: "A pending request should be deleted.",
+			status: "PENDING",
+			want:   "delete",
+		},
+		"Approved": {
+			reason: "An approved request should be undone by the approver.",
+			status: "APPROVED",
+			want:   "undo",
+		},
+		"Denied": {
+			reason: "A denied request should be left alone.",
+			status: "DENIED",
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			cr := deferral("id", nil)
+			cr.Status.AtProvider.Status = tc.status
+			e := external{
+				svc: &fakeVulnerabilityRequestService{
+					MockDeleteVulnerabilityRequest: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*v1.Empty, error) {
+						got = "delete"
+						return &v1.Empty{}, nil
+					},
+				},
+				approver: &fakeVulnerabilityRequestService{
+					MockUndoVulnerabilityRequest: func(_ context.Context, _ *v1.ResourceByID, _ ...grpc.CallOption) (*v1.UndoVulnRequestResponse, error) {
+						got = "undo"
+						return &v1.UndoVulnRequestResponse{}, nil
+					},
+				},
+			}
+
+			if err := e.Delete(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Delete(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestApproverUsageTracker(t *testing.T) {
+	errBoom := errors.New("boom")
+	approver := &v1alpha1.Approval{ProviderConfigReference: &xpv1.Reference{Name: "security-lead"}}
+
+	type want struct {
+		err error
+		pcu *apisv1alpha1.ProviderConfigUsage
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   *test.MockClient
+		cr     *v1alpha1.VulnerabilityException
+		want   want
+	}{
+		"Approver": {
+			reason: "The ProviderConfig of the approval should be tracked.",
+			kube: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+			cr: deferral("id", approver),
+			want: want{
+				pcu: &apisv1alpha1.ProviderConfigUsage{
+					ProviderConfigUsage: xpv1.ProviderConfigUsage{ProviderConfigReference: xpv1.Reference{Name: "security-lead"}},
+				},
+			},
+		},
+		"NoApprover": {
+			reason: "The usage should be removed when the approval does not reference a ProviderConfig.",
+			kube: &test.MockClient{
+				MockDelete: test.NewMockDeleteFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+			cr:   deferral("id", &v1alpha1.Approval{Comment: "ok"}),
+			want: want{},
+		},
+		"DeleteFailed": {
+			reason: "Errors removing the usage should be returned.",
+			kube: &test.MockClient{
+				MockDelete: test.NewMockDeleteFn(errBoom),
+			},
+			cr:   deferral("id", nil),
+			want: want{err: errors.Wrap(errBoom, errDeleteApproverPCU)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *apisv1alpha1.ProviderConfigUsage
+			if tc.kube.MockCreate != nil {
+				create := tc.kube.MockCreate
+				tc.kube.MockCreate = func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+					got = obj.(*apisv1alpha1.ProviderConfigUsage)
+					return create(ctx, obj, opts...)
+				}
+			}
+			u := &approverUsageTracker{kube: tc.kube}
+			err := u.Track(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nu.Track(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.pcu == nil {
+				if got != nil {
+					t.Errorf("\n%s\nu.Track(...): want no ProviderConfigUsage, got %v", tc.reason, got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("\n%s\nu.Track(...): want ProviderConfigUsage, got none", tc.reason)
+			}
+			if diff := cmp.Diff(tc.want.pcu.ProviderConfigReference, got.ProviderConfigReference); diff != "" {
+				t.Errorf("\n%s\nu.Track(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got.GetName() != approverUsageName(tc.cr) {
+				t.Errorf("\n%s\nu.Track(...): want name %q, got %q", tc.reason, approverUsageName(tc.cr), got.GetName())
+			}
+		})
+	}
+}
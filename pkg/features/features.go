@@ -24,4 +24,9 @@ const (
 	// External Secret Stores. See the below design for more details.
 	// https://github.com/crossplane/crossplane/blob/390ddd/design/design-doc-external-secret-stores.md
 	EnableAlphaExternalSecretStores feature.Flag = "EnableAlphaExternalSecretStores"
+
+	// EnableAlphaManagementPolicies enables alpha support for management
+	// policies, which restrict the actions that the provider may take on
+	// the external resources of clusters and init bundles.
+	EnableAlphaManagementPolicies feature.Flag = "EnableAlphaManagementPolicies"
 )
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package management restricts the actions that external clients take on
+// external resources to the management policies of managed resources.
+package management
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+)
+
+const (
+	errCreateNotAllowed = "management policies do not allow to create the external resource"
+	errUpdateNotAllowed = "management policies do not allow to update the external resource"
+	errDeleteNotAllowed = "management policies do not allow to delete the external resource"
+	errNotFound         = "external resource does not exist and " + errCreateNotAllowed
+	errNotUpToDate      = "external resource is not up to date and " + errUpdateNotAllowed
+)
+
+// NewConnectDisconnecter returns an ExternalConnectDisconnecter whose external
+// clients honor the management policies of managed resources.
+func NewConnectDisconnecter(c managed.ExternalConnectDisconnecter) managed.ExternalConnectDisconnecter {
+	return &connectDisconnecter{ExternalConnectDisconnecter: c}
+}
+
+type connectDisconnecter struct {
+	managed.ExternalConnectDisconnecter
+}
+
+// Connect produces an ExternalClient that honors the management policies of
+// the managed resource.
+func (c *connectDisconnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ext, err := c.ExternalConnectDisconnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: ext}, nil
+}
+
+// An external client only observes, creates, updates, deletes or late
+// initializes external resources if the management policies allow it.
+type external struct {
+	managed.ExternalClient
+}
+
+// policies returns the management policies of the managed resource. Managed
+// resources without management policies allow all actions.
+func policies(mg resource.Managed) apisv1alpha1.ManagementPolicies {
+	if a, ok := mg.(apisv1alpha1.ManagementPoliciesAccessor); ok {
+		return a.GetManagementPolicies()
+	}
+	return nil
+}
+
+// Observe reports drift of external resources that may not be updated as an
+// error, so that the diff surfaces in the Synced condition. Deleted managed
+// resources whose external resource may not be deleted are orphaned.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	p := policies(mg)
+	if meta.WasDeleted(mg) {
+		if !p.Allows(apisv1alpha1.ManagementActionDelete) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return e.ExternalClient.Observe(ctx, mg)
+	}
+
+	obs, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return obs, err
+	}
+	if !p.Allows(apisv1alpha1.ManagementActionLateInitialize) {
+		obs.ResourceLateInitialized = false
+	}
+	if !obs.ResourceExists && !p.Allows(apisv1alpha1.ManagementActionCreate) {
+		return obs, errors.New(errNotFound)
+	}
+	if obs.ResourceExists && !obs.ResourceUpToDate && !p.Allows(apisv1alpha1.ManagementActionUpdate) {
+		return obs, errors.Wrap(errors.New(obs.Diff), errNotUpToDate)
+	}
+	return obs, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if !policies(mg).Allows(apisv1alpha1.ManagementActionCreate) {
+		return managed.ExternalCreation{}, errors.New(errCreateNotAllowed)
+	}
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if !policies(mg).Allows(apisv1alpha1.ManagementActionUpdate) {
+		return managed.ExternalUpdate{}, errors.New(errUpdateNotAllowed)
+	}
+	return e.ExternalClient.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if !policies(mg).Allows(apisv1alpha1.ManagementActionDelete) {
+		return errors.New(errDeleteNotAllowed)
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package management
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/stehessel/provider-stackrox/apis/initbundle/v1alpha1"
+	apisv1alpha1 "github.com/stehessel/provider-stackrox/apis/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const drift = "Observed difference in init bundle\n-name"
+
+type initBundleModifier func(*v1alpha1.InitBundle)
+
+func withPolicies(p ...apisv1alpha1.ManagementAction) initBundleModifier {
+	return func(cr *v1alpha1.InitBundle) { cr.SetManagementPolicies(p) }
+}
+
+func withDeletionTimestamp() initBundleModifier {
+	return func(cr *v1alpha1.InitBundle) {
+		now := metav1.Now()
+		cr.SetDeletionTimestamp(&now)
+	}
+}
+
+func initBundle(m ...initBundleModifier) *v1alpha1.InitBundle {
+	cr := &v1alpha1.InitBundle{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// fakeExternal returns an external client that observes the supplied
+// observation and records the actions it takes.
+func fakeExternal(obs managed.ExternalObservation, calls *[]string) managed.ExternalClient {
+	return &external{ExternalClient: managed.ExternalClientFns{
+		ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			*calls = append(*calls, "Observe")
+			return obs, nil
+		},
+		CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+			*calls = append(*calls, "Create")
+			return managed.ExternalCreation{}, nil
+		},
+		UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+			*calls = append(*calls, "Update")
+			return managed.ExternalUpdate{}, nil
+		},
+		DeleteFn: func(_ context.Context, _ resource.Managed) error {
+			*calls = append(*calls, "Delete")
+			return nil
+		},
+	}}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o     managed.ExternalObservation
+		err   error
+		calls []string
+	}
+
+	cases := map[string]struct {
+		obs  managed.ExternalObservation
+		cr   *v1alpha1.InitBundle
+		want want
+	}{
+		"FullControl": {
+			obs: managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: true, Diff: drift},
+			cr:  initBundle(withPolicies(apisv1alpha1.ManagementActionAll)),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: true, Diff: drift},
+				calls: []string{"Observe"},
+			},
+		},
+		"NoPolicies": {
+			obs: managed.ExternalObservation{ResourceExists: false},
+			cr:  initBundle(),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				calls: []string{"Observe"},
+			},
+		},
+		"ObserveOnlyUpToDate": {
+			obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			cr:  initBundle(withPolicies(apisv1alpha1.ManagementActionObserve)),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				calls: []string{"Observe"},
+			},
+		},
+		"ObserveOnlyDrifted": {
+			obs: managed.ExternalObservation{ResourceExists: true, Diff: drift},
+			cr:  initBundle(withPolicies(apisv1alpha1.ManagementActionObserve)),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, Diff: drift},
+				err:   errors.Wrap(errors.New(drift), errNotUpToDate),
+				calls: []string{"Observe"},
+			},
+		},
+		"ObserveOnlyNotFound": {
+			obs: managed.ExternalObservation{ResourceExists: false},
+			cr:  initBundle(withPolicies(apisv1alpha1.ManagementActionObserve)),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				err:   errors.New(errNotFound),
+				calls: []string{"Observe"},
+			},
+		},
+		"ObserveOnlyDeleted": {
+			obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			cr:  initBundle(withPolicies(apisv1alpha1.ManagementActionObserve), withDeletionTimestamp()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"DeletedWithoutCreate": {
+			obs: managed.ExternalObservation{ResourceExists: false},
+			cr:  initBundle(withPolicies(apisv1alpha1.ManagementActionObserve, apisv1alpha1.ManagementActionDelete), withDeletionTimestamp()),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				calls: []string{"Observe"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			e := fakeExternal(tc.obs, &calls)
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", name, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", name, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want calls, +got calls:\n%s\n", name, diff)
+			}
+		})
+	}
+}
+
+func TestObserveOnly(t *testing.T) {
+	var calls []string
+	e := fakeExternal(managed.ExternalObservation{ResourceExists: true}, &calls)
+	cr := initBundle(withPolicies(apisv1alpha1.ManagementActionObserve))
+
+	if _, err := e.Create(context.Background(), cr); err == nil {
+		t.Errorf("e.Create(...): want error, got nil")
+	}
+	if _, err := e.Update(context.Background(), cr); err == nil {
+		t.Errorf("e.Update(...): want error, got nil")
+	}
+	if err := e.Delete(context.Background(), cr); err == nil {
+		t.Errorf("e.Delete(...): want error, got nil")
+	}
+	if len(calls) != 0 {
+		t.Errorf("observe-only external client called %v", calls)
+	}
+}